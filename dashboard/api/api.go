@@ -5,6 +5,8 @@
 // All structures in this package are backwards compatible.
 package api
 
+import "time"
+
 const Version = 1
 
 type BugGroup struct {
@@ -51,3 +53,68 @@ type Commit struct {
 	Repo   string `json:"repo,omitempty"`
 	Branch string `json:"branch,omitempty"`
 }
+
+// Export is one page of the incremental namespace export (see Client.Export and docs/syzbot.md).
+// It contains all bugs that were updated in the (Since, Until] time range.
+type Export struct {
+	Version   int       `json:"version"`
+	Namespace string    `json:"namespace"`
+	Since     time.Time `json:"since"`
+	// Until should be passed as Since to the next incremental export.
+	Until time.Time   `json:"until"`
+	Bugs  []ExportBug `json:"bugs,omitempty"`
+	// If non-empty, there are more bugs in the time range, they can be queried with this cursor.
+	// A bug may be repeated in several pages, the last copy is the most recent.
+	NextCursor string `json:"next-cursor,omitempty"`
+}
+
+// Possible values of ExportBug.Status.
+const (
+	ExportStatusOpen    = "open"
+	ExportStatusFixed   = "fixed"
+	ExportStatusInvalid = "invalid"
+	ExportStatusDup     = "dup"
+)
+
+type ExportBug struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Link   string `json:"link"`
+	Status string `json:"status"`
+	// ID of the canonical bug for dups.
+	DupOf      string    `json:"dup-of,omitempty"`
+	FirstCrash time.Time `json:"first-crash"`
+	LastCrash  time.Time `json:"last-crash"`
+	NumCrashes int64     `json:"num-crashes"`
+	// Zero for open bugs.
+	Closed time.Time `json:"closed"`
+	// The last time the bug was changed in a way that is reflected in the export.
+	Updated time.Time `json:"updated"`
+	// The best found reproducer type: "c", "syz" or empty.
+	ReproLevel string   `json:"repro-level,omitempty"`
+	Subsystems []string `json:"subsystems,omitempty"`
+	// All other labels in the "name" or "name:value" form.
+	Labels         []string      `json:"labels,omitempty"`
+	FixCommits     []Commit      `json:"fix-commits,omitempty"`
+	CauseBisection *Bisection    `json:"cause-bisection,omitempty"`
+	FixBisection   *Bisection    `json:"fix-bisection,omitempty"`
+	Discussions    []string      `json:"discussions,omitempty"`
+	Crashes        []ExportCrash `json:"crashes,omitempty"`
+}
+
+type ExportCrash struct {
+	Crash
+	Time         time.Time `json:"time"`
+	Manager      string    `json:"manager,omitempty"`
+	KernelRepo   string    `json:"kernel-repo,omitempty"`
+	KernelBranch string    `json:"kernel-branch,omitempty"`
+	CrashLogLink string    `json:"crash-log-link,omitempty"`
+}
+
+type Bisection struct {
+	// A single commit for a successful bisection, several commits for an inconclusive one.
+	Commits    []Commit  `json:"commits,omitempty"`
+	Time       time.Time `json:"time"`
+	LogLink    string    `json:"log,omitempty"`
+	Confidence float64   `json:"confidence,omitempty"`
+}
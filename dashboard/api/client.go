@@ -85,6 +85,53 @@ func (c *Client) Bug(link string) (*Bug, error) {
 	return bug, c.query(link, bug)
 }
 
+// Export returns all bugs in the namespace that were updated after since
+// (all bugs if since is zero). The returned Until should be passed as since
+// to the next call to get incremental updates.
+func (c *Client) Export(ns string, since time.Time) (*Export, error) {
+	vals := url.Values{}
+	if !since.IsZero() {
+		vals.Set("since", since.UTC().Format(time.RFC3339Nano))
+	}
+	return c.export(ns, vals)
+}
+
+// ExportSubsystem returns all bugs of the subsystem in the namespace.
+func (c *Client) ExportSubsystem(ns, subsystem string) (*Export, error) {
+	return c.export(ns, url.Values{"subsystem": {subsystem}})
+}
+
+func (c *Client) export(ns string, vals url.Values) (*Export, error) {
+	var ret *Export
+	seen := make(map[string]int)
+	for {
+		page := new(Export)
+		if err := c.query("/"+ns+"/export?"+vals.Encode(), page); err != nil {
+			return nil, err
+		}
+		bugs := page.Bugs
+		if ret == nil {
+			ret = page
+			ret.Bugs = nil
+			// Query all subsequent pages for the same time range.
+			vals.Set("until", page.Until.UTC().Format(time.RFC3339Nano))
+		}
+		for _, bug := range bugs {
+			if idx, ok := seen[bug.ID]; ok {
+				ret.Bugs[idx] = bug
+				continue
+			}
+			seen[bug.ID] = len(ret.Bugs)
+			ret.Bugs = append(ret.Bugs, bug)
+		}
+		if page.NextCursor == "" {
+			ret.NextCursor = ""
+			return ret, nil
+		}
+		vals.Set("cursor", page.NextCursor)
+	}
+}
+
 func (c *Client) Text(query string) ([]byte, error) {
 	queryURL, err := c.queryURL(query)
 	if err != nil {
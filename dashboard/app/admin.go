@@ -61,6 +61,7 @@ func dropNamespace(c context.Context, w http.ResponseWriter, r *http.Request) er
 		{textReproC, ""},
 		{textReproSyz, ""},
 		{textKernelConfig, ""},
+		{textBuildCommits, ""},
 		{"Job", ""},
 		{textLog, ""},
 		{textError, ""},
@@ -55,6 +55,7 @@ var apiHandlers = map[string]APIHandler{
 	"new_test_job":          apiNewTestJob,
 	"needed_assets":         apiNeededAssetsList,
 	"load_full_bug":         apiLoadFullBug,
+	"related_bugs":          apiRelatedBugs,
 	"save_discussion":       apiSaveDiscussion,
 	"create_upload_url":     apiCreateUploadURL,
 	"save_coverage":         gcsPayloadHandler(apiSaveCoverage),
@@ -596,6 +597,17 @@ func uploadBuild(c context.Context, now time.Time, ns string, req *dashapi.Build
 		KernelConfig:        configID,
 		Assets:              newAssets,
 	}
+	if len(req.KernelCommits) != 0 {
+		data, err := json.Marshal(req.KernelCommits)
+		if err != nil {
+			return nil, false, err
+		}
+		if build.KernelCommits, err = putText(c, ns, textBuildCommits, data); err != nil {
+			return nil, false, err
+		}
+		build.KernelCommitsBase = req.KernelCommitsBase
+		build.KernelCommitsTruncated = req.KernelCommitsTruncated
+	}
 	if _, err := db.Put(c, buildKey(c, ns, req.ID), build); err != nil {
 		return nil, false, err
 	}
@@ -887,6 +899,13 @@ func reportCrash(c context.Context, build *Build, req *dashapi.Crash) (*Bug, err
 			return nil, err
 		}
 	}
+	var commitRange *BugCommitRange
+	if bug.NumCrashes == 0 && !req.Corrupted && !req.Suppressed && getNsConfig(c, ns).FindCommitRanges {
+		commitRange, err = findBugCommitRange(c, build, req.Report)
+		if err != nil {
+			log.Errorf(c, "%q: failed to find commit range: %s", bug.Title, err)
+		}
+	}
 
 	tx := func(c context.Context) error {
 		bug = new(Bug)
@@ -894,6 +913,9 @@ func reportCrash(c context.Context, build *Build, req *dashapi.Crash) (*Bug, err
 			return fmt.Errorf("failed to get bug: %w", err)
 		}
 		bug.LastTime = now
+		if commitRange != nil && bug.CommitRange.Bad == "" {
+			bug.CommitRange = *commitRange
+		}
 		if save {
 			bug.LastSavedCrash = now
 		}
@@ -901,6 +923,7 @@ func reportCrash(c context.Context, build *Build, req *dashapi.Crash) (*Bug, err
 			bug.NumRepro++
 			bug.LastReproTime = now
 		}
+		prevReproLevel := bug.ReproLevel
 		bug.ReproLevel = max(bug.ReproLevel, reproLevel)
 		bug.HeadReproLevel = max(bug.HeadReproLevel, reproLevel)
 		if len(req.Report) != 0 {
@@ -909,12 +932,17 @@ func reportCrash(c context.Context, build *Build, req *dashapi.Crash) (*Bug, err
 		if calculateSubsystems {
 			bug.SetAutoSubsystems(c, newSubsystems, now, getNsConfig(c, ns).Subsystems.Revision)
 		}
-		bug.increaseCrashStats(now)
+		bug.increaseCrashStats(now, build.Manager)
 		bug.HappenedOn = mergeString(bug.HappenedOn, build.Manager)
 		// Migration of older entities (for new bugs Title is always in MergedTitles).
 		bug.MergedTitles = mergeString(bug.MergedTitles, bug.Title)
 		bug.MergedTitles = mergeString(bug.MergedTitles, req.Title)
 		bug.AltTitles = mergeStringList(bug.AltTitles, req.AltTitles)
+		// Prefer stacks from crashes with reproducers, they are more reliable.
+		if len(req.Report) != 0 && (len(bug.StackFrames) == 0 || reproLevel > prevReproLevel) &&
+			!req.Corrupted && !req.Suppressed {
+			bug.StackFrames = crashStackFrames(req.Report)
+		}
 		if _, err = db.Put(c, bugKey, bug); err != nil {
 			return fmt.Errorf("failed to put bug: %w", err)
 		}
@@ -976,9 +1004,10 @@ func saveCrash(c context.Context, ns string, req *dashapi.Crash, bug *Bug, bugKe
 		Maintainers: email.MergeEmailLists(req.Maintainers,
 			GetEmails(req.Recipients, dashapi.To),
 			GetEmails(req.Recipients, dashapi.Cc)),
-		ReproOpts: req.ReproOpts,
-		Flags:     int64(req.Flags),
-		Assets:    assets,
+		ReproOpts:        req.ReproOpts,
+		ReproReliability: req.ReproReliability,
+		Flags:            int64(req.Flags),
+		Assets:           assets,
 		ReportElements: CrashReportElements{
 			GuiltyFiles: req.GuiltyFiles,
 		},
@@ -1218,7 +1247,9 @@ func apiManagerStats(c context.Context, ns string, payload io.Reader) (interface
 		return nil, fmt.Errorf("failed to unmarshal request: %w", err)
 	}
 	now := timeNow(c)
+	var currentBuild string
 	err := updateManager(c, ns, req.Name, func(mgr *Manager, stats *ManagerStats) error {
+		currentBuild = mgr.CurrentBuild
 		mgr.Link = req.Addr
 		mgr.LastAlive = now
 		mgr.CurrentUpTime = req.UpTime
@@ -1234,7 +1265,26 @@ func apiManagerStats(c context.Context, ns string, payload io.Reader) (interface
 		stats.TriagedPCs = max(stats.TriagedPCs, int64(req.TriagedPCs))
 		return nil
 	})
-	return nil, err
+	if err != nil || req.FuzzingTime <= 0 || currentBuild == "" {
+		return nil, err
+	}
+	return nil, addBuildFuzzingTime(c, ns, currentBuild, req.FuzzingTime)
+}
+
+// addBuildFuzzingTime accounts the fuzzing time to the build (see findBugCommitRange).
+func addBuildFuzzingTime(c context.Context, ns, id string, fuzzingTime time.Duration) error {
+	tx := func(c context.Context) error {
+		build, err := loadBuild(c, ns, id)
+		if err != nil {
+			return err
+		}
+		build.FuzzingTime += fuzzingTime
+		if _, err := db.Put(c, buildKey(c, ns, id), build); err != nil {
+			return fmt.Errorf("failed to put build: %w", err)
+		}
+		return nil
+	}
+	return runInTransaction(c, tx, nil)
 }
 
 func apiUpdateReport(c context.Context, ns string, payload io.Reader) (interface{}, error) {
@@ -324,7 +324,10 @@ var testConfig = &GlobalConfig{
 					},
 				},
 			},
-			RetestRepros: true,
+			RetestRepros:      true,
+			FindFixCandidates: true,
+			NotifyCrashSpikes: true,
+			FindCommitRanges:  true,
 			Subsystems: SubsystemsConfig{
 				Service: subsystem.MustMakeService(testSubsystems),
 				Redirect: map[string]string{
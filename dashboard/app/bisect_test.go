@@ -625,7 +625,7 @@ func TestUnreliableBisect(t *testing.T) {
 }
 
 func TestBisectWrong(t *testing.T) {
-	// Test bisection results with BisectResultMerge/BisectResultNoop flags set.
+	// Test bisection results with BisectResultMerge/BisectResultNoop flags set or with low confidence.
 	// If any of these set, the result must not be reported separately,
 	// as part of bug report during upstreamming, nor should affect CC list.
 	c := NewCtx(t)
@@ -636,8 +636,9 @@ func TestBisectWrong(t *testing.T) {
 
 	build := testBuild(1)
 	c.client2.UploadBuild(build)
-	for i := 0; i < 6; i++ {
+	for i := 0; i < 7; i++ {
 		var flags dashapi.JobDoneFlags
+		confidence := 0.99
 		switch i {
 		case 0:
 		case 1:
@@ -650,10 +651,12 @@ func TestBisectWrong(t *testing.T) {
 			flags = dashapi.BisectResultRelease
 		case 5:
 			flags = dashapi.BisectResultIgnore
+		case 6:
+			confidence = 0.5
 		default:
 			t.Fatalf("assign flags")
 		}
-		t.Logf("iteration %v: flags=%v", i, flags)
+		t.Logf("iteration %v: flags=%v confidence=%v", i, flags, confidence)
 
 		crash := testCrashWithRepro(build, i)
 		c.client2.ReportCrash(crash)
@@ -662,10 +665,11 @@ func TestBisectWrong(t *testing.T) {
 		{
 			pollResp := c.client2.pollJobs(build.Manager)
 			done := &dashapi.JobDoneReq{
-				ID:    pollResp.ID,
-				Flags: flags,
-				Build: *build,
-				Log:   []byte("bisect log"),
+				ID:         pollResp.ID,
+				Flags:      flags,
+				Confidence: confidence,
+				Build:      *build,
+				Log:        []byte("bisect log"),
 				Commits: []dashapi.Commit{
 					{
 						Hash:       "111111111111111111111111",
@@ -681,6 +685,7 @@ func TestBisectWrong(t *testing.T) {
 			if i == 0 {
 				msg := c.pollEmailBug()
 				c.expectTrue(strings.Contains(msg.Body, "syzbot has bisected this issue to:"))
+				c.expectTrue(strings.Contains(msg.Body, "confidence:     99%"))
 			} else {
 				c.expectNoEmail()
 			}
@@ -691,6 +696,7 @@ func TestBisectWrong(t *testing.T) {
 			done := &dashapi.JobDoneReq{
 				ID:          pollResp.ID,
 				Flags:       flags,
+				Confidence:  confidence,
 				Build:       *build,
 				Log:         []byte("bisectfix log 4"),
 				CrashTitle:  "bisectfix crash title 4",
@@ -0,0 +1,179 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/vcs"
+)
+
+// Before bisection runs (or if it is not possible at all), a new bug can be attributed
+// to the range of kernel commits between the last build of the manager that was fuzzed
+// long enough without the crash and the build where the crash was first seen.
+// The fuzzing time of a build is the sum of the fuzzing time the manager has reported
+// in its stats while the build was current (see Build.FuzzingTime).
+// syz-ci uploads the list of commits with the touched files for every new build
+// (see dashapi.Build.KernelCommits), so we can also shortlist the commits from the range
+// that touch the files from the crash stack.
+
+const (
+	// A build is considered to have been fuzzed long enough if the manager has reported
+	// at least that much fuzzing time for it.
+	commitRangeMinFuzzing = 12 * time.Hour
+	// Max number of suspected commits we store per bug.
+	maxCommitRangeSuspects = 10
+)
+
+// findBugCommitRange returns the commit range for a new bug first seen on the build,
+// or nil if the range can't be determined.
+func findBugCommitRange(c context.Context, build *Build, report []byte) (*BugCommitRange, error) {
+	if build.Type != BuildNormal || build.KernelCommit == "" {
+		return nil, nil
+	}
+	builds, err := loadBuilds(c, build.Namespace, build.Manager, BuildNormal)
+	if err != nil {
+		return nil, err
+	}
+	pos := -1
+	for i, b := range builds {
+		if b.ID == build.ID {
+			pos = i
+			break
+		}
+	}
+	if pos == -1 {
+		return nil, nil
+	}
+	// Builds after the good one up to the bad one, the newest go first.
+	chain := []*Build{build}
+	var good *Build
+	for _, prev := range builds[pos+1:] {
+		if prev.KernelRepo != build.KernelRepo || prev.KernelBranch != build.KernelBranch {
+			return nil, nil
+		}
+		if prev.FuzzingTime >= commitRangeMinFuzzing {
+			good = prev
+			break
+		}
+		chain = append(chain, prev)
+	}
+	if good == nil || good.KernelCommit == build.KernelCommit {
+		// Either we don't know, or the bug is not caused by kernel changes
+		// (e.g. syzkaller has learned to test something new).
+		return nil, nil
+	}
+	ret := &BugCommitRange{
+		Repo:   build.KernelRepo,
+		Branch: build.KernelBranch,
+		Good:   good.KernelCommit,
+		Bad:    build.KernelCommit,
+	}
+	commits, complete, err := loadCommitRange(c, chain, good.KernelCommit)
+	if err != nil {
+		return nil, err
+	}
+	ret.Incomplete = !complete
+	files := make(map[string]bool)
+	for _, file := range crashStackFiles(report) {
+		files[file] = true
+	}
+	for _, com := range commits {
+		if len(ret.Suspects) >= maxCommitRangeSuspects {
+			break
+		}
+		for _, file := range com.Files {
+			if files[file] {
+				ret.Suspects = append(ret.Suspects, BugCommitRangeSuspect{
+					Hash:  com.Hash,
+					Title: com.Title,
+				})
+				break
+			}
+		}
+	}
+	return ret, nil
+}
+
+// loadCommitRange returns the commits uploaded with the chain of builds (the newest first)
+// that together cover the range from base to the first build. The returned bool is false
+// if some of the commits are not known (then the returned list is partial or empty).
+func loadCommitRange(c context.Context, chain []*Build, base string) ([]dashapi.BuildCommit, bool, error) {
+	var ret []dashapi.BuildCommit
+	complete := true
+	head := chain[0].KernelCommit
+	for _, build := range chain {
+		if build.KernelCommit != head {
+			return nil, false, nil
+		}
+		if build.KernelCommitsBase == "" {
+			// The build is on the same kernel commit as the previous one.
+			continue
+		}
+		data, _, err := getText(c, textBuildCommits, build.KernelCommits)
+		if err != nil {
+			return nil, false, err
+		}
+		var commits []dashapi.BuildCommit
+		if err := json.Unmarshal(data, &commits); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal build commits: %w", err)
+		}
+		ret = append(ret, commits...)
+		complete = complete && !build.KernelCommitsTruncated
+		head = build.KernelCommitsBase
+	}
+	if head != base {
+		return nil, false, nil
+	}
+	return ret, complete, nil
+}
+
+func (cr *BugCommitRange) toDashapi() *dashapi.CommitRange {
+	if cr.Bad == "" {
+		return nil
+	}
+	ret := &dashapi.CommitRange{
+		KernelRepo:   cr.Repo,
+		KernelBranch: cr.Branch,
+		Good:         cr.Good,
+		Bad:          cr.Bad,
+		Incomplete:   cr.Incomplete,
+	}
+	for _, com := range cr.Suspects {
+		ret.Suspects = append(ret.Suspects, dashapi.Commit{
+			Hash:  com.Hash,
+			Title: com.Title,
+			Link:  vcs.CommitLink(cr.Repo, com.Hash),
+		})
+	}
+	return ret
+}
+
+func makeUICommitRange(cr *BugCommitRange) *uiCommitRange {
+	if cr.Bad == "" {
+		return nil
+	}
+	makeCommit := func(hash, title string) *uiCommit {
+		return &uiCommit{
+			Hash:   hash,
+			Repo:   cr.Repo,
+			Branch: cr.Branch,
+			Title:  title,
+			Link:   vcs.CommitLink(cr.Repo, hash),
+		}
+	}
+	ret := &uiCommitRange{
+		Good:       makeCommit(cr.Good, ""),
+		Bad:        makeCommit(cr.Bad, ""),
+		Incomplete: cr.Incomplete,
+	}
+	for _, com := range cr.Suspects {
+		ret.Suspects = append(ret.Suspects, makeCommit(com.Hash, com.Title))
+	}
+	return ret
+}
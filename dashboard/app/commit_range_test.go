@@ -0,0 +1,134 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/email"
+)
+
+func TestBugCommitRange(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	client := c.publicClient
+	build1 := testBuild(1)
+	client.UploadBuild(build1)
+
+	// The first build is fuzzed long enough, then the kernel is updated twice.
+	for i := 0; i < 2; i++ {
+		c.advanceTime(12 * time.Hour)
+		c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+			Name:        build1.Manager,
+			FuzzingTime: 10 * time.Hour,
+		}))
+	}
+	build2 := testBuild(1)
+	build2.ID = "build2"
+	build2.KernelCommit = strings.Repeat("2", 40)
+	build2.KernelCommitsBase = build1.KernelCommit
+	build2.KernelCommits = []dashapi.BuildCommit{
+		{Hash: strings.Repeat("a", 40), Title: "net: tcp fix", Files: []string{"net/ipv4/tcp_output.c"}},
+		{Hash: strings.Repeat("b", 40), Title: "mm: unrelated", Files: []string{"mm/slab.c"}},
+	}
+	client.UploadBuild(build2)
+
+	c.advanceTime(time.Hour)
+	c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+		Name:        build1.Manager,
+		FuzzingTime: time.Hour,
+	}))
+	build3 := testBuild(1)
+	build3.ID = "build3"
+	build3.KernelCommit = strings.Repeat("3", 40)
+	build3.KernelCommitsBase = build2.KernelCommit
+	build3.KernelCommits = []dashapi.BuildCommit{
+		{Hash: strings.Repeat("c", 40), Title: "net: socket cleanup", Files: []string{"net/socket.c"}},
+	}
+	client.UploadBuild(build3)
+
+	crash := testCrash(build3, 1)
+	crash.Report = []byte(testStackReport)
+	client.ReportCrash(crash)
+
+	msg := c.pollEmailBug()
+	c.expectTrue(strings.Contains(msg.Body, `The issue first appeared in the commit range:
+111111111111..333333333333
+Commits from the range that touch the files from the crash stack:
+
+cccccccccccc net: socket cleanup
+aaaaaaaaaaaa net: tcp fix
+`))
+	c.expectTrue(!strings.Contains(msg.Body, "incomplete"))
+
+	_, extBugID, err := email.RemoveAddrContext(msg.Sender)
+	c.expectOK(err)
+	bug, _, _ := c.loadBug(extBugID)
+	c.expectEQ(bug.CommitRange.Good, build1.KernelCommit)
+	c.expectEQ(bug.CommitRange.Bad, build3.KernelCommit)
+	c.expectEQ(len(bug.CommitRange.Suspects), 2)
+}
+
+func TestBugCommitRangeNotFuzzed(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	// The previous build was not fuzzed long enough to say anything:
+	// it was current for a day, but the manager did not fuzz much.
+	client := c.publicClient
+	build1 := testBuild(1)
+	client.UploadBuild(build1)
+	c.advanceTime(24 * time.Hour)
+	c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+		Name:        build1.Manager,
+		FuzzingTime: time.Hour,
+	}))
+	build2 := testBuild(1)
+	build2.ID = "build2"
+	build2.KernelCommit = strings.Repeat("2", 40)
+	client.UploadBuild(build2)
+
+	client.ReportCrash(testCrash(build2, 1))
+	msg := c.pollEmailBug()
+	c.expectTrue(!strings.Contains(msg.Body, "commit range"))
+}
+
+func TestBugCommitRangeTruncated(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	client := c.publicClient
+	build1 := testBuild(1)
+	client.UploadBuild(build1)
+	c.advanceTime(24 * time.Hour)
+	c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+		Name:        build1.Manager,
+		FuzzingTime: 24 * time.Hour,
+	}))
+
+	// syz-ci did not upload all commits since the previous build.
+	build2 := testBuild(1)
+	build2.ID = "build2"
+	build2.KernelCommit = strings.Repeat("2", 40)
+	build2.KernelCommitsBase = build1.KernelCommit
+	build2.KernelCommits = []dashapi.BuildCommit{
+		{Hash: strings.Repeat("a", 40), Title: "mm: unrelated", Files: []string{"mm/slab.c"}},
+	}
+	build2.KernelCommitsTruncated = true
+	client.UploadBuild(build2)
+
+	crash := testCrash(build2, 1)
+	crash.Report = []byte(testStackReport)
+	client.ReportCrash(crash)
+
+	msg := c.pollEmailBug()
+	c.expectTrue(strings.Contains(msg.Body, `The issue first appeared in the commit range:
+111111111111..222222222222
+Not all commits from the range are known, the list of suspects may be incomplete.
+`))
+}
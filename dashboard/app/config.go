@@ -99,6 +99,18 @@ type Config struct {
 	FixBisectionAutoClose bool
 	// If set, dashboard will periodically request repros and revoke no longer working ones.
 	RetestRepros bool
+	// If set, once a retested repro stops working on the latest build of the same tree,
+	// dashboard will request a fix bisection limited to the commits touching the files
+	// from the crash stack and will report its result as a fix candidate.
+	// Requires RetestRepros.
+	FindFixCandidates bool
+	// If set, dashboard will notify about known bugs that suddenly start crashing much more often
+	// (normalized by the fuzzing time) after a new kernel build.
+	NotifyCrashSpikes bool
+	// If set, new bugs are attributed to the range of kernel commits between the last build
+	// that was fuzzed long enough without the crash and the first build with the crash.
+	// The commits from the range that touch the files from the crash stack are reported as suspects.
+	FindCommitRanges bool
 	// If set, dashboard will periodically verify the presence of the missing backports in the
 	// tested kernel trees.
 	RetestMissingBackports bool
@@ -579,6 +591,9 @@ func checkNamespace(ns string, cfg *Config, namespaces, clientNames map[string]b
 	if len(cfg.Reporting) == 0 {
 		panic(fmt.Sprintf("no reporting in namespace %q", ns))
 	}
+	if cfg.FindFixCandidates && !cfg.RetestRepros {
+		panic(fmt.Sprintf("%v: FindFixCandidates requires RetestRepros", ns))
+	}
 	if cfg.TransformCrash == nil {
 		cfg.TransformCrash = func(build *Build, crash *dashapi.Crash) bool {
 			return true
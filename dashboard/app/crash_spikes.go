@@ -0,0 +1,317 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/vcs"
+	"google.golang.org/appengine/v2"
+	db "google.golang.org/appengine/v2/datastore"
+)
+
+// Known bugs sometimes start crashing much more often after a new kernel build
+// (e.g. a new commit made the bug easier to trigger or added another path to it).
+// To detect such cases, for every manager the bug happens on we take its daily crash counts
+// (Bug.ManagerDailyStats), normalize them by the fuzzing time of the manager
+// (ManagerStats.TotalFuzzingTime), look for a single changepoint of the crash rate
+// in the last few days, and attribute the change to the kernel build that the first
+// crashes on the manager after the changepoint happened on.
+// The rate is computed per manager, since managers fuzz different kernels
+// and a spike on one of them would be diluted by the others.
+
+const (
+	// The rate after the changepoint is compared with the rate during that many preceding days.
+	spikeBaselineDays = 14
+	// The changepoint is searched among that many last days.
+	spikeRecentDays = 3
+	// The new crash rate must be at least that many times higher than the baseline rate.
+	spikeMinRatio = 5.0
+	// The bug must crash at least that many times after the changepoint.
+	spikeMinCrashes = 10
+	// The new kernel build must not be older than the changepoint by more than that.
+	spikeBuildSlack = 24 * time.Hour
+	// Prefix of the reporting label that says that we have notified about the spike
+	// (the changepoint date and the ID of the build that caused it follow the prefix).
+	spikeLabelPrefix = "crash-spike:"
+)
+
+// bugDayStats holds the number of bug crashes during a day and the corresponding fuzzing time.
+type bugDayStats struct {
+	Crashes     int
+	FuzzingTime time.Duration
+}
+
+type crashRateChange struct {
+	// Index of the first day with the new crash rate.
+	Day int
+	// Crashes per hour of fuzzing before and after the change.
+	BaselineRate float64
+	Rate         float64
+}
+
+// detectCrashRateChange looks for an increase of the crash rate in days (from the oldest to the newest).
+// Only changes within the last spikeRecentDays days are considered. Among the candidate changepoints
+// that satisfy the thresholds, we choose the one that maximizes the log-likelihood ratio
+// of the two-rate Poisson model over the single-rate one.
+func detectCrashRateChange(days []bugDayStats) *crashRateChange {
+	var best *crashRateChange
+	bestLLR := 0.0
+	total := sumDayStats(days)
+	for split := max(1, len(days)-spikeRecentDays); split < len(days); split++ {
+		before, after := sumDayStats(days[:split]), sumDayStats(days[split:])
+		if before.FuzzingTime <= 0 || after.FuzzingTime <= 0 || after.Crashes < spikeMinCrashes {
+			continue
+		}
+		rate := float64(after.Crashes) / after.FuzzingTime.Hours()
+		// Assume there was at least 1 crash before to not react on bugs that were just rare.
+		if rate < spikeMinRatio*float64(max(before.Crashes, 1))/before.FuzzingTime.Hours() {
+			continue
+		}
+		llr := poissonLogLikelihood(before) + poissonLogLikelihood(after) - poissonLogLikelihood(total)
+		if best == nil || llr > bestLLR {
+			bestLLR = llr
+			best = &crashRateChange{
+				Day:          split,
+				BaselineRate: float64(before.Crashes) / before.FuzzingTime.Hours(),
+				Rate:         rate,
+			}
+		}
+	}
+	return best
+}
+
+func sumDayStats(days []bugDayStats) bugDayStats {
+	var ret bugDayStats
+	for _, day := range days {
+		ret.Crashes += day.Crashes
+		ret.FuzzingTime += day.FuzzingTime
+	}
+	return ret
+}
+
+// poissonLogLikelihood returns the log-likelihood (without the constant term)
+// of the observed crashes given the maximum likelihood estimation of the rate.
+func poissonLogLikelihood(stats bugDayStats) float64 {
+	if stats.Crashes == 0 {
+		return 0
+	}
+	n := float64(stats.Crashes)
+	return n*math.Log(n/stats.FuzzingTime.Hours()) - n
+}
+
+// findCrashSpike returns the crash spike of the bug, the date of the changepoint and the ID of the build
+// that caused it, or nil if the bug crash rate did not suddenly increase after a new kernel build.
+func findCrashSpike(c context.Context, bug *Bug) (*dashapi.CrashSpike, int, string, error) {
+	const days = spikeBaselineDays + spikeRecentDays
+	now := timeNow(c)
+	start := timeDate(now.Add(-(days - 1) * 24 * time.Hour))
+	// Cheap checks before we query anything.
+	if bug.FirstTime.IsZero() || timeDate(bug.FirstTime) >= start {
+		return nil, 0, "", nil
+	}
+	crashes := bug.managerCrashCounts(now, days)
+	if len(crashes) == 0 {
+		return nil, 0, "", nil
+	}
+	stats, err := loadBugDayStats(c, bug.Namespace, crashes, now, days)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	var change *crashRateChange
+	manager := ""
+	for _, mgr := range slices.Sorted(maps.Keys(stats)) {
+		mgrChange := detectCrashRateChange(stats[mgr])
+		if mgrChange != nil && (change == nil || mgrChange.Rate > change.Rate) {
+			change, manager = mgrChange, mgr
+		}
+	}
+	if change == nil {
+		return nil, 0, "", nil
+	}
+	changeDate := timeDate(now.Add(-time.Duration(days-1-change.Day) * 24 * time.Hour))
+	changeTime := dateTime(changeDate)
+	build, prevBuild, err := loadCrashSpikeBuilds(c, bug, manager, changeTime)
+	if err != nil || build == nil {
+		return nil, 0, "", err
+	}
+	return &dashapi.CrashSpike{
+		Date:         changeTime,
+		BaselineRate: change.BaselineRate,
+		Rate:         change.Rate,
+		KernelRepo:   build.KernelRepo,
+		KernelBranch: build.KernelBranch,
+		GoodCommit:   prevBuild.KernelCommit,
+		BadCommit:    build.KernelCommit,
+		Link:         vcs.LogLink(build.KernelRepo, build.KernelCommit),
+	}, changeDate, build.ID, nil
+}
+
+// managerCrashCounts returns the daily crash counts for the days ending with now
+// of the managers that crashed enough times recently to have a spike.
+func (bug *Bug) managerCrashCounts(now time.Time, days int) map[string][]int {
+	ret := make(map[string][]int)
+	recent := make(map[string]int)
+	recentStart := timeDate(now.Add(-(spikeRecentDays - 1) * 24 * time.Hour))
+	for _, item := range bug.ManagerDailyStats {
+		if item.Date >= recentStart {
+			recent[item.Manager] += item.CrashCount
+		}
+	}
+	for _, item := range bug.ManagerDailyStats {
+		if recent[item.Manager] < spikeMinCrashes {
+			continue
+		}
+		day := days - 1 - int(dateTime(timeDate(now)).Sub(dateTime(item.Date))/(24*time.Hour))
+		if day < 0 || day >= days {
+			continue
+		}
+		if ret[item.Manager] == nil {
+			ret[item.Manager] = make([]int, days)
+		}
+		ret[item.Manager][day] += item.CrashCount
+	}
+	return ret
+}
+
+// loadBugDayStats returns the bug crash counts and the fuzzing time of each manager for the days ending with now.
+func loadBugDayStats(c context.Context, ns string, crashes map[string][]int, now time.Time,
+	days int) (map[string][]bugDayStats, error) {
+	var dates []int
+	for i := days - 1; i >= 0; i-- {
+		dates = append(dates, timeDate(now.Add(-time.Duration(i)*24*time.Hour)))
+	}
+	managers := slices.Sorted(maps.Keys(crashes))
+	var keys []*db.Key
+	for _, mgr := range managers {
+		parent := mgrKey(c, ns, mgr)
+		for _, date := range dates {
+			keys = append(keys, db.NewKey(c, "ManagerStats", "", int64(date), parent))
+		}
+	}
+	stats := make([]*ManagerStats, len(keys))
+	if err := db.GetMulti(c, keys, stats); err != nil {
+		var merr appengine.MultiError
+		if !errors.As(err, &merr) {
+			return nil, fmt.Errorf("failed to fetch manager stats: %w", err)
+		}
+		for _, objErr := range merr {
+			if objErr != nil && objErr != db.ErrNoSuchEntity {
+				return nil, fmt.Errorf("failed to fetch manager stats: %w", objErr)
+			}
+		}
+	}
+	ret := make(map[string][]bugDayStats)
+	for i, mgr := range managers {
+		days := make([]bugDayStats, len(dates))
+		for j := range days {
+			days[j].Crashes = crashes[mgr][j]
+			if stat := stats[i*len(dates)+j]; stat != nil {
+				days[j].FuzzingTime = stat.TotalFuzzingTime
+			}
+		}
+		ret[mgr] = days
+	}
+	return ret, nil
+}
+
+// loadCrashSpikeBuilds returns the build of the first bug crash on the manager after the changepoint
+// and the preceding build of the same manager. If the build is not new or the kernel
+// commit has not changed, it returns nil.
+func loadCrashSpikeBuilds(c context.Context, bug *Bug, manager string, changeTime time.Time) (*Build, *Build, error) {
+	crashes, _, err := queryCrashesForBug(c, bug.key(c), maxCrashes())
+	if err != nil {
+		return nil, nil, err
+	}
+	var first *Crash
+	for _, crash := range crashes {
+		if crash.Manager == manager && !crash.Time.Before(changeTime) &&
+			(first == nil || crash.Time.Before(first.Time)) {
+			first = crash
+		}
+	}
+	if first == nil {
+		return nil, nil, nil
+	}
+	build, err := loadBuild(c, bug.Namespace, first.BuildID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if build.Time.Before(changeTime.Add(-spikeBuildSlack)) {
+		return nil, nil, nil
+	}
+	builds, err := loadBuilds(c, bug.Namespace, build.Manager, BuildNormal)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, prev := range builds {
+		if !prev.Time.Before(build.Time) {
+			continue
+		}
+		if prev.KernelCommit == "" || prev.KernelCommit == build.KernelCommit ||
+			prev.KernelRepo != build.KernelRepo || prev.KernelBranch != build.KernelBranch {
+			return nil, nil, nil
+		}
+		return build, prev, nil
+	}
+	return nil, nil, nil
+}
+
+func createCrashSpikeNotification(c context.Context, bug *Bug, reporting *Reporting,
+	bugReporting *BugReporting) (*dashapi.BugNotification, error) {
+	if !getNsConfig(c, bug.Namespace).NotifyCrashSpikes {
+		return nil, nil
+	}
+	// The check is cheap, so do it before we query anything.
+	if crashSpikeNotified(c, bugReporting, "") {
+		return nil, nil
+	}
+	spike, date, buildID, err := findCrashSpike(c, bug)
+	if err != nil || spike == nil {
+		return nil, err
+	}
+	if crashSpikeNotified(c, bugReporting, buildID) {
+		return nil, nil
+	}
+	label := fmt.Sprintf("%v%v:%v", spikeLabelPrefix, date, buildID)
+	notif, err := createNotification(c, dashapi.BugNotifCrashSpike, true, "", bug, reporting, bugReporting)
+	if err != nil {
+		return nil, err
+	}
+	notif.Label = label
+	notif.CrashSpike = spike
+	return notif, nil
+}
+
+// crashSpikeNotified returns whether we have notified about a spike caused by the build
+// or, if buildID is empty, about a spike that findCrashSpike may still detect now.
+func crashSpikeNotified(c context.Context, bugReporting *BugReporting, buildID string) bool {
+	recentStart := timeDate(timeNow(c).Add(-(spikeRecentDays - 1) * 24 * time.Hour))
+	for _, label := range bugReporting.GetLabels() {
+		rest, ok := strings.CutPrefix(label, spikeLabelPrefix)
+		if !ok {
+			continue
+		}
+		dateStr, id, _ := strings.Cut(rest, ":")
+		if buildID != "" {
+			if id == buildID {
+				return true
+			}
+			continue
+		}
+		if date, err := strconv.Atoi(dateStr); err == nil && date >= recentStart {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectCrashRateChange(t *testing.T) {
+	days := func(crashes ...int) []bugDayStats {
+		var ret []bugDayStats
+		for _, n := range crashes {
+			ret = append(ret, bugDayStats{Crashes: n, FuzzingTime: 10 * time.Hour})
+		}
+		return ret
+	}
+	// Stable crash rate.
+	assert.Nil(t, detectCrashRateChange(days(5, 4, 6, 5, 5, 6, 4, 5, 5, 5, 6, 4, 5, 5, 6, 5, 4)))
+	// A big relative increase, but too few crashes.
+	assert.Nil(t, detectCrashRateChange(days(0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 4)))
+	// The rate increased 2 days ago.
+	assert.Equal(t, &crashRateChange{
+		Day:          15,
+		BaselineRate: 0.1,
+		Rate:         2,
+	}, detectCrashRateChange(days(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 20, 20)))
+	// The same number of crashes, but the fuzzing time increased as well.
+	stats := days(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 20, 20)
+	stats[15].FuzzingTime *= 20
+	stats[16].FuzzingTime *= 20
+	assert.Nil(t, detectCrashRateChange(stats))
+	// The increase is too old.
+	assert.Nil(t, detectCrashRateChange(days(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 20, 20, 20, 20, 20, 20, 20)))
+	// No fuzzing time data.
+	assert.Nil(t, detectCrashRateChange(make([]bugDayStats, 17)))
+}
+
+func TestManagerCrashCounts(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	bug := &Bug{}
+	for day := 29; day >= 0; day-- {
+		when := now.Add(-time.Duration(day) * 24 * time.Hour)
+		bug.increaseCrashStats(when, "rare")
+		for i := 0; i < 5; i++ {
+			bug.increaseCrashStats(when, "frequent")
+		}
+	}
+	// Only the recent days are kept.
+	assert.Len(t, bug.ManagerDailyStats, 2*(spikeBaselineDays+spikeRecentDays))
+	counts := bug.managerCrashCounts(now, 4)
+	// The rare manager did not crash enough recently.
+	assert.Equal(t, map[string][]int{
+		"frequent": {5, 5, 5, 5},
+	}, counts)
+}
+
+func TestCrashSpikeNotification(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	client := c.publicClient
+	build := testBuild(1)
+	client.UploadBuild(build)
+	client.ReportCrash(testCrash(build, 1))
+	c.pollEmailBug()
+
+	// The bug crashes once per 10 hours of fuzzing.
+	for day := 0; day < spikeBaselineDays+2; day++ {
+		c.advanceTime(24 * time.Hour)
+		c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+			Name:        build.Manager,
+			FuzzingTime: 10 * time.Hour,
+		}))
+		client.ReportCrash(testCrash(build, 1))
+	}
+	c.expectNoEmail()
+
+	// After a new kernel build, it crashes 20 times per 10 hours.
+	c.advanceTime(24 * time.Hour)
+	newBuild := testBuild(1)
+	newBuild.ID = "new-build"
+	newBuild.KernelCommit = strings.Repeat("2", 40)
+	client.UploadBuild(newBuild)
+	c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+		Name:        build.Manager,
+		FuzzingTime: 10 * time.Hour,
+	}))
+	for i := 0; i < 20; i++ {
+		c.advanceTime(time.Minute)
+		client.ReportCrash(testCrash(newBuild, 1))
+	}
+
+	msg := c.pollEmailBug()
+	c.expectTrue(strings.Contains(msg.Body, "This bug started to crash much more often after a kernel update."))
+	c.expectTrue(strings.Contains(msg.Body, "commit range: 111111111111..222222222222"))
+	// We don't notify about the same spike twice.
+	c.advanceTime(time.Hour)
+	c.expectNoEmail()
+}
+
+func TestCrashSpikeNotificationPerManager(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	client := c.publicClient
+	build := testBuild(1)
+	client.UploadBuild(build)
+	otherBuild := testBuild(2)
+	client.UploadBuild(otherBuild)
+	client.ReportCrash(testCrash(build, 1))
+	c.pollEmailBug()
+
+	// Both managers crash once per 10 hours of fuzzing, but the other one fuzzes 10x more.
+	for day := 0; day < spikeBaselineDays+2; day++ {
+		c.advanceTime(24 * time.Hour)
+		c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+			Name:        build.Manager,
+			FuzzingTime: 10 * time.Hour,
+		}))
+		c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+			Name:        otherBuild.Manager,
+			FuzzingTime: 100 * time.Hour,
+		}))
+		client.ReportCrash(testCrash(build, 1))
+		for i := 0; i < 10; i++ {
+			client.ReportCrash(testCrash(otherBuild, 1))
+		}
+	}
+	c.expectNoEmail()
+
+	// After a new kernel build, the first manager crashes 20 times per 10 hours.
+	// In total that's not even 3x more crashes, but the rate of the manager went up 20x.
+	c.advanceTime(24 * time.Hour)
+	newBuild := testBuild(1)
+	newBuild.ID = "new-build"
+	newBuild.KernelCommit = strings.Repeat("2", 40)
+	client.UploadBuild(newBuild)
+	c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+		Name:        build.Manager,
+		FuzzingTime: 10 * time.Hour,
+	}))
+	c.expectOK(client.UploadManagerStats(&dashapi.ManagerStatsReq{
+		Name:        otherBuild.Manager,
+		FuzzingTime: 100 * time.Hour,
+	}))
+	for i := 0; i < 20; i++ {
+		c.advanceTime(time.Minute)
+		client.ReportCrash(testCrash(newBuild, 1))
+	}
+	for i := 0; i < 10; i++ {
+		client.ReportCrash(testCrash(otherBuild, 1))
+	}
+
+	msg := c.pollEmailBug()
+	c.expectTrue(strings.Contains(msg.Body, "commit range: 111111111111..222222222222"))
+}
@@ -83,6 +83,12 @@ type Build struct {
 	KernelConfig        int64     // reference to KernelConfig text entity
 	Assets              []Asset   // build-related assets
 	AssetsLastCheck     time.Time // the last time we checked the assets for deprecation
+	// Kernel commits since the previous build of the manager (see dashapi.Build.KernelCommits).
+	KernelCommitsBase      string `datastore:",noindex"`
+	KernelCommits          int64  // reference to BuildCommits text entity
+	KernelCommitsTruncated bool   `datastore:",noindex"`
+	// Total fuzzing time reported by the manager while this was its current build.
+	FuzzingTime time.Duration `datastore:",noindex"`
 }
 
 type Bug struct {
@@ -128,9 +134,32 @@ type Bug struct {
 	Labels         []BugLabel
 	DiscussionInfo []BugDiscussionInfo
 	TreeTests      BugTreeTestInfo
-	// FixCandidateJob holds the key of the latest successful cross-tree fix bisection job.
+	// FixCandidateJob holds the key of the latest successful cross-tree or fix candidate bisection job.
 	FixCandidateJob string
 	ReproAttempts   []BugReproAttempt
+	// StackFrames are normalized top frames of the crash stack used to find related bugs
+	// (see crashStackFrames).
+	StackFrames []string
+	// CommitRange is where the bug has likely been introduced (see findBugCommitRange).
+	CommitRange BugCommitRange
+	// ManagerDailyStats are crash counts per manager for the last days (see increaseManagerCrashStats).
+	ManagerDailyStats []BugManagerDailyStats
+}
+
+type BugCommitRange struct {
+	Repo   string `datastore:",noindex"`
+	Branch string `datastore:",noindex"`
+	Good   string `datastore:",noindex"`
+	Bad    string `datastore:",noindex"`
+	// Commits from the range that touch the crash stack files.
+	Suspects []BugCommitRangeSuspect
+	// Not all commits from the range are known, so some suspects may be missing.
+	Incomplete bool `datastore:",noindex"`
+}
+
+type BugCommitRangeSuspect struct {
+	Hash  string `datastore:",noindex"`
+	Title string `datastore:",noindex"`
 }
 
 type BugTreeTestInfo struct {
@@ -285,6 +314,12 @@ type BugDailyStats struct {
 	CrashCount int
 }
 
+type BugManagerDailyStats struct {
+	Manager    string
+	Date       int // YYYYMMDD
+	CrashCount int
+}
+
 type Commit struct {
 	Hash       string
 	Title      string
@@ -347,24 +382,25 @@ func (r *BugReporting) AddLabel(label string) {
 type Crash struct {
 	// May be different from bug.Title due to AltTitles.
 	// May be empty for old bugs, in such case bug.Title is the right title.
-	Title           string
-	Manager         string
-	BuildID         string
-	Time            time.Time
-	Reported        time.Time // set if this crash was ever reported
-	References      []CrashReference
-	Maintainers     []string            `datastore:",noindex"`
-	Log             int64               // reference to CrashLog text entity
-	Flags           int64               // properties of the Crash
-	Report          int64               // reference to CrashReport text entity
-	ReportElements  CrashReportElements // parsed parts of the crash report
-	ReproOpts       []byte              `datastore:",noindex"`
-	ReproSyz        int64               // reference to ReproSyz text entity
-	ReproC          int64               // reference to ReproC text entity
-	ReproIsRevoked  bool                // the repro no longer triggers the bug on HEAD
-	ReproLog        int64               // reference to ReproLog text entity
-	LastReproRetest time.Time           // the last time when the repro was re-checked
-	MachineInfo     int64               // Reference to MachineInfo text entity.
+	Title            string
+	Manager          string
+	BuildID          string
+	Time             time.Time
+	Reported         time.Time // set if this crash was ever reported
+	References       []CrashReference
+	Maintainers      []string            `datastore:",noindex"`
+	Log              int64               // reference to CrashLog text entity
+	Flags            int64               // properties of the Crash
+	Report           int64               // reference to CrashReport text entity
+	ReportElements   CrashReportElements // parsed parts of the crash report
+	ReproOpts        []byte              `datastore:",noindex"`
+	ReproSyz         int64               // reference to ReproSyz text entity
+	ReproC           int64               // reference to ReproC text entity
+	ReproIsRevoked   bool                // the repro no longer triggers the bug on HEAD
+	ReproLog         int64               // reference to ReproLog text entity
+	ReproReliability float64             `datastore:",noindex"` // 0 if unknown
+	LastReproRetest  time.Time           // the last time when the repro was re-checked
+	MachineInfo      int64               // Reference to MachineInfo text entity.
 	// Custom crash priority for reporting (greater values are higher priority).
 	// For example, a crash in mainline kernel has higher priority than a crash in a side branch.
 	// For historical reasons this is called ReportLen.
@@ -590,6 +626,9 @@ type Job struct {
 	// By default, bisection starts from the revision of the associated crash.
 	// The BisectFrom field can override this.
 	BisectFrom string
+	// If set, fix bisection only considers commits that touch these files.
+	// Such jobs are created when a retested reproducer stops working (see createFixCandidateJob).
+	BisectPaths []string `datastore:",noindex"`
 
 	// Result of execution:
 	CrashTitle  string // if empty, we did not hit crash during testing
@@ -600,6 +639,9 @@ type Job struct {
 	Log         int64 // reference to Log text entity
 	Error       int64 // reference to Error text entity, if set job failed
 	Flags       dashapi.JobDoneFlags
+	Confidence  float64 // probability of the bisection result being correct, 0 if unknown
+	// For first-parent bisection, the merge that brought the culprit into the tree.
+	MergeCommit Commit
 
 	Reported         bool   // have we reported result back to user?
 	InvalidatedBy    string // user who marked this bug as invalid, empty by default
@@ -641,16 +683,27 @@ func (job *Job) isUnreliableBisect() bool {
 	}
 	// If a bisection points to a merge or a commit that does not affect the kernel binary,
 	// it is considered an unreliable/wrong result and should not be reported in emails.
+	// The same goes for results that are likely wrong because of a flaky reproducer.
 	return job.Flags&dashapi.BisectResultMerge != 0 ||
 		job.Flags&dashapi.BisectResultNoop != 0 ||
 		job.Flags&dashapi.BisectResultRelease != 0 ||
-		job.Flags&dashapi.BisectResultIgnore != 0
+		job.Flags&dashapi.BisectResultIgnore != 0 ||
+		job.Confidence != 0 && job.Confidence < minBisectConfidence
 }
 
+// Bisection results with a lower confidence are not reported.
+const minBisectConfidence = 0.66
+
 func (job *Job) IsCrossTree() bool {
 	return job.MergeBaseRepo != "" && job.IsBisection()
 }
 
+// IsFixCandidate says whether the job only looks for a suspected fix commit:
+// either in a different tree or among the commits touching the crash stack files.
+func (job *Job) IsFixCandidate() bool {
+	return job.IsCrossTree() || job.Type == JobBisectFix && len(job.BisectPaths) != 0
+}
+
 // Text holds text blobs (crash logs, reports, reproducers, etc).
 type Text struct {
 	Namespace string
@@ -669,6 +722,7 @@ const (
 	textError        = "Error"
 	textReproLog     = "ReproLog"
 	textFsckLog      = "FsckLog"
+	textBuildCommits = "BuildCommits"
 )
 
 const (
@@ -966,7 +1020,7 @@ func (bug *Bug) getCommitInfo(i int) Commit {
 	return Commit{}
 }
 
-func (bug *Bug) increaseCrashStats(now time.Time) {
+func (bug *Bug) increaseCrashStats(now time.Time, manager string) {
 	bug.NumCrashes++
 	date := timeDate(now)
 	if len(bug.DailyStats) == 0 || bug.DailyStats[len(bug.DailyStats)-1].Date < date {
@@ -983,6 +1037,30 @@ func (bug *Bug) increaseCrashStats(now time.Time) {
 	if len(bug.DailyStats) > maxBugHistoryDays {
 		bug.DailyStats = bug.DailyStats[len(bug.DailyStats)-maxBugHistoryDays:]
 	}
+	bug.increaseManagerCrashStats(now, manager)
+}
+
+// increaseManagerCrashStats updates the per-manager crash counts.
+// They are only needed to detect crash spikes, so we keep only the days that findCrashSpike looks at.
+func (bug *Bug) increaseManagerCrashStats(now time.Time, manager string) {
+	date := timeDate(now)
+	oldest := timeDate(now.Add(-(spikeBaselineDays + spikeRecentDays - 1) * 24 * time.Hour))
+	found := false
+	var stats []BugManagerDailyStats
+	for _, item := range bug.ManagerDailyStats {
+		if item.Date < oldest {
+			continue
+		}
+		if item.Manager == manager && item.Date == date {
+			item.CrashCount++
+			found = true
+		}
+		stats = append(stats, item)
+	}
+	if !found {
+		stats = append(stats, BugManagerDailyStats{manager, date, 1})
+	}
+	bug.ManagerDailyStats = stats
 }
 
 func (bug *Bug) dailyStatsTail(from time.Time) []BugDailyStats {
@@ -285,7 +285,7 @@ func invalidateBisection(c context.Context, jobKey *db.Key, restart bool) error
 			}
 			if job.Type == JobBisectCause {
 				bug.BisectCause = BisectNot
-			} else if job.IsCrossTree() {
+			} else if job.IsFixCandidate() {
 				bug.FixCandidateJob = ""
 			} else if job.Type == JobBisectFix {
 				bug.BisectFix = BisectNot
@@ -880,19 +880,21 @@ func createJobResp(c context.Context, job *Job, jobKey *db.Key) (*dashapi.JobPol
 		return nil, true, nil
 	}
 	resp := &dashapi.JobPollResp{
-		ID:              jobID,
-		Manager:         job.Manager,
-		KernelRepo:      job.KernelRepo,
-		KernelBranch:    job.KernelBranch,
-		MergeBaseRepo:   job.MergeBaseRepo,
-		MergeBaseBranch: job.MergeBaseBranch,
-		KernelCommit:    job.BisectFrom,
-		KernelConfig:    kernelConfig,
-		SyzkallerCommit: build.SyzkallerCommit,
-		Patch:           patch,
-		ReproOpts:       crash.ReproOpts,
-		ReproSyz:        reproSyz,
-		ReproC:          reproC,
+		ID:               jobID,
+		Manager:          job.Manager,
+		KernelRepo:       job.KernelRepo,
+		KernelBranch:     job.KernelBranch,
+		MergeBaseRepo:    job.MergeBaseRepo,
+		MergeBaseBranch:  job.MergeBaseBranch,
+		KernelCommit:     job.BisectFrom,
+		KernelConfig:     kernelConfig,
+		SyzkallerCommit:  build.SyzkallerCommit,
+		Patch:            patch,
+		ReproOpts:        crash.ReproOpts,
+		ReproSyz:         reproSyz,
+		ReproC:           reproC,
+		ReproReliability: crash.ReproReliability,
+		BisectPaths:      job.BisectPaths,
 	}
 	if resp.KernelCommit == "" {
 		resp.KernelCommit = build.KernelCommit
@@ -1103,6 +1105,16 @@ func doneJob(c context.Context, req *dashapi.JobDoneReq) error {
 		job.Finished = now
 		job.IsRunning = false
 		job.Flags = req.Flags
+		job.Confidence = req.Confidence
+		if com := req.Merge; com != nil {
+			job.MergeCommit = Commit{
+				Hash:       com.Hash,
+				Title:      com.Title,
+				Author:     com.Author,
+				AuthorName: com.AuthorName,
+				Date:       com.Date,
+			}
+		}
 		if job.Type == JobBisectCause || job.Type == JobBisectFix {
 			// Update bug.BisectCause/Fix status and also remember current bug reporting to send results.
 			var err error
@@ -1135,13 +1147,80 @@ func postJob(c context.Context, jobKey *db.Key, job *Job) error {
 			return fmt.Errorf("job %v: failed to execute tree origin handlers: %w", jobKey, err)
 		}
 	}
-	err := doneCrossTreeBisection(c, jobKey, job)
+	err := doneFixCandidateBisection(c, jobKey, job)
+	if err != nil {
+		return fmt.Errorf("job %s: fix candidate bisection handlers failed: %w", jobKey, err)
+	}
+	err = createFixCandidateJob(c, jobKey, job)
 	if err != nil {
-		return fmt.Errorf("job %s: cross tree bisection handlers failed: %w", jobKey, err)
+		return fmt.Errorf("job %s: failed to create a fix candidate job: %w", jobKey, err)
 	}
 	return nil
 }
 
+// createFixCandidateJob is invoked for finished jobs. If a retested repro no longer crashes
+// the latest build of the tree the crash happened on, it requests a fix bisection that only
+// considers the commits touching the files from the crash stack. If it succeeds,
+// the result is reported as a fix candidate (see doneFixCandidateBisection).
+func createFixCandidateJob(c context.Context, jobKey *db.Key, job *Job) error {
+	if job.Type != JobTestPatch || job.Patch != 0 || job.User != "" || job.TreeOrigin ||
+		job.MergeBaseRepo != "" || job.Error != 0 || job.CrashTitle != "" ||
+		!getNsConfig(c, job.Namespace).FindFixCandidates {
+		return nil
+	}
+	bugKey := jobKey.Parent()
+	bug := new(Bug)
+	if err := db.Get(c, bugKey, bug); err != nil {
+		return fmt.Errorf("failed to get bug: %w", err)
+	}
+	if bug.Status != BugStatusOpen || len(bug.Commits) != 0 || bug.FixCandidateJob != "" {
+		return nil
+	}
+	crash := new(Crash)
+	if err := db.Get(c, db.NewKey(c, "Crash", "", job.CrashID, bugKey), crash); err != nil {
+		return fmt.Errorf("failed to get crash: %w", err)
+	}
+	build, err := loadBuild(c, job.Namespace, crash.BuildID)
+	if err != nil {
+		return err
+	}
+	if build.KernelRepo != job.KernelRepo || build.KernelBranch != job.KernelBranch {
+		// The crash happened on a different tree, we can't bisect from its revision.
+		return nil
+	}
+	report, _, err := getText(c, textCrashReport, crash.Report)
+	if err != nil {
+		return err
+	}
+	paths := crashStackFiles(report)
+	if len(paths) == 0 {
+		return nil
+	}
+	bugJobs, err := queryBugJobs(c, bug, JobBisectFix)
+	if err != nil {
+		return err
+	}
+	for _, item := range bugJobs.all() {
+		if len(item.job.BisectPaths) != 0 &&
+			(item.job.CrashID == job.CrashID || !item.job.IsFinished()) {
+			// We've already tried or are still trying.
+			return nil
+		}
+	}
+	_, err = saveJob(c, &Job{
+		Type:         JobBisectFix,
+		Created:      timeNow(c),
+		Namespace:    job.Namespace,
+		Manager:      job.Manager,
+		KernelRepo:   job.KernelRepo,
+		KernelBranch: job.KernelBranch,
+		BisectPaths:  paths,
+		BugTitle:     bug.displayTitle(),
+		CrashID:      job.CrashID,
+	}, bugKey)
+	return err
+}
+
 func updateBugBisection(c context.Context, job *Job, jobKey *db.Key, req *dashapi.JobDoneReq,
 	bug *Bug, now time.Time) (*Bug, error) {
 	if bug == nil {
@@ -1163,7 +1242,8 @@ func updateBugBisection(c context.Context, job *Job, jobKey *db.Key, req *dashap
 	if job.Type == JobBisectCause {
 		bug.BisectCause = result
 		bug.LastCauseBisect = now
-	} else {
+	} else if len(job.BisectPaths) == 0 {
+		// Fix candidate detection does not replace the normal fix bisection.
 		bug.BisectFix = result
 	}
 	infraError := (req.Flags & dashapi.BisectResultInfraError) == dashapi.BisectResultInfraError
@@ -1172,7 +1252,7 @@ func updateBugBisection(c context.Context, job *Job, jobKey *db.Key, req *dashap
 	}
 	// If the crash still occurs on HEAD, update the bug's LastTime so that it will be
 	// retried after 30 days.
-	if job.Type == JobBisectFix && (result != BisectError || infraError) &&
+	if job.Type == JobBisectFix && len(job.BisectPaths) == 0 && (result != BisectError || infraError) &&
 		len(req.Commits) == 0 && len(req.CrashLog) != 0 {
 		bug.BisectFix = BisectNot
 		bug.LastTime = now
@@ -1355,6 +1435,8 @@ func bisectFromJob(c context.Context, job *Job) (*dashapi.BisectResult, []string
 		CrashReportLink: externalLink(c, textCrashReport, job.CrashReport),
 		Fix:             job.Type == JobBisectFix,
 		CrossTree:       job.IsCrossTree(),
+		FixCandidate:    job.IsFixCandidate(),
+		Confidence:      job.Confidence,
 	}
 	for _, com := range job.Commits {
 		bisect.Commits = append(bisect.Commits, com.toDashapi())
@@ -1367,6 +1449,9 @@ func bisectFromJob(c context.Context, job *Job) (*dashapi.BisectResult, []string
 		newEmails = []string{com.Author}
 		newEmails = append(newEmails, strings.Split(com.CC, "|")...)
 	}
+	if job.MergeCommit.Hash != "" && bisect.Commit != nil {
+		bisect.Merge = job.MergeCommit.toDashapi()
+	}
 	if job.BackportedCommit.Title != "" {
 		bisect.Backported = job.BackportedCommit.toDashapi()
 	}
@@ -1389,7 +1474,7 @@ func jobReported(c context.Context, jobID string) error {
 		// if the setting is enabled for the namespace.
 		if job.Type == JobBisectFix &&
 			getNsConfig(c, job.Namespace).FixBisectionAutoClose &&
-			!job.IsCrossTree() &&
+			!job.IsFixCandidate() &&
 			len(job.Commits) == 1 {
 			bug := new(Bug)
 			bugKey := jobKey.Parent()
@@ -1574,6 +1659,7 @@ func makeJobInfo(c context.Context, job *Job, jobKey *db.Key, bug *Bug, build *B
 		CrashReportLink:  externalLink(c, textCrashReport, job.CrashReport),
 		LogLink:          externalLink(c, textLog, job.Log),
 		ErrorLink:        externalLink(c, textError, job.Error),
+		Confidence:       job.Confidence,
 		Reported:         job.Reported,
 		InvalidatedBy:    job.InvalidatedBy,
 		TreeOrigin:       job.TreeOrigin,
@@ -1602,6 +1688,13 @@ func makeJobInfo(c context.Context, job *Job, jobKey *db.Key, bug *Bug, build *B
 	if len(info.Commits) == 1 {
 		info.Commit = info.Commits[0]
 		info.Commits = nil
+		if com := job.MergeCommit; com.Hash != "" {
+			info.Merge = &dashapi.Commit{
+				Hash:  com.Hash,
+				Title: com.Title,
+				Link:  vcs.CommitLink(kernelRepo, com.Hash),
+			}
+		}
 	}
 	if crash != nil {
 		info.ReproCLink = externalLink(c, textReproC, crash.ReproC)
@@ -1661,8 +1754,12 @@ func relevantBackportJobs(c context.Context) ([]*backportInfo, error) {
 	for i, job := range allJobs {
 		// Some assertions just in case.
 		jobKey := allJobKeys[i]
+		if !job.IsFixCandidate() {
+			return nil, fmt.Errorf("job %s: expected to be a fix candidate", jobKey)
+		}
 		if !job.IsCrossTree() {
-			return nil, fmt.Errorf("job %s: expected to be cross-tree", jobKey)
+			// The fix candidate comes from the same tree, there's nothing to backport.
+			continue
 		}
 		if len(job.Commits) != 1 || job.InvalidatedBy != "" ||
 			job.BackportedCommit.Title != "" {
@@ -1741,16 +1838,12 @@ type bugJob struct {
 	build    *Build
 }
 
+// Just in case.
+const limitBugJobs = 25
+
 func queryBugJobs(c context.Context, bug *Bug, jobType JobType) (*bugJobs, error) {
-	// Just in case.
-	const limitJobs = 25
 	var jobs []*Job
-	jobKeys, err := db.NewQuery("Job").
-		Ancestor(bug.key(c)).
-		Filter("Type=", jobType).
-		Order("-Finished").
-		Limit(limitJobs).
-		GetAll(c, &jobs)
+	jobKeys, err := bugJobsQuery(bug.key(c), jobType).GetAll(c, &jobs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch bug jobs: %w", err)
 	}
@@ -1772,6 +1865,23 @@ func queryBugJobs(c context.Context, bug *Bug, jobType JobType) (*bugJobs, error
 	return ret, nil
 }
 
+// queryBugJobKeys returns the keys of the jobs queryBugJobs would return.
+func queryBugJobKeys(c context.Context, bugKey *db.Key, jobType JobType) ([]*db.Key, error) {
+	keys, err := bugJobsQuery(bugKey, jobType).KeysOnly().GetAll(c, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bug jobs: %w", err)
+	}
+	return keys, nil
+}
+
+func bugJobsQuery(bugKey *db.Key, jobType JobType) *db.Query {
+	return db.NewQuery("Job").
+		Ancestor(bugKey).
+		Filter("Type=", jobType).
+		Order("-Finished").
+		Limit(limitBugJobs)
+}
+
 func queryBestBisection(c context.Context, bug *Bug, jobType JobType) (*bugJob, error) {
 	jobs, err := queryBugJobs(c, bug, jobType)
 	if err != nil {
@@ -1790,8 +1900,8 @@ func (b *bugJobs) bestBisection() *bugJob {
 		if j.job.InvalidatedBy != "" {
 			continue
 		}
-		if j.job.MergeBaseRepo != "" {
-			// It was a cross-tree bisection.
+		if j.job.IsFixCandidate() {
+			// It was a cross-tree or a fix candidate bisection.
 			continue
 		}
 		return j
@@ -1809,7 +1919,7 @@ func (b *bugJobs) bestFixCandidate() *bugJob {
 		if j.job.InvalidatedBy != "" {
 			continue
 		}
-		if !j.job.IsCrossTree() {
+		if !j.job.IsFixCandidate() {
 			continue
 		}
 		return j
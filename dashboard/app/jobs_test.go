@@ -488,6 +488,72 @@ func TestReproRetestJob(t *testing.T) {
 	c.expectEQ(bug.StatusReason, dashapi.InvalidatedByRevokedRepro)
 }
 
+func TestFixCandidateFromReproRetest(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	client := c.publicClient
+	build := testBuild(1)
+	build.KernelRepo = "git://mygit.com/git.git"
+	build.KernelBranch = "main"
+	client.UploadBuild(build)
+
+	crash := testCrashWithRepro(build, 1)
+	crash.Report = []byte(testStackReport)
+	client.ReportCrash(crash)
+	sender := c.pollEmailBug().Sender
+	_, extBugID, err := email.RemoveAddrContext(sender)
+	c.expectOK(err)
+
+	// Upload a newer build of the same tree.
+	c.advanceTime(time.Minute)
+	newBuild := testBuild(1)
+	newBuild.ID = "new-build"
+	newBuild.KernelRepo = build.KernelRepo
+	newBuild.KernelBranch = build.KernelBranch
+	newBuild.KernelCommit = strings.Repeat("2", 40)
+	client.UploadBuild(newBuild)
+
+	// The repro no longer works on the new build.
+	c.advanceTime(c.config().Obsoleting.ReproRetestStart + time.Hour)
+	resp := client.pollSpecificJobs(build.Manager, dashapi.ManagerJobs{TestPatches: true})
+	c.expectEQ(resp.Type, dashapi.JobTestPatch)
+	c.expectEQ(resp.KernelRepo, build.KernelRepo)
+	client.expectOK(client.JobDone(&dashapi.JobDoneReq{ID: resp.ID}))
+
+	// Expect a fix bisection limited to the crash stack files.
+	c.advanceTime(4 * 24 * time.Hour)
+	resp = client.pollSpecificJobs(build.Manager, dashapi.ManagerJobs{BisectFix: true})
+	c.expectEQ(resp.Type, dashapi.JobBisectFix)
+	c.expectEQ(resp.KernelRepo, build.KernelRepo)
+	c.expectEQ(resp.KernelBranch, build.KernelBranch)
+	c.expectEQ(resp.KernelCommit, build.KernelCommit)
+	c.expectEQ(resp.BisectPaths, []string{"net/ipv4/tcp_output.c", "net/ipv4/tcp.c", "net/socket.c"})
+	client.expectOK(client.JobDone(&dashapi.JobDoneReq{
+		ID:  resp.ID,
+		Log: []byte("bisect log"),
+		Commits: []dashapi.Commit{{
+			Hash:       "deadf00d",
+			Title:      "tcp: fix use-after-free in tcp_write_xmit",
+			Author:     "someone@somewhere.com",
+			AuthorName: "Someone",
+			Date:       time.Date(2000, 2, 9, 4, 5, 6, 7, time.UTC),
+		}},
+	}))
+
+	msg := c.pollEmailBug()
+	c.expectTrue(strings.Contains(msg.Body, "syzbot suspects this commit\nfixed the issue"))
+	c.expectTrue(strings.Contains(msg.Body, "#syz fix: tcp: fix use-after-free in tcp_write_xmit"))
+	c.expectNoEmail()
+
+	// The bug is not closed and the usual fix bisection is not affected.
+	bug, _, _ := c.loadBug(extBugID)
+	c.expectEQ(bug.Status, BugStatusOpen)
+	c.expectEQ(bug.BisectFix, BisectNot)
+	c.expectNE(bug.FixCandidateJob, "")
+	c.expectEQ(len(bug.Commits), 0)
+}
+
 func TestDelegatedManagerReproRetest(t *testing.T) {
 	c := NewCtx(t)
 	defer c.Close()
@@ -77,6 +77,7 @@ func initHTTPHandlers() {
 		}
 		http.Handle("/"+ns+"/repos", handlerWrapper(handleRepos))
 		http.Handle("/"+ns+"/bug-summaries", handlerWrapper(handleBugSummaries))
+		http.Handle("/"+ns+"/export", handlerWrapper(handleExport))
 		http.Handle("/"+ns+"/subsystems", handlerWrapper(handleSubsystemsList))
 		http.Handle("/"+ns+"/backports", handlerWrapper(handleBackports))
 		http.Handle("/"+ns+"/s/", handlerWrapper(handleSubsystemPage))
@@ -86,6 +87,7 @@ func initHTTPHandlers() {
 	http.HandleFunc("/cron/minute_cache_update", handleMinuteCacheUpdate)
 	http.HandleFunc("/cron/deprecate_assets", handleDeprecateAssets)
 	http.HandleFunc("/cron/refresh_subsystems", handleRefreshSubsystems)
+	http.HandleFunc("/cron/backfill_stack_frames", handleBackfillStackFrames)
 	http.HandleFunc("/cron/subsystem_reports", handleSubsystemReports)
 }
 
@@ -291,6 +293,7 @@ type uiBugPage struct {
 	BisectCause     *uiJob
 	BisectFix       *uiJob
 	FixCandidate    *uiJob
+	CommitRange     *uiCommitRange
 	Sections        []*uiCollapsible
 	SampleReport    template.HTML
 	Crashes         *uiCrashTable
@@ -299,6 +302,13 @@ type uiBugPage struct {
 	DebugSubsystems string
 }
 
+type uiCommitRange struct {
+	Good       *uiCommit
+	Bad        *uiCommit
+	Suspects   []*uiCommit
+	Incomplete bool
+}
+
 type uiBugLabelGroup struct {
 	Name   string
 	Labels []*uiBugLabel
@@ -339,10 +349,12 @@ type uiBugGroup struct {
 	ShowPatch     bool
 	ShowPatched   bool
 	ShowStatus    bool
-	ShowIndex     int
-	Bugs          []*uiBug
-	DispLastAct   bool
-	DispDiscuss   bool
+	// Show the Similarity column.
+	ShowSimilarity bool
+	ShowIndex      int
+	Bugs           []*uiBug
+	DispLastAct    bool
+	DispDiscuss    bool
 }
 
 type uiJobList struct {
@@ -387,6 +399,7 @@ type uiBug struct {
 	Labels         []*uiBugLabel
 	Discussions    DiscussionSummary
 	ID             string
+	Similarity     float64 // stack similarity for related bugs
 }
 
 type uiBugLabel struct {
@@ -1148,6 +1161,18 @@ func handleBug(c context.Context, w http.ResponseWriter, r *http.Request) error
 			Value: similar,
 		})
 	}
+	related, err := loadRelatedBugsUI(c, r, bug, state, similar)
+	if err != nil {
+		return err
+	}
+	if len(related.Bugs) > 0 {
+		sections = append(sections, &uiCollapsible{
+			Title: fmt.Sprintf("Possibly related bugs (%d)", len(related.Bugs)),
+			Show:  getNsConfig(c, hdr.Namespace).AccessLevel != AccessPublic,
+			Type:  sectionBugList,
+			Value: related,
+		})
+	}
 	causeBisections, err := queryBugJobs(c, bug, JobBisectCause)
 	if err != nil {
 		return fmt.Errorf("failed to load cause bisections: %w", err)
@@ -1211,6 +1236,9 @@ func handleBug(c context.Context, w http.ResponseWriter, r *http.Request) error
 		Crashes:      crashesTable,
 		LabelGroups:  getLabelGroups(c, bug),
 	}
+	if bisectCause == nil {
+		data.CommitRange = makeUICommitRange(&bug.CommitRange)
+	}
 	if accessLevel == AccessAdmin && !bug.hasUserSubsystems() {
 		data.DebugSubsystems = urlutil.SetParam(data.Bug.Link, "debug_subsystems", "1")
 	}
@@ -1872,6 +1900,46 @@ func loadSimilarBugsUI(c context.Context, r *http.Request, bug *Bug, state *Repo
 	return group, nil
 }
 
+// loadRelatedBugsUI returns bugs with similar crash stacks, except for the already shown similar bugs.
+func loadRelatedBugsUI(c context.Context, r *http.Request, bug *Bug, state *ReportingState,
+	similar *uiBugGroup) (*uiBugGroup, error) {
+	managers := make(map[string][]string)
+	accessLevel := accessLevel(c, r)
+	relatedBugs, err := loadRelatedBugs(c, bug)
+	if err != nil {
+		return nil, err
+	}
+	shown := make(map[string]bool)
+	for _, bug := range similar.Bugs {
+		shown[bug.ID] = true
+	}
+	var results []*uiBug
+	for _, related := range relatedBugs {
+		if accessLevel < related.bug.sanitizeAccess(c, accessLevel) ||
+			shown[related.bug.keyHash(c)] {
+			continue
+		}
+		if managers[related.bug.Namespace] == nil {
+			mgrs, err := CachedManagerList(c, related.bug.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			managers[related.bug.Namespace] = mgrs
+		}
+		uiBug := createUIBug(c, related.bug, state, managers[related.bug.Namespace])
+		uiBug.Similarity = related.similarity
+		results = append(results, uiBug)
+	}
+	group := &uiBugGroup{
+		Now:            timeNow(c),
+		ShowNamespace:  true,
+		ShowStatus:     true,
+		ShowSimilarity: true,
+		Bugs:           results,
+	}
+	return group, nil
+}
+
 func closedBugStatus(bug *Bug, bugReporting *BugReporting) string {
 	status := ""
 	switch bug.Status {
@@ -2374,7 +2442,7 @@ func makeUIJob(c context.Context, job *Job, jobKey *db.Key, bug *Bug, crash *Cra
 		JobInfo:           makeJobInfo(c, job, jobKey, bug, build, crash),
 		InvalidateJobLink: invalidateJobLink(c, job, jobKey, false),
 		RestartJobLink:    invalidateJobLink(c, job, jobKey, true),
-		FixCandidate:      job.IsCrossTree(),
+		FixCandidate:      job.IsFixCandidate(),
 	}
 	if crash != nil {
 		ui.Crash = makeUICrash(c, crash, build)
@@ -0,0 +1,424 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/api"
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/vcs"
+	db "google.golang.org/appengine/v2/datastore"
+)
+
+// The export is the documented and versioned (see api.Version) dump of the namespace bugs
+// that can be consumed by external tools (see api.Client.Export and docs/syzbot.md).
+// The export is incremental: the caller passes the Until time of the previous export as since
+// and gets only the bugs that were updated after that time.
+// The bugs updated in the time range are found by several queries (see exportQueries)
+// that are run one after another, the page cursor holds the index of the current query
+// and the datastore cursor in it. A bug returned by several queries is exported
+// by the first of them.
+
+const (
+	// How many bugs we return in a single response.
+	exportBugsPerPage = 100
+	// How many crashes we return per bug.
+	exportCrashesPerBug = 20
+)
+
+// Bug fields that are updated when something in the exported bug data changes.
+var exportBugTimeFields = []string{
+	"LastTime",
+	"LastReproTime",
+	"FixTime",
+	"LastActivity",
+	"Closed",
+	"SubsystemsTime",
+}
+
+func handleExport(c context.Context, w http.ResponseWriter, r *http.Request) error {
+	hdr, err := commonHeader(c, r, w, "")
+	if err != nil {
+		return err
+	}
+	var since, until time.Time
+	if val := r.FormValue("since"); val != "" {
+		if since, err = time.Parse(time.RFC3339Nano, val); err != nil {
+			return fmt.Errorf("bad since: %w: %w", err, ErrClientBadRequest)
+		}
+	}
+	until = timeNow(c)
+	if val := r.FormValue("until"); val != "" {
+		if until, err = time.Parse(time.RFC3339Nano, val); err != nil {
+			return fmt.Errorf("bad until: %w: %w", err, ErrClientBadRequest)
+		}
+	}
+	subsystem := r.FormValue("subsystem")
+	if subsystem != "" && !since.IsZero() {
+		return fmt.Errorf("subsystem can't be combined with since: %w", ErrClientBadRequest)
+	}
+	export, err := loadExport(c, hdr.Namespace, accessLevel(c, r), since, until, subsystem, r.FormValue("cursor"))
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(export)
+}
+
+func loadExport(c context.Context, ns string, accessLevel AccessLevel, since, until time.Time,
+	subsystem, cursor string) (*api.Export, error) {
+	keys, queries, next, err := exportBugKeys(c, ns, since, until, subsystem, cursor)
+	if err != nil {
+		return nil, err
+	}
+	export := &api.Export{
+		Version:    api.Version,
+		Namespace:  ns,
+		Since:      since,
+		Until:      until,
+		NextCursor: next,
+	}
+	bugs := make([]*Bug, len(keys))
+	if err := db.GetMulti(c, keys, bugs); err != nil {
+		return nil, fmt.Errorf("failed to fetch bugs: %w", err)
+	}
+	var items []*exportItem
+	exported := make(map[string]bool)
+	for i, bug := range bugs {
+		if exported[keys[i].StringID()] ||
+			!since.IsZero() && bug.exportQuery(since, until) < queries[i] {
+			continue
+		}
+		exported[keys[i].StringID()] = true
+		if accessLevel < bug.sanitizeAccess(c, accessLevel) {
+			continue
+		}
+		items = append(items, &exportItem{bug: bug, key: keys[i]})
+	}
+	if err := loadExportItems(c, items); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		export.Bugs = append(export.Bugs, *exportBug(c, item, accessLevel))
+	}
+	return export, nil
+}
+
+// exportItem is an exported bug together with the entities it references.
+type exportItem struct {
+	bug         *Bug
+	key         *db.Key
+	dup         *Bug
+	crashes     []*Crash
+	builds      []*Build
+	bisectCause *bugJobs
+	bisectFix   *bugJobs
+}
+
+// loadExportItems loads the entities referenced by the exported bugs.
+// Per bug we only run keys-only queries, the entities of the whole page are then fetched in batches.
+func loadExportItems(c context.Context, items []*exportItem) error {
+	dupLoader := &dependencyLoader[Bug]{}
+	crashLoader := &dependencyLoader[Crash]{}
+	jobLoader := &dependencyLoader[Job]{}
+	for _, item := range items {
+		if item.bug.DupOf != "" {
+			dupLoader.add(db.NewKey(c, "Bug", item.bug.DupOf, 0, nil), func(dup *Bug) {
+				item.dup = dup
+			})
+		}
+		crashKeys, err := db.NewQuery("Crash").
+			Ancestor(item.key).
+			Order("-ReportLen").
+			Order("-Time").
+			Limit(exportCrashesPerBug).
+			KeysOnly().
+			GetAll(c, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch crashes: %w", err)
+		}
+		item.crashes = make([]*Crash, len(crashKeys))
+		for i, key := range crashKeys {
+			crashLoader.add(key, func(crash *Crash) {
+				item.crashes[i] = crash
+			})
+		}
+		item.bisectCause = &bugJobs{}
+		item.bisectFix = &bugJobs{}
+		for _, jobs := range []struct {
+			typ  JobType
+			list *bugJobs
+		}{{JobBisectCause, item.bisectCause}, {JobBisectFix, item.bisectFix}} {
+			jobKeys, err := queryBugJobKeys(c, item.key, jobs.typ)
+			if err != nil {
+				return err
+			}
+			jobs.list.list = make([]*bugJob, len(jobKeys))
+			for i, key := range jobKeys {
+				jobs.list.list[i] = &bugJob{bug: item.bug, key: key}
+				jobLoader.add(key, func(job *Job) {
+					jobs.list.list[i].job = job
+				})
+			}
+		}
+	}
+	if err := dupLoader.load(c); err != nil {
+		return fmt.Errorf("failed to fetch bugs: %w", err)
+	}
+	if err := crashLoader.load(c); err != nil {
+		return fmt.Errorf("failed to fetch crashes: %w", err)
+	}
+	if err := jobLoader.load(c); err != nil {
+		return fmt.Errorf("failed to fetch jobs: %w", err)
+	}
+	buildLoader := &dependencyLoader[Build]{}
+	for _, item := range items {
+		item.builds = make([]*Build, len(item.crashes))
+		for i, crash := range item.crashes {
+			buildLoader.add(buildKey(c, item.bug.Namespace, crash.BuildID), func(build *Build) {
+				item.builds[i] = build
+			})
+		}
+	}
+	if err := buildLoader.load(c); err != nil {
+		return fmt.Errorf("failed to fetch builds: %w", err)
+	}
+	return nil
+}
+
+// exportQueries returns the queries that together return keys of all bugs (or of their jobs)
+// updated in the (since, until] range. If since is zero, all bugs of the namespace
+// (or only of the subsystem, if it's set) are returned.
+func exportQueries(ns string, since, until time.Time, subsystem string) []*db.Query {
+	if since.IsZero() {
+		query := db.NewQuery("Bug").Filter("Namespace=", ns)
+		if subsystem != "" {
+			query = query.Filter("Labels.Label=", string(SubsystemLabel)).
+				Filter("Labels.Value=", subsystem)
+		}
+		return []*db.Query{query}
+	}
+	var queries []*db.Query
+	for _, field := range exportBugTimeFields {
+		queries = append(queries, db.NewQuery("Bug").
+			Filter("Namespace=", ns).
+			Filter(field+">", since).
+			Filter(field+"<=", until))
+	}
+	// Bisection results are not reflected in the bug times.
+	queries = append(queries, db.NewQuery("Job").
+		Filter("Namespace=", ns).
+		Filter("Finished>", since).
+		Filter("Finished<=", until))
+	return queries
+}
+
+// exportBugKeys returns up to exportBugsPerPage keys of the bugs updated in the (since, until] range
+// starting from the cursor, the indexes of the queries that have returned them and the cursor
+// for the next page (empty if there are no more bugs).
+func exportBugKeys(c context.Context, ns string, since, until time.Time, subsystem, cursor string) (
+	[]*db.Key, []int, string, error) {
+	queries := exportQueries(ns, since, until, subsystem)
+	idx, start, err := parseExportCursor(cursor, len(queries))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	var keys []*db.Key
+	var keyQueries []int
+	for ; idx < len(queries); idx, start = idx+1, nil {
+		limit := exportBugsPerPage - len(keys)
+		query := queries[idx].KeysOnly().Limit(limit)
+		if start != nil {
+			query = query.Start(*start)
+		}
+		iter := query.Run(c)
+		n := 0
+		for ; ; n++ {
+			key, err := iter.Next(nil)
+			if err == db.Done {
+				break
+			}
+			if err != nil {
+				return nil, nil, "", fmt.Errorf("failed to query bugs: %w", err)
+			}
+			if key.Kind() == "Job" {
+				if key = key.Parent(); key == nil {
+					continue
+				}
+			}
+			keys = append(keys, key)
+			keyQueries = append(keyQueries, idx)
+		}
+		if n < limit {
+			continue
+		}
+		// The page is full, but the query may have more results.
+		next, err := iter.Cursor()
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to get cursor: %w", err)
+		}
+		return keys, keyQueries, fmt.Sprintf("%v:%v", idx, next), nil
+	}
+	return keys, keyQueries, "", nil
+}
+
+func parseExportCursor(cursor string, queries int) (int, *db.Cursor, error) {
+	if cursor == "" {
+		return 0, nil, nil
+	}
+	idxStr, curStr, ok := strings.Cut(cursor, ":")
+	idx, err := strconv.Atoi(idxStr)
+	if !ok || err != nil || idx < 0 || idx >= queries {
+		return 0, nil, fmt.Errorf("bad cursor %q: %w", cursor, ErrClientBadRequest)
+	}
+	cur, err := db.DecodeCursor(curStr)
+	if err != nil {
+		return 0, nil, fmt.Errorf("bad cursor %q: %w", cursor, ErrClientBadRequest)
+	}
+	return idx, &cur, nil
+}
+
+// exportTimes returns the bug times in the order of exportBugTimeFields.
+func (bug *Bug) exportTimes() []time.Time {
+	return []time.Time{bug.LastTime, bug.LastReproTime, bug.FixTime,
+		bug.LastActivity, bug.Closed, bug.SubsystemsTime}
+}
+
+// exportQuery returns the index of the first of exportQueries that returns the bug.
+func (bug *Bug) exportQuery(since, until time.Time) int {
+	for i, t := range bug.exportTimes() {
+		if t.After(since) && !t.After(until) {
+			return i
+		}
+	}
+	return len(exportBugTimeFields)
+}
+
+func exportBug(c context.Context, item *exportItem, accessLevel AccessLevel) *api.ExportBug {
+	bug := item.bug
+	uiBug := createUIBug(c, bug, nil, nil)
+	ret := &api.ExportBug{
+		ID:         uiBug.ID,
+		Title:      uiBug.Title,
+		Link:       uiBug.Link,
+		Status:     exportBugStatus(bug),
+		FirstCrash: bug.FirstTime,
+		LastCrash:  bug.LastTime,
+		NumCrashes: bug.NumCrashes,
+		Closed:     bug.Closed,
+		ReproLevel: exportReproLevel(bug.ReproLevel),
+		FixCommits: getBugFixCommits(uiBug),
+	}
+	// Don't leak the bugs that the caller can't see.
+	if item.dup != nil && accessLevel >= item.dup.sanitizeAccess(c, accessLevel) {
+		ret.DupOf = bug.DupOf
+	}
+	for _, t := range bug.exportTimes() {
+		if t.After(ret.Updated) {
+			ret.Updated = t
+		}
+	}
+	for _, label := range bug.Labels {
+		if label.Label == SubsystemLabel {
+			ret.Subsystems = append(ret.Subsystems, label.Value)
+		} else {
+			ret.Labels = append(ret.Labels, label.String())
+		}
+	}
+	nsConfig := getNsConfig(c, bug.Namespace)
+	for _, bugReporting := range bug.Reporting {
+		// Don't leak the discussions in the reportings that the caller can't see.
+		reporting := nsConfig.ReportingByName(bugReporting.Name)
+		if bugReporting.Link != "" && reporting != nil && accessLevel >= reporting.AccessLevel {
+			ret.Discussions = append(ret.Discussions, bugReporting.Link)
+		}
+	}
+	ret.CauseBisection = exportBisection(item.bisectCause.bestBisection())
+	ret.FixBisection = exportBisection(item.bisectFix.bestBisection())
+	for _, bisection := range []*api.Bisection{ret.CauseBisection, ret.FixBisection} {
+		if bisection != nil && bisection.Time.After(ret.Updated) {
+			ret.Updated = bisection.Time
+		}
+	}
+	for i, crash := range item.crashes {
+		build := item.builds[i]
+		ui := makeUICrash(c, crash, build)
+		ret.Crashes = append(ret.Crashes, api.ExportCrash{
+			Crash: api.Crash{
+				Title:               ui.Title,
+				SyzReproducerLink:   ui.ReproSyzLink,
+				CReproducerLink:     ui.ReproCLink,
+				KernelConfigLink:    ui.KernelConfigLink,
+				KernelSourceGit:     ui.KernelCommitLink,
+				KernelSourceCommit:  ui.KernelCommit,
+				SyzkallerGit:        ui.SyzkallerCommitLink,
+				SyzkallerCommit:     ui.SyzkallerCommit,
+				CompilerDescription: build.CompilerID,
+				Architecture:        build.Arch,
+				CrashReportLink:     ui.ReportLink,
+			},
+			Time:         crash.Time,
+			Manager:      crash.Manager,
+			KernelRepo:   build.KernelRepo,
+			KernelBranch: build.KernelBranch,
+			CrashLogLink: ui.LogLink,
+		})
+	}
+	return ret
+}
+
+func exportBisection(bj *bugJob) *api.Bisection {
+	if bj == nil {
+		return nil
+	}
+	job := bj.job
+	ret := &api.Bisection{
+		Time:       job.Finished,
+		LogLink:    textLink(textLog, job.Log),
+		Confidence: job.Confidence,
+	}
+	for _, com := range job.Commits {
+		ret.Commits = append(ret.Commits, api.Commit{
+			Title:  com.Title,
+			Hash:   com.Hash,
+			Link:   vcs.CommitLink(job.KernelRepo, com.Hash),
+			Repo:   job.KernelRepo,
+			Branch: job.KernelBranch,
+		})
+	}
+	return ret
+}
+
+func exportBugStatus(bug *Bug) string {
+	switch bug.Status {
+	case BugStatusFixed:
+		return api.ExportStatusFixed
+	case BugStatusInvalid:
+		return api.ExportStatusInvalid
+	case BugStatusDup:
+		return api.ExportStatusDup
+	default:
+		return api.ExportStatusOpen
+	}
+}
+
+func exportReproLevel(level dashapi.ReproLevel) string {
+	switch level {
+	case dashapi.ReproLevelC:
+		return "c"
+	case dashapi.ReproLevelSyz:
+		return "syz"
+	default:
+		return ""
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/syzkaller/dashboard/api"
 	"github.com/google/syzkaller/dashboard/dashapi"
@@ -258,6 +260,95 @@ func TestPublicJSONAPI(t *testing.T) {
 	c.expectEQ(config, []byte("config1"))
 }
 
+func TestPublicExport(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	client := c.makeClient(clientPublic, keyPublic, true)
+	build := testBuild(1)
+	client.UploadBuild(build)
+	client.ReportCrash(testCrashWithRepro(build, 1))
+	client.ReportCrash(testCrash(build, 2))
+	// The discussion in the non-public reporting must not be exported.
+	for _, rep := range client.pollBugs(2) {
+		reply, _ := client.ReportingUpdate(&dashapi.BugUpdate{
+			ID:     rep.ID,
+			Status: dashapi.BugStatusUpstream,
+			Link:   "https://internal.discussion/" + rep.ID,
+		})
+		c.expectTrue(reply.OK)
+	}
+	for _, rep := range client.pollBugs(2) {
+		reply, _ := client.ReportingUpdate(&dashapi.BugUpdate{
+			ID:     rep.ID,
+			Status: dashapi.BugStatusOpen,
+			Link:   "https://public.discussion/" + rep.ID,
+		})
+		c.expectTrue(reply.OK)
+	}
+
+	cli := c.makeAPIClient()
+	export, err := cli.Export("access-public", time.Time{})
+	c.expectOK(err)
+	c.expectEQ(export.Version, api.Version)
+	c.expectTrue(export.Until.Equal(c.mockedTime))
+	c.expectEQ(len(export.Bugs), 2)
+	for _, bug := range export.Bugs {
+		c.expectEQ(bug.Status, api.ExportStatusOpen)
+		c.expectEQ(len(bug.Crashes), 1)
+		c.expectEQ(bug.Crashes[0].Title, bug.Title)
+		c.expectEQ(bug.Crashes[0].Manager, build.Manager)
+		c.expectEQ(len(bug.Discussions), 1)
+		c.expectTrue(strings.HasPrefix(bug.Discussions[0], "https://public.discussion/"))
+		if bug.Title == "title1" {
+			c.expectEQ(bug.ReproLevel, "c")
+			c.expectNE(bug.Crashes[0].CReproducerLink, "")
+		} else {
+			c.expectEQ(bug.ReproLevel, "")
+		}
+	}
+
+	// Nothing has changed since the last export.
+	c.advanceTime(time.Hour)
+	export1, err := cli.Export("access-public", export.Until)
+	c.expectOK(err)
+	c.expectEQ(len(export1.Bugs), 0)
+
+	// Only the bug with a new crash is exported.
+	c.advanceTime(time.Hour)
+	client.ReportCrash(testCrash(build, 2))
+	export2, err := cli.Export("access-public", export1.Until)
+	c.expectOK(err)
+	c.expectEQ(len(export2.Bugs), 1)
+	c.expectEQ(export2.Bugs[0].Title, "title2")
+	c.expectEQ(export2.Bugs[0].NumCrashes, int64(2))
+	c.expectTrue(export2.Bugs[0].Updated.Equal(c.mockedTime))
+}
+
+func TestPublicExportSubsystem(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	client := c.makeClient(clientPublicEmail, keyPublicEmail, true)
+	build := testBuild(1)
+	client.UploadBuild(build)
+	crash1 := testCrash(build, 1)
+	crash1.GuiltyFiles = []string{"a.c"}
+	client.ReportCrash(crash1)
+	c.pollEmailBug()
+	crash2 := testCrash(build, 2)
+	crash2.GuiltyFiles = []string{"b.c"}
+	client.ReportCrash(crash2)
+	c.pollEmailBug()
+
+	cli := c.makeAPIClient()
+	export, err := cli.ExportSubsystem("access-public-email", "subsystemA")
+	c.expectOK(err)
+	c.expectEQ(len(export.Bugs), 1)
+	c.expectEQ(export.Bugs[0].Title, "title1")
+	c.expectEQ(export.Bugs[0].Subsystems, []string{"subsystemA"})
+}
+
 func TestWriteExtAPICoverageFor(t *testing.T) {
 	ctx := setCoverageDBClient(context.Background(), fileFuncLinesDBFixture(t,
 		[]*coveragedb.FuncLines{
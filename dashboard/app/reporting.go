@@ -276,6 +276,8 @@ var notificationGenerators = []func(context.Context, *Bug, *Reporting,
 		}
 		return nil, nil
 	},
+	// Crash rate spikes.
+	createCrashSpikeNotification,
 }
 
 func createLabelNotification(c context.Context, label BugLabel, bug *Bug, reporting *Reporting,
@@ -571,6 +573,7 @@ func crashBugReport(c context.Context, bug *Bug, crash *Crash, crashKey *db.Key,
 		Assets:          assetList,
 		ReportElements:  &dashapi.ReportElements{GuiltyFiles: crash.ReportElements.GuiltyFiles},
 		ReproIsRevoked:  crash.ReproIsRevoked,
+		CommitRange:     bug.CommitRange.toDashapi(),
 	}
 	rep.ReproCLink = externalLink(c, textReproC, crash.ReproC)
 	rep.ReproC, _, err = getText(c, textReproC, crash.ReproC)
@@ -359,6 +359,12 @@ func emailSendBugNotif(c context.Context, notif *dashapi.BugNotification) error
 			return fmt.Errorf("failed to execute mail_label_notif.txt: %w", err)
 		}
 		body = bodyBuf.String()
+	case dashapi.BugNotifCrashSpike:
+		bodyBuf := new(bytes.Buffer)
+		if err := mailTemplates.ExecuteTemplate(bodyBuf, "mail_crash_spike.txt", notif); err != nil {
+			return fmt.Errorf("failed to execute mail_crash_spike.txt: %w", err)
+		}
+		body = bodyBuf.String()
 	default:
 		return fmt.Errorf("bad notification type %v", notif.Type)
 	}
@@ -471,7 +477,7 @@ func emailReport(c context.Context, rep *dashapi.BugReport) error {
 	case dashapi.ReportBisectCause:
 		templ = "mail_bisect_result.txt"
 	case dashapi.ReportBisectFix:
-		if rep.BisectFix.CrossTree {
+		if rep.BisectFix.FixCandidate {
 			templ = "mail_fix_candidate.txt"
 			if rep.BisectFix.Commit == nil {
 				return fmt.Errorf("reporting failed fix candidate bisection for %s", rep.ID)
@@ -0,0 +1,343 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"google.golang.org/appengine/v2"
+	db "google.golang.org/appengine/v2/datastore"
+	"google.golang.org/appengine/v2/log"
+)
+
+// Bugs are grouped by exact titles, but the same root cause frequently manifests itself
+// with different titles in different kernels (e.g. upstream and downstream trees, or after
+// the function in the title was renamed/inlined). To find such bugs, we store normalized
+// top frames of the crash stack in Bug.StackFrames and compare stacks of bugs that share
+// at least one of the top frames.
+// For the bugs that were reported before StackFrames was introduced, the frames are
+// backfilled from the stored crash reports (see handleBackfillStackFrames).
+
+const (
+	// How many normalized frames we store per bug.
+	maxStackFrames = 16
+	// How many source files of the crash stack we use for fix candidate detection.
+	maxStackFiles = 8
+	// How many top frames we use to query candidate bugs.
+	stackQueryFrames = 3
+	// Max number of candidate bugs per queried frame.
+	maxStackCandidates = 200
+	// Bugs with lower stack similarity are not considered related.
+	minStackSimilarity = 0.5
+	// Max number of related bugs we show/return.
+	maxRelatedBugs = 20
+	// How many bugs per namespace one backfill cron run processes.
+	backfillStackFramesCount = 100
+)
+
+var (
+	// Matches both symbolized and non-symbolized frames, e.g.:
+	//  __sys_sendmsg+0x1b4/0x250 net/socket.c:2646
+	//  [<ffffffff81234567>] __sys_sendmsg+0x1b4/0x250
+	//  sock_sendmsg_nosec net/socket.c:730 [inline]
+	//  RIP: 0010:__sys_sendmsg+0x1b4/0x250
+	stackFrameRe = regexp.MustCompile(`^\s*(?:RIP: [0-9a-f]{4}:|pc : |lr : )?(?:\[<[0-9a-f]+>\]\s*)?` +
+		`([a-zA-Z_][a-zA-Z0-9_.]*)(?:\+0x[0-9a-f]+/0x[0-9a-f]+|\s+[a-zA-Z0-9_./-]+\.[chS]:\d+)`)
+	// Source file of a symbolized frame, e.g. net/socket.c in the examples above.
+	stackFrameFileRe = regexp.MustCompile(`\s([a-zA-Z0-9_./-]+\.[chS]):\d+`)
+	// Compiler-generated suffixes of function clones.
+	stackFrameSuffixRe = regexp.MustCompile(`(\.(isra|constprop|part|cold|llvm|lto_priv)(\.[0-9a-f]+)?)+$`)
+	// Syscall entry wrappers are replaced with the syscall name.
+	stackFrameSyscallRe = regexp.MustCompile(`^(__se_|__do_|__x64_|__ia32_|__arm64_|__riscv_|__s390x_)+sys_`)
+	// Frames of error reporting and generic kernel entry code don't tell anything about the bug.
+	stackFrameNoiseRe = regexp.MustCompile(`^(` +
+		`dump_stack.*|__dump_stack|show_stack|show_regs|print_.*|printk.*|_printk|vprintk.*|` +
+		`kasan_.*|__kasan_.*|check_memory_region.*|__asan_.*|__msan_.*|kmsan_.*|__kmsan_.*|` +
+		`ubsan_.*|__ubsan_.*|kcsan_.*|__kcsan_.*|__tsan_.*|kfence_.*|` +
+		`panic|__panic|nmi_.*|report_bug|__report_bug|__warn|warn_slowpath.*|handle_bug|` +
+		`exc_.*|asm_exc_.*|do_error_trap|do_invalid_op|invalid_op|do_trap|` +
+		`lockdep_.*|__lock_acquire|lock_acquire|lock_release|lock_is_held_type|` +
+		`entry_SYSCALL.*|do_syscall_.*|x64_sys_call|invoke_syscall|__invoke_syscall|el0_.*|el1_.*|` +
+		`ret_from_fork.*|kthread|worker_thread|process_one_work.*|` +
+		`sysvec_.*|asm_sysvec_.*|irq_exit.*|__irq_exit_rcu|__do_softirq|handle_softirqs|do_softirq.*` +
+		`)$`)
+)
+
+// crashStackFrames extracts normalized unique frames from the crash report, from top to bottom.
+func crashStackFrames(report []byte) []string {
+	var frames []string
+	dedup := make(map[string]bool)
+	forEachStackFrame(report, func(frame string, line []byte) bool {
+		if !dedup[frame] {
+			dedup[frame] = true
+			frames = append(frames, frame)
+		}
+		return len(frames) < maxStackFrames
+	})
+	return frames
+}
+
+// crashStackFiles extracts unique source files of the crash stack frames, from top to bottom.
+func crashStackFiles(report []byte) []string {
+	var files []string
+	dedup := make(map[string]bool)
+	forEachStackFrame(report, func(frame string, line []byte) bool {
+		match := stackFrameFileRe.FindSubmatch(line)
+		if match == nil {
+			return true
+		}
+		file := strings.TrimPrefix(string(match[1]), "./")
+		if !dedup[file] {
+			dedup[file] = true
+			files = append(files, file)
+		}
+		return len(files) < maxStackFiles
+	})
+	return files
+}
+
+// forEachStackFrame calls fn for every normalized non-noise frame of the crash report
+// and the corresponding report line until fn returns false.
+func forEachStackFrame(report []byte, fn func(frame string, line []byte) bool) {
+	s := bufio.NewScanner(bytes.NewReader(report))
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		line := s.Bytes()
+		// Unreliable frames.
+		if bytes.Contains(line, []byte(" ? ")) {
+			continue
+		}
+		match := stackFrameRe.FindSubmatch(line)
+		if match == nil {
+			continue
+		}
+		frame := stackFrameSuffixRe.ReplaceAllString(string(match[1]), "")
+		frame = stackFrameSyscallRe.ReplaceAllString(frame, "sys_")
+		if stackFrameNoiseRe.MatchString(frame) {
+			continue
+		}
+		if !fn(frame, line) {
+			return
+		}
+	}
+}
+
+// stackSimilarity returns a value in [0, 1] that says how similar two stacks are.
+// Frames closer to the top of the stack have larger weight.
+func stackSimilarity(a, b []string) float64 {
+	weight := func(i int) float64 {
+		return 1 / float64(i+1)
+	}
+	posB := make(map[string]int)
+	total := 0.0
+	for i, frame := range b {
+		posB[frame] = i
+		total += weight(i)
+	}
+	common := 0.0
+	for i, frame := range a {
+		total += weight(i)
+		if j, ok := posB[frame]; ok {
+			common += weight(i) + weight(j)
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return common / total
+}
+
+type relatedBug struct {
+	bug        *Bug
+	similarity float64
+}
+
+// loadRelatedBugs returns bugs (in all namespaces of the same similarity domain,
+// including closed bugs) with stacks similar to the bug's stack, the most similar go first.
+func loadRelatedBugs(c context.Context, bug *Bug) ([]*relatedBug, error) {
+	if len(bug.StackFrames) == 0 {
+		return nil, nil
+	}
+	domain := getNsConfig(c, bug.Namespace).SimilarityDomain
+	dedup := map[string]bool{
+		bug.keyHash(c): true,
+	}
+	var ret []*relatedBug
+	for _, frame := range bug.StackFrames[:min(len(bug.StackFrames), stackQueryFrames)] {
+		var candidates []*Bug
+		_, err := db.NewQuery("Bug").
+			Filter("StackFrames=", frame).
+			Limit(maxStackCandidates).
+			GetAll(c, &candidates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query bugs: %w", err)
+		}
+		for _, candidate := range candidates {
+			hash := candidate.keyHash(c)
+			if dedup[hash] || candidate.Status == BugStatusDup ||
+				getNsConfig(c, candidate.Namespace).SimilarityDomain != domain {
+				continue
+			}
+			dedup[hash] = true
+			similarity := stackSimilarity(bug.StackFrames, candidate.StackFrames)
+			if similarity < minStackSimilarity {
+				continue
+			}
+			ret = append(ret, &relatedBug{candidate, similarity})
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].similarity > ret[j].similarity
+	})
+	if len(ret) > maxRelatedBugs {
+		ret = ret[:maxRelatedBugs]
+	}
+	return ret, nil
+}
+
+func apiRelatedBugs(c context.Context, payload io.Reader) (interface{}, error) {
+	req := new(dashapi.RelatedBugsReq)
+	if err := json.NewDecoder(payload).Decode(req); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request: %w", err)
+	}
+	bug, _, err := findBugByReportingID(c, req.BugID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the bug: %w", err)
+	}
+	reqReporting, _ := bugReportingByID(bug, req.BugID)
+	if reqReporting == nil {
+		return nil, fmt.Errorf("failed to find the bug reporting")
+	}
+	reporting := getNsConfig(c, bug.Namespace).ReportingByName(reqReporting.Name)
+	if reporting == nil {
+		return nil, fmt.Errorf("reporting %v is missing in config", reqReporting.Name)
+	}
+	related, err := loadRelatedBugs(c, bug)
+	if err != nil {
+		return nil, err
+	}
+	// The bugs are shown to the audience of the reporting, so only return the bugs it may see.
+	accessLevel := reporting.AccessLevel
+	resp := new(dashapi.RelatedBugsResp)
+	for _, item := range related {
+		if accessLevel < item.bug.sanitizeAccess(c, accessLevel) {
+			continue
+		}
+		bugReporting := lastReportedReporting(item.bug)
+		if bugReporting == nil {
+			continue
+		}
+		status, err := item.bug.dashapiStatus()
+		if err != nil {
+			return nil, err
+		}
+		resp.Bugs = append(resp.Bugs, &dashapi.SimilarBugInfo{
+			Title:      item.bug.displayTitle(),
+			Status:     status,
+			Namespace:  item.bug.Namespace,
+			ReproLevel: item.bug.ReproLevel,
+			Link:       fmt.Sprintf("%v/bug?extid=%v", appURL(c), bugReporting.ID),
+			ReportLink: bugReporting.Link,
+			Closed:     item.bug.Closed,
+			Similarity: item.similarity,
+		})
+	}
+	return resp, nil
+}
+
+// StackFramesBackfill is the position of the Bug.StackFrames backfill in a namespace.
+type StackFramesBackfill struct {
+	Cursor string
+	Done   bool
+}
+
+func handleBackfillStackFrames(w http.ResponseWriter, r *http.Request) {
+	c := appengine.NewContext(r)
+	for ns := range getConfig(c).Namespaces {
+		if err := backfillStackFrames(c, ns, backfillStackFramesCount); err != nil {
+			log.Errorf(c, "failed to backfill stack frames for %s: %v", ns, err)
+		}
+	}
+}
+
+// backfillStackFrames fills in Bug.StackFrames for the next count bugs of the namespace.
+func backfillStackFrames(c context.Context, ns string, count int) error {
+	stateKey := db.NewKey(c, "StackFramesBackfill", ns, 0, nil)
+	state := new(StackFramesBackfill)
+	if err := db.Get(c, stateKey, state); err != nil && err != db.ErrNoSuchEntity {
+		return fmt.Errorf("failed to load the backfill state: %w", err)
+	}
+	if state.Done {
+		return nil
+	}
+	query := db.NewQuery("Bug").Filter("Namespace=", ns).Limit(count)
+	if state.Cursor != "" {
+		cursor, err := db.DecodeCursor(state.Cursor)
+		if err != nil {
+			return fmt.Errorf("failed to decode the backfill cursor: %w", err)
+		}
+		query = query.Start(cursor)
+	}
+	iter := query.Run(c)
+	processed := 0
+	for ; ; processed++ {
+		bug := new(Bug)
+		key, err := iter.Next(bug)
+		if err == db.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch bugs: %w", err)
+		}
+		if len(bug.StackFrames) != 0 || !bug.HasReport {
+			continue
+		}
+		if err := backfillBugStackFrames(c, bug, key); err != nil {
+			return err
+		}
+	}
+	cursor, err := iter.Cursor()
+	if err != nil {
+		return fmt.Errorf("cursor failed while fetching bugs: %w", err)
+	}
+	state.Cursor = cursor.String()
+	state.Done = processed < count
+	if _, err := db.Put(c, stateKey, state); err != nil {
+		return fmt.Errorf("failed to save the backfill state: %w", err)
+	}
+	return nil
+}
+
+func backfillBugStackFrames(c context.Context, bug *Bug, bugKey *db.Key) error {
+	crash, _, err := findCrashForBug(c, bug)
+	if err != nil {
+		// Old bugs may have no crashes left.
+		log.Warningf(c, "bug %v: no crash to backfill stack frames: %v", bug.keyHash(c), err)
+		return nil
+	}
+	report, _, err := getText(c, textCrashReport, crash.Report)
+	if err != nil {
+		return err
+	}
+	frames := crashStackFrames(report)
+	if len(frames) == 0 {
+		return nil
+	}
+	return updateSingleBug(c, bugKey, func(bug *Bug) error {
+		if len(bug.StackFrames) == 0 {
+			bug.StackFrames = frames
+		}
+		return nil
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/stretchr/testify/assert"
+)
+
+const testStackReport = `BUG: KASAN: slab-use-after-free in tcp_write_xmit+0x1a2/0x5e0
+Read of size 8 at addr ffff88801f2b3c40 by task syz-executor/5123
+
+CPU: 1 PID: 5123 Comm: syz-executor Not tainted 6.8.0-syzkaller #0
+Call Trace:
+ <TASK>
+ __dump_stack lib/dump_stack.c:88 [inline]
+ dump_stack_lvl+0x1e7/0x2e0 lib/dump_stack.c:106
+ print_address_description mm/kasan/report.c:377 [inline]
+ print_report+0x167/0x540 mm/kasan/report.c:488
+ kasan_report+0x142/0x180 mm/kasan/report.c:601
+ tcp_write_xmit.isra.0+0x1a2/0x5e0 net/ipv4/tcp_output.c:2712
+ ? tcp_current_mss+0x30/0x30
+ __tcp_push_pending_frames+0x9b/0x340 net/ipv4/tcp_output.c:2972
+ tcp_sendmsg_locked+0x2fb5/0x3fe0 net/ipv4/tcp.c:1324
+ tcp_sendmsg+0x2c/0x50 net/ipv4/tcp.c:1356
+ sock_sendmsg_nosec net/socket.c:730 [inline]
+ __sock_sendmsg+0x221/0x270 net/socket.c:745
+ __sys_sendto+0x3a4/0x4f0 net/socket.c:2191
+ __do_sys_sendto net/socket.c:2203 [inline]
+ __se_sys_sendto net/socket.c:2199 [inline]
+ __x64_sys_sendto+0xde/0x100 net/socket.c:2199
+ do_syscall_x64 arch/x86/entry/common.c:52 [inline]
+ do_syscall_64+0xfb/0x240 arch/x86/entry/common.c:83
+ entry_SYSCALL_64_after_hwframe+0x6d/0x75
+ </TASK>
+`
+
+func TestCrashStackFrames(t *testing.T) {
+	assert.Equal(t, []string{
+		"tcp_write_xmit",
+		"__tcp_push_pending_frames",
+		"tcp_sendmsg_locked",
+		"tcp_sendmsg",
+		"sock_sendmsg_nosec",
+		"__sock_sendmsg",
+		"__sys_sendto",
+		"sys_sendto",
+	}, crashStackFrames([]byte(testStackReport)))
+	assert.Empty(t, crashStackFrames([]byte("no stack here")))
+}
+
+func TestCrashStackFiles(t *testing.T) {
+	assert.Equal(t, []string{
+		"net/ipv4/tcp_output.c",
+		"net/ipv4/tcp.c",
+		"net/socket.c",
+	}, crashStackFiles([]byte(testStackReport)))
+	assert.Empty(t, crashStackFiles([]byte("no stack here")))
+}
+
+func TestStackSimilarity(t *testing.T) {
+	a := []string{"foo", "bar", "baz", "qux"}
+	assert.Equal(t, 1.0, stackSimilarity(a, a))
+	assert.Equal(t, 0.0, stackSimilarity(a, []string{"a", "b"}))
+	assert.Equal(t, 0.0, stackSimilarity(nil, nil))
+	// The same stack with an additional inlined frame on top is still very similar.
+	assert.Greater(t, stackSimilarity(a, []string{"inlined", "foo", "bar", "baz", "qux"}), 0.7)
+	// The same bottom frames are less important.
+	assert.Less(t, stackSimilarity(a, []string{"x", "y", "baz", "qux"}), minStackSimilarity)
+	assert.InDelta(t, stackSimilarity(a, []string{"bar", "foo"}), stackSimilarity([]string{"bar", "foo"}, a), 1e-9)
+}
+
+func TestRelatedBugs(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	// The same crash in a downstream kernel with a different title.
+	build2 := testBuild(2)
+	c.client2.UploadBuild(build2)
+	crash2 := testCrash(build2, 2)
+	crash2.Title = "KASAN: use-after-free Read in tcp_write_xmit"
+	crash2.Report = []byte(testStackReport)
+	c.client2.ReportCrash(crash2)
+	extID2 := c.pollEmailExtID()
+
+	build1 := testBuild(1)
+	c.client.UploadBuild(build1)
+	crash1 := testCrash(build1, 1)
+	crash1.Title = "KASAN: slab-use-after-free Read in tcp_write_xmit"
+	crash1.Report = bytes.ReplaceAll([]byte(testStackReport), []byte("0x"), []byte("0x1"))
+	c.client.ReportCrash(crash1)
+	// An unrelated bug.
+	crash3 := testCrash(build1, 3)
+	crash3.Report = []byte(`WARNING in foo
+ foo+0x1/0x2 fs/foo.c:1
+ tcp_sendmsg+0x2c/0x50 net/ipv4/tcp.c:1356
+`)
+	c.client.ReportCrash(crash3)
+	reports := c.client.pollBugs(2)
+	if reports[0].Title != crash1.Title {
+		reports[0], reports[1] = reports[1], reports[0]
+	}
+
+	resp, err := c.client.RelatedBugs(&dashapi.RelatedBugsReq{BugID: reports[0].ID})
+	c.expectOK(err)
+	c.expectEQ(len(resp.Bugs), 1)
+	assert.InDelta(t, 1, resp.Bugs[0].Similarity, 1e-9)
+	resp.Bugs[0].Similarity = 0
+	c.expectEQ(resp.Bugs, []*dashapi.SimilarBugInfo{{
+		Title:      crash2.Title,
+		Namespace:  "test2",
+		Status:     dashapi.BugStatusOpen,
+		ReproLevel: dashapi.ReproLevelNone,
+		Link:       "https://testapp.appspot.com/bug?extid=" + extID2,
+	}})
+
+	resp, err = c.client.RelatedBugs(&dashapi.RelatedBugsReq{BugID: reports[1].ID})
+	c.expectOK(err)
+	c.expectEQ(len(resp.Bugs), 0)
+
+	// The related bug is shown on the bug page.
+	reply, err := c.AuthGET(AccessAdmin, fmt.Sprintf("/bug?extid=%v", reports[0].ID))
+	c.expectOK(err)
+	c.expectTrue(bytes.Contains(reply, []byte("Possibly related bugs (1)")))
+	c.expectTrue(bytes.Contains(reply, []byte(crash2.Title)))
+}
@@ -832,9 +832,9 @@ func (list *lazyJobList) lastMatch(job *Job) (*Job, error) {
 	return best, nil
 }
 
-func doneCrossTreeBisection(c context.Context, jobKey *db.Key, job *Job) error {
-	if job.Type != JobBisectFix || job.MergeBaseRepo == "" {
-		// Not a cross tree bisection.
+func doneFixCandidateBisection(c context.Context, jobKey *db.Key, job *Job) error {
+	if !job.IsFixCandidate() {
+		// Neither a cross tree nor a fix candidate bisection.
 		return nil
 	}
 	if job.Error != 0 || job.isUnreliableBisect() || len(job.Commits) != 1 {
@@ -106,6 +106,19 @@ type Build struct {
 	Commits             []string // see BuilderPoll
 	FixCommits          []Commit
 	Assets              []NewAsset
+	// Kernel commits between KernelCommitsBase (the kernel commit of the previous build
+	// uploaded by the manager) and KernelCommit, the newest go first.
+	// Used to attribute new bugs to commit ranges.
+	KernelCommitsBase string
+	KernelCommits     []BuildCommit
+	// Set if KernelCommits does not include all commits from the range.
+	KernelCommitsTruncated bool
+}
+
+type BuildCommit struct {
+	Hash  string
+	Title string
+	Files []string
 }
 
 type Commit struct {
@@ -198,6 +211,10 @@ type JobPollResp struct {
 	ReproOpts         []byte
 	ReproSyz          []byte
 	ReproC            []byte
+	// The probability that the reproducer crashes the kernel in a single run, 0 if unknown.
+	ReproReliability float64
+	// If set, fix bisection only considers commits that touch these files.
+	BisectPaths []string
 }
 
 type JobDoneReq struct {
@@ -217,6 +234,10 @@ type JobDoneReq struct {
 	// If there are more than 1: suspected commits due to skips (broken build/boot).
 	Commits []Commit
 	Flags   JobDoneFlags
+	// The probability that the bisection result is correct, 0 if unknown.
+	Confidence float64
+	// For first-parent bisection, the merge that brought the culprit commit into the tree.
+	Merge *Commit
 }
 
 type JobType int
@@ -333,11 +354,12 @@ type Crash struct {
 	Assets      []NewAsset
 	GuiltyFiles []string
 	// The following is optional and is filled only after repro.
-	ReproOpts     []byte
-	ReproSyz      []byte
-	ReproC        []byte
-	ReproLog      []byte
-	OriginalTitle string // Title before we began bug reproduction.
+	ReproOpts        []byte
+	ReproSyz         []byte
+	ReproC           []byte
+	ReproLog         []byte
+	ReproReliability float64 // the probability that the reproducer crashes the kernel in a single run
+	OriginalTitle    string  // Title before we began bug reproduction.
 }
 
 type ReportCrashResp struct {
@@ -480,6 +502,21 @@ type BugReport struct {
 	Subsystems     []BugSubsystem
 	ReportElements *ReportElements
 	LabelMessages  map[string]string // notification messages for bug labels
+	CommitRange    *CommitRange
+}
+
+// CommitRange is the kernel commit range where a new bug has likely been introduced:
+// Good is the last commit that was fuzzed long enough without the crash,
+// and Bad is the first commit with the crash.
+type CommitRange struct {
+	KernelRepo   string
+	KernelBranch string
+	Good         string
+	Bad          string
+	// Commits from the range that touch the source files from the crash stack.
+	Suspects []Commit
+	// Set if not all commits from the range are known, so some suspects may be missing.
+	Incomplete bool
 }
 
 type ReportElements struct {
@@ -521,6 +558,9 @@ type BisectResult struct {
 	CrashReportLink string
 	Fix             bool
 	CrossTree       bool
+	FixCandidate    bool    // the commit is only a suspected fix (see mail_fix_candidate.txt)
+	Confidence      float64 // 0 if unknown
+	Merge           *Commit // the merge that brought Commit into the tree, if known
 	// In case a missing backport was backported.
 	Backported *Commit
 }
@@ -624,7 +664,8 @@ type BugNotification struct {
 	Maintainers []string // deprecated in favor of Recipients
 	Link        string
 	Recipients  Recipients
-	TreeJobs    []*JobInfo // set for some BugNotifLabel
+	TreeJobs    []*JobInfo  // set for some BugNotifLabel
+	CrashSpike  *CrashSpike // set for BugNotifCrashSpike
 	// Public is what we want all involved people to see (e.g. if we notify about a wrong commit title,
 	// people need to see it and provide the right title). Not public is what we want to send only
 	// to a minimal set of recipients (our mailing list) (e.g. notification about an obsoleted bug
@@ -632,6 +673,23 @@ type BugNotification struct {
 	Public bool
 }
 
+// CrashSpike describes a sudden increase of the bug crash rate after a new kernel build.
+type CrashSpike struct {
+	// The first day with the increased crash rate.
+	Date time.Time
+	// Crashes per hour of fuzzing before and after Date.
+	BaselineRate float64
+	Rate         float64
+	KernelRepo   string
+	KernelBranch string
+	// The kernel commit that was fuzzed before the spike and the new one.
+	// The suspicious commit range is GoodCommit..BadCommit.
+	GoodCommit string
+	BadCommit  string
+	// Link to the log of BadCommit.
+	Link string
+}
+
 type PollNotificationsRequest struct {
 	Type string
 }
@@ -867,6 +925,7 @@ type SimilarBugInfo struct {
 	ReportLink string
 	Closed     time.Time
 	ReproLevel ReproLevel
+	Similarity float64 // similarity of crash stacks in [0, 1], set only by RelatedBugs
 }
 
 func (dash *Dashboard) LoadFullBug(req *LoadFullBugReq) (*FullBugInfo, error) {
@@ -875,6 +934,22 @@ func (dash *Dashboard) LoadFullBug(req *LoadFullBugReq) (*FullBugInfo, error) {
 	return resp, err
 }
 
+type RelatedBugsReq struct {
+	BugID string // reporting ID of the bug
+}
+
+type RelatedBugsResp struct {
+	Bugs []*SimilarBugInfo
+}
+
+// RelatedBugs returns bugs (possibly in other namespaces and closed) with crash stacks
+// similar to the crash stack of the bug, the most similar bugs go first.
+func (dash *Dashboard) RelatedBugs(req *RelatedBugsReq) (*RelatedBugsResp, error) {
+	resp := new(RelatedBugsResp)
+	err := dash.Query("related_bugs", req, resp)
+	return resp, err
+}
+
 type UpdateReportReq struct {
 	BugID       string
 	CrashID     int64
@@ -920,6 +995,9 @@ const (
 	// New bug label has been assigned (only if enabled).
 	// Text contains the custome message that needs to be delivered to the user.
 	BugNotifLabel
+	// The bug crashes much more often after a new kernel build.
+	// CrashSpike contains the details.
+	BugNotifCrashSpike
 )
 
 const (
@@ -969,6 +1047,8 @@ type JobInfo struct {
 	ReproSyzLink     string
 	Commit           *Commit   // for conclusive bisection
 	Commits          []*Commit // for inconclusive bisection
+	Confidence       float64   // for bisection, 0 if unknown
+	Merge            *Commit   // the merge that brought Commit into the tree, if known
 	Reported         bool
 	InvalidatedBy    string
 	TreeOrigin       bool
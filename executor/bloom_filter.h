@@ -0,0 +1,81 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// BloomFilter is a fixed-size signal bloom filter that can be placed in shared memory.
+//
+// It's used as the max signal filter with the compact cover encoding (see ConnectReplyRaw.compact_cover).
+// Unlike CoverFilter, its size does not depend on the amount of signal, so the manager can send
+// the whole max signal on connect as the filter bits (see pkg/flatrpc.MaxSignalBloom).
+// A false positive makes us drop a new signal value, for 4M values the false positive rate is ~0.5%.
+class BloomFilter
+{
+public:
+	// Must match flatrpc.MaxSignalBloomSize.
+	static constexpr size_t kSize = 8 << 20;
+
+	BloomFilter()
+	    : shmem_(kSize),
+	      bits_(static_cast<uint8*>(shmem_.Mem()))
+	{
+	}
+
+	BloomFilter(int fd, void* preferred = nullptr)
+	    : shmem_(fd, preferred, kSize, false),
+	      bits_(static_cast<uint8*>(shmem_.Mem()))
+	{
+	}
+
+	void Insert(uint64 sig)
+	{
+		for (size_t i = 0; i < kHashes; i++) {
+			uint64 bit = Bit(sig, i);
+			bits_[bit / 8] |= 1 << (bit % 8);
+		}
+	}
+
+	bool Contains(uint64 sig)
+	{
+		for (size_t i = 0; i < kHashes; i++) {
+			uint64 bit = Bit(sig, i);
+			if (!(bits_[bit / 8] & (1 << (bit % 8))))
+				return false;
+		}
+		return true;
+	}
+
+	// Merge adds the filter bits sent by the manager starting at the offset.
+	void Merge(size_t offset, const uint8* data, size_t size)
+	{
+		if (offset > kSize || size > kSize - offset)
+			failmsg("bad bloom filter update", "offset=%zu size=%zu", offset, size);
+		for (size_t i = 0; i < size; i++)
+			bits_[offset + i] |= data[i];
+	}
+
+	int FD() const
+	{
+		return shmem_.FD();
+	}
+
+	// Bit returns the i-th bit that represents the value (must match bloomBits in pkg/flatrpc/compact.go).
+	static uint64 Bit(uint64 sig, size_t i)
+	{
+		// This is the 64-bit finalizer of MurmurHash3.
+		uint64 h = sig;
+		h ^= h >> 33;
+		h *= 0xff51afd7ed558ccdull;
+		h ^= h >> 33;
+		h *= 0xc4ceb9fe1a85ec53ull;
+		h ^= h >> 33;
+		return ((h & 0xffffffff) + i * (h >> 32)) % (kSize * 8);
+	}
+
+private:
+	static constexpr size_t kHashes = 3;
+
+	ShmemFile shmem_;
+	uint8* bits_ = nullptr;
+
+	BloomFilter(const BloomFilter&) = delete;
+	BloomFilter& operator=(const BloomFilter&) = delete;
+};
@@ -246,6 +246,7 @@ const int kInFd = 3;
 const int kOutFd = 4;
 const int kMaxSignalFd = 5;
 const int kCoverFilterFd = 6;
+const int kMaxSignalBloomFd = 7;
 static OutputData* output_data;
 static std::optional<ShmemBuilder> output_builder;
 static uint32 output_size;
@@ -325,6 +326,7 @@ static int running;
 static uint32 completed;
 static bool is_kernel_64_bit;
 static bool use_cover_edges;
+static bool use_compact_cover;
 
 static uint8* input_data;
 
@@ -408,6 +410,7 @@ const uint64 kInMagic = 0xbadc0ffeebadface;
 struct handshake_req {
 	uint64 magic;
 	bool use_cover_edges;
+	bool use_compact_cover;
 	bool is_kernel_64_bit;
 	rpc::ExecEnv flags;
 	uint64 pid;
@@ -475,6 +478,8 @@ static bool copyout(char* addr, uint64 size, uint64* res);
 static void setup_control_pipes();
 static bool coverage_filter(uint64 pc);
 static rpc::ComparisonRaw convert(const kcov_comparison_t& cmp);
+static uint32 push_compact(flatbuffers::FlatBufferBuilder& fbb, uint64 val, uint64 prev);
+static uint32 end_compact(flatbuffers::FlatBufferBuilder& fbb, uint32 size);
 static flatbuffers::span<uint8_t> finish_output(OutputData* output, int proc_id, uint64 req_id, uint32 num_calls,
 						uint64 elapsed, uint64 freshness, uint32 status, bool hanged,
 						const std::vector<uint8_t>* process_output);
@@ -548,6 +553,7 @@ static feature_t features[] = {};
 #include "shmem.h"
 
 #include "conn.h"
+#include "bloom_filter.h"
 #include "cover_filter.h"
 #include "files.h"
 #include "subprocess.h"
@@ -559,6 +565,7 @@ static feature_t features[] = {};
 #include "test.h"
 
 static std::optional<CoverFilter> max_signal;
+static std::optional<BloomFilter> max_signal_bloom;
 static std::optional<CoverFilter> cover_filter;
 
 #if SYZ_HAVE_SANDBOX_ANDROID
@@ -627,6 +634,10 @@ int main(int argc, char** argv)
 			cover_filter.emplace(kCoverFilterFd, reinterpret_cast<void*>(0x110f230000ull));
 			close(kCoverFilterFd);
 		}
+		if (fcntl(kMaxSignalBloomFd, F_GETFD) != -1) {
+			max_signal_bloom.emplace(kMaxSignalBloomFd, reinterpret_cast<void*>(0x1112230000ull));
+			close(kMaxSignalBloomFd);
+		}
 
 		setup_control_pipes();
 		receive_handshake();
@@ -771,6 +782,7 @@ void parse_handshake(const handshake_req& req)
 #endif
 	is_kernel_64_bit = req.is_kernel_64_bit;
 	use_cover_edges = req.use_cover_edges;
+	use_compact_cover = req.use_compact_cover;
 	procid = req.pid;
 	syscall_timeout_ms = req.syscall_timeout_ms;
 	program_timeout_ms = req.program_timeout_ms;
@@ -1166,16 +1178,52 @@ thread_t* schedule_call(int call_index, int call_num, uint64 copyout_index, uint
 	return th;
 }
 
+// push_compact writes val in the compact form (see CallInfoRaw.signal_packed) and returns the number of bytes.
+// Flatbuffer arrays are written backwards, so prev is the value that follows val in the resulting array.
+static uint32 push_compact(flatbuffers::FlatBufferBuilder& fbb, uint64 val, uint64 prev)
+{
+	uint64 delta = val - prev;
+	uint64 zigzag = (delta << 1) ^ (uint64)((int64_t)delta >> 63);
+	uint8 buf[10];
+	uint32 n = 0;
+	do {
+		buf[n] = zigzag & 0x7f;
+		zigzag >>= 7;
+		if (zigzag)
+			buf[n] |= 0x80;
+		n++;
+	} while (zigzag);
+	for (uint32 i = n; i > 0; i--)
+		fbb.PushElement(buf[i - 1]);
+	return n;
+}
+
+// end_compact finishes a vector written with push_compact.
+// Since the size is not known in advance, StartVector can't align the data for the length prefix,
+// so we move the data to put the alignment padding after it.
+static uint32 end_compact(flatbuffers::FlatBufferBuilder& fbb, uint32 size)
+{
+	const uint32 align = sizeof(flatbuffers::uoffset_t);
+	const uint32 pad = (align - size % align) % align;
+	for (uint32 i = 0; i < pad; i++)
+		fbb.PushElement(uint8(0));
+	uint8* data = fbb.GetCurrentBufferPointer();
+	memmove(data, data + pad, size);
+	memset(data + size, 0, pad);
+	return fbb.EndVector(size);
+}
+
 template <typename cover_data_t>
 uint32 write_signal(flatbuffers::FlatBufferBuilder& fbb, int index, cover_t* cov, bool all)
 {
 	// Write out feedback signals.
 	// Currently it is code edges computed as xor of two subsequent basic block PCs.
-	fbb.StartVector(0, sizeof(uint64));
+	fbb.StartVector(0, use_compact_cover ? sizeof(uint8) : sizeof(uint64));
 	cover_data_t* cover_data = (cover_data_t*)(cov->data + cov->data_offset);
 	if ((char*)(cover_data + cov->size) > cov->data_end)
 		failmsg("too much cover", "cov=%u", cov->size);
 	uint32 nsig = 0;
+	uint64 prev_sig = 0;
 	cover_data_t prev_pc = 0;
 	bool prev_filter = true;
 	for (uint32 i = 0; i < cov->size; i++) {
@@ -1196,9 +1244,18 @@ uint32 write_signal(flatbuffers::FlatBufferBuilder& fbb, int index, cover_t* cov
 			continue;
 		if (!all && max_signal && max_signal->Contains(sig))
 			continue;
+		if (!all && max_signal_bloom && max_signal_bloom->Contains(sig))
+			continue;
+		if (use_compact_cover) {
+			nsig += push_compact(fbb, sig, prev_sig);
+			prev_sig = sig;
+			continue;
+		}
 		fbb.PushElement(uint64(sig));
 		nsig++;
 	}
+	if (use_compact_cover)
+		return end_compact(fbb, nsig);
 	return fbb.EndVector(nsig);
 }
 
@@ -1212,6 +1269,17 @@ uint32 write_cover(flatbuffers::FlatBufferBuilder& fbb, cover_t* cov)
 		std::sort(cover_data, end);
 		cover_size = std::unique(cover_data, end) - cover_data;
 	}
+	if (use_compact_cover) {
+		fbb.StartVector(0, sizeof(uint8));
+		uint32 size = 0;
+		uint64 prev = 0;
+		for (uint32 i = 0; i < cover_size; i++) {
+			uint64 pc = cover_data[cover_size - i - 1] + cov->pc_offset;
+			size += push_compact(fbb, pc, prev);
+			prev = pc;
+		}
+		return end_compact(fbb, size);
+	}
 	fbb.StartVector(cover_size, sizeof(uint64));
 	// Flatbuffer arrays are written backwards, so reverse the order on our side as well.
 	for (uint32 i = 0; i < cover_size; i++)
@@ -1349,9 +1417,13 @@ void write_output(int index, cover_t* cov, rpc::CallFlag flags, uint32 error, bo
 		flags |= rpc::CallFlag::CoverageOverflow;
 	builder.add_flags(flags);
 	builder.add_error(error);
-	if (signal_off)
+	if (signal_off && use_compact_cover)
+		builder.add_signal_packed(signal_off);
+	else if (signal_off)
 		builder.add_signal(signal_off);
-	if (cover_off)
+	if (cover_off && use_compact_cover)
+		builder.add_cover_packed(cover_off);
+	else if (cover_off)
 		builder.add_cover(cover_off);
 	if (comps_off)
 		builder.add_comps(comps_off);
@@ -87,8 +87,8 @@ private:
 class Proc
 {
 public:
-	Proc(Connection& conn, const char* bin, ProcIDPool& proc_id_pool, int& restarting, const bool& corpus_triaged, int max_signal_fd, int cover_filter_fd,
-	     bool use_cover_edges, bool is_kernel_64_bit, uint32 slowdown, uint32 syscall_timeout_ms, uint32 program_timeout_ms)
+	Proc(Connection& conn, const char* bin, ProcIDPool& proc_id_pool, int& restarting, const bool& corpus_triaged, int max_signal_fd, int max_signal_bloom_fd, int cover_filter_fd,
+	     bool use_cover_edges, bool use_compact_cover, bool is_kernel_64_bit, uint32 slowdown, uint32 syscall_timeout_ms, uint32 program_timeout_ms)
 	    : conn_(conn),
 	      bin_(bin),
 	      proc_id_pool_(proc_id_pool),
@@ -96,8 +96,10 @@ public:
 	      restarting_(restarting),
 	      corpus_triaged_(corpus_triaged),
 	      max_signal_fd_(max_signal_fd),
+	      max_signal_bloom_fd_(max_signal_bloom_fd),
 	      cover_filter_fd_(cover_filter_fd),
 	      use_cover_edges_(use_cover_edges),
+	      use_compact_cover_(use_compact_cover),
 	      is_kernel_64_bit_(is_kernel_64_bit),
 	      slowdown_(slowdown),
 	      syscall_timeout_ms_(syscall_timeout_ms),
@@ -199,8 +201,10 @@ private:
 	int& restarting_;
 	const bool& corpus_triaged_;
 	const int max_signal_fd_;
+	const int max_signal_bloom_fd_;
 	const int cover_filter_fd_;
 	const bool use_cover_edges_;
+	const bool use_compact_cover_;
 	const bool is_kernel_64_bit_;
 	const uint32 slowdown_;
 	const uint32 syscall_timeout_ms_;
@@ -322,6 +326,7 @@ private:
 		    {resp_shmem_.FD(), kOutFd},
 		    {max_signal_fd_, kMaxSignalFd},
 		    {cover_filter_fd_, kCoverFilterFd},
+		    {max_signal_bloom_fd_, kMaxSignalBloomFd},
 		};
 		const char* argv[] = {bin_, "exec", nullptr};
 		process_.emplace(argv, fds);
@@ -358,6 +363,7 @@ private:
 		handshake_req req = {
 		    .magic = kInMagic,
 		    .use_cover_edges = use_cover_edges_,
+		    .use_compact_cover = use_compact_cover_,
 		    .is_kernel_64_bit = is_kernel_64_bit_,
 		    .flags = exec_env_,
 		    .pid = static_cast<uint64>(id_),
@@ -542,10 +548,11 @@ public:
 		int num_procs = Handshake();
 		proc_id_pool_.emplace(num_procs);
 		int max_signal_fd = max_signal_ ? max_signal_->FD() : -1;
+		int max_signal_bloom_fd = max_signal_bloom_ ? max_signal_bloom_->FD() : -1;
 		int cover_filter_fd = cover_filter_ ? cover_filter_->FD() : -1;
 		for (int i = 0; i < num_procs; i++)
 			procs_.emplace_back(new Proc(conn, bin, *proc_id_pool_, restarting_, corpus_triaged_,
-						     max_signal_fd, cover_filter_fd, use_cover_edges_, is_kernel_64_bit_, slowdown_,
+						     max_signal_fd, max_signal_bloom_fd, cover_filter_fd, use_cover_edges_, use_compact_cover_, is_kernel_64_bit_, slowdown_,
 						     syscall_timeout_ms_, program_timeout_ms_));
 
 		for (;;)
@@ -556,6 +563,8 @@ private:
 	Connection& conn_;
 	const int vm_index_;
 	std::optional<CoverFilter> max_signal_;
+	// Used instead of max_signal_ with the compact cover encoding.
+	std::optional<BloomFilter> max_signal_bloom_;
 	std::optional<CoverFilter> cover_filter_;
 	std::optional<ProcIDPool> proc_id_pool_;
 	std::vector<std::unique_ptr<Proc>> procs_;
@@ -564,6 +573,7 @@ private:
 	int restarting_ = 0;
 	bool corpus_triaged_ = false;
 	bool use_cover_edges_ = false;
+	bool use_compact_cover_ = false;
 	bool is_kernel_64_bit_ = false;
 	uint32 slowdown_ = 0;
 	uint32 syscall_timeout_ms_ = 0;
@@ -573,10 +583,12 @@ private:
 	{
 		ss << "vm_index=" << runner.vm_index_
 		   << " max_signal=" << !!runner.max_signal_
+		   << " max_signal_bloom=" << !!runner.max_signal_bloom_
 		   << " cover_filter=" << !!runner.cover_filter_
 		   << " restarting=" << runner.restarting_
 		   << " corpus_triaged=" << runner.corpus_triaged_
 		   << " use_cover_edges=" << runner.use_cover_edges_
+		   << " use_compact_cover=" << runner.use_compact_cover_
 		   << " is_kernel_64_bit=" << runner.is_kernel_64_bit_
 		   << " slowdown=" << runner.slowdown_
 		   << " syscall_timeout_ms=" << runner.syscall_timeout_ms_
@@ -664,11 +676,14 @@ private:
 		      conn_reply.program_timeout_ms, static_cast<uint64>(conn_reply.features));
 		leak_frames_ = conn_reply.leak_frames;
 		use_cover_edges_ = conn_reply.cover_edges;
+		use_compact_cover_ = conn_reply.compact_cover;
 		is_kernel_64_bit_ = is_kernel_64_bit = conn_reply.kernel_64_bit;
 		slowdown_ = conn_reply.slowdown;
 		syscall_timeout_ms_ = conn_reply.syscall_timeout_ms;
 		program_timeout_ms_ = conn_reply.program_timeout_ms;
-		if (conn_reply.cover)
+		if (conn_reply.cover && conn_reply.compact_cover)
+			max_signal_bloom_.emplace();
+		else if (conn_reply.cover)
 			max_signal_.emplace();
 
 		// Handshake stage 2: share information requested by the manager.
@@ -750,9 +765,17 @@ private:
 
 	void Handle(const rpc::SignalUpdateRawT& msg)
 	{
-		debug("recv signal update: new=%zu\n", msg.new_max.size());
+		debug("recv signal update: new=%zu bloom=%zu\n", msg.new_max.size(), msg.bloom.size());
+		if (max_signal_bloom_) {
+			max_signal_bloom_->Merge(msg.bloom_offset, msg.bloom.data(), msg.bloom.size());
+			for (auto pc : msg.new_max)
+				max_signal_bloom_->Insert(pc);
+			return;
+		}
 		if (!max_signal_)
 			fail("signal update when no signal filter installed");
+		if (!msg.bloom.empty())
+			fail("bloom filter update without compact cover");
 		for (auto pc : msg.new_max)
 			max_signal_->Insert(pc);
 	}
@@ -939,8 +962,8 @@ static void runner(char** argv, int argc)
 	Connection conn(manager_addr, manager_port);
 
 	// This is required to make Subprocess fd remapping logic work.
-	// kCoverFilterFd is the largest fd we set in the child processes.
-	for (int fd = conn.FD(); fd < kCoverFilterFd;)
+	// kMaxSignalBloomFd is the largest fd we set in the child processes.
+	for (int fd = conn.FD(); fd < kMaxSignalBloomFd;)
 		fd = dup(fd);
 
 	Runner(conn, vm_index, argv[0]);
@@ -275,6 +275,68 @@ static int test_cover_filter()
 	return ret;
 }
 
+static int test_compact_cover()
+{
+	// Must match the expectations in pkg/flatrpc/compact_test.go.
+	std::vector<uint64> vals = {3, 1, 2, 300};
+	std::vector<uint8> want = {0x04, 0x01, 0xd3, 0x04, 0xd8, 0x04};
+	flatbuffers::FlatBufferBuilder fbb;
+	fbb.StartVector(0, sizeof(uint8));
+	uint32 size = 0;
+	uint64 prev = 0;
+	for (auto it = vals.rbegin(); it != vals.rend(); it++) {
+		size += push_compact(fbb, *it, prev);
+		prev = *it;
+	}
+	auto off = flatbuffers::Offset<flatbuffers::Vector<uint8>>(end_compact(fbb, size));
+	fbb.Finish(off);
+	auto vec = flatbuffers::GetRoot<flatbuffers::Vector<uint8>>(fbb.GetBufferPointer());
+	std::vector<uint8> got(vec->begin(), vec->end());
+	if (got != want) {
+		printf("got %zu bytes:", got.size());
+		for (auto b : got)
+			printf(" 0x%02x", b);
+		printf("\n");
+		return 1;
+	}
+	return 0;
+}
+
+static int test_max_signal_bloom()
+{
+	// Must match the expectations in pkg/flatrpc/compact_test.go.
+	const uint64 sig = 0xffffffff81000010ull;
+	const uint64 want[] = {46605348, 62131722, 10549232};
+	int ret = 0;
+	for (size_t i = 0; i < sizeof(want) / sizeof(want[0]); i++) {
+		uint64 bit = BloomFilter::Bit(sig, i);
+		if (bit != want[i]) {
+			printf("bit %zu is %llu, want %llu\n", i, bit, want[i]);
+			ret = 1;
+		}
+	}
+	BloomFilter filter;
+	BloomFilter child(filter.FD());
+	// The manager sends the filter bits in parts.
+	std::vector<uint8> part(8);
+	part[want[0] % 64 / 8] = 1 << (want[0] % 8);
+	filter.Merge(want[0] / 64 * 8, part.data(), part.size());
+	if (filter.Contains(sig)) {
+		printf("filter contains 0x%llx after merging one bit\n", sig);
+		ret = 1;
+	}
+	std::vector<uint64> sigs = {sig, 0, 1, sig + 1, 0xffffffff81000000ull, ~0ull};
+	for (auto v : sigs)
+		filter.Insert(v);
+	for (auto v : sigs) {
+		if (!filter.Contains(v) || !child.Contains(v)) {
+			printf("filter doesn't contain 0x%llx\n", v);
+			ret = 1;
+		}
+	}
+	return ret;
+}
+
 static bool test_one_glob(const char* pattern, std::vector<std::string> want)
 {
 	std::vector<std::string> got = Glob(pattern);
@@ -379,6 +441,8 @@ static struct {
     {"test_syzos", test_syzos},
 #endif
     {"test_cover_filter", test_cover_filter},
+    {"test_compact_cover", test_compact_cover},
+    {"test_max_signal_bloom", test_max_signal_bloom},
     {"test_glob", test_glob},
 };
 
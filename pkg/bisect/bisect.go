@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/google/syzkaller/pkg/build"
@@ -43,6 +45,21 @@ type Config struct {
 	// Kernel.Commit is not reachable from Kernel.Branch.
 	// In this case, bisection starts from their merge base.
 	CrossTree bool
+	// Parallel is the number of commits that are built and tested concurrently.
+	// If it's > 1, cause bisection uses a k-ary search over the commit range,
+	// each concurrently tested commit uses a separate kernel checkout and build dir.
+	Parallel int
+	// FirstParent makes bisection first find the culprit merge on the first-parent chain
+	// of Kernel.Branch and then the culprit among the commits brought in by that merge
+	// (see vcs.Bisecter.BisectFirstParent). Commits that fail to build are skipped.
+	// This is useful for trees like linux-next that consist of merges of other trees.
+	// Parallel is ignored in this mode.
+	FirstParent bool
+	// Paths limits fix bisection to the commits that touch any of these files
+	// (e.g. the files from the crash stack), see env.pathBisect.
+	// The result is only a fix candidate: if the fix is not among these commits,
+	// the bisection fails. Parallel and FirstParent are ignored in this mode.
+	Paths []string
 }
 
 type KernelConfig struct {
@@ -75,6 +92,9 @@ type ReproConfig struct {
 	Opts []byte
 	Syz  []byte
 	C    []byte
+	// Reliability is the probability that a single run of the reproducer crashes the kernel
+	// as estimated during reproduction (repro.Result.Reliability), 0 if unknown.
+	Reliability float64
 }
 
 type env struct {
@@ -86,13 +106,21 @@ type env struct {
 	head         *vcs.Commit
 	kernelConfig []byte
 	inst         instance.Env
-	numTests     int
-	startTime    time.Time
-	buildTime    time.Duration
-	testTime     time.Duration
-	reportTypes  []crash.Type
+	// Workers used for parallel bisection, workers[0] is repo/inst.
+	workers []*worker
+	// Protects numTests, buildTime, testTime and flaky during parallel testing.
+	mu          sync.Mutex
+	numTests    int
+	startTime   time.Time
+	buildTime   time.Duration
+	testTime    time.Duration
+	reportTypes []crash.Type
 	// The current estimate of the reproducer's kernel crashing probability.
 	reproChance float64
+	// The number of crashed and all test runs on commits that have the bug
+	// (plus the prior derived from Repro.Reliability), reproChance is their ratio.
+	reproBad  float64
+	reproRuns float64
 	// The product of our confidence in every bisection step result.
 	confidence float64
 	// Whether we should do 2x more execution runs for every test step.
@@ -105,7 +133,20 @@ type env struct {
 	buildCfg instance.BuildKernelConfig
 }
 
-const MaxNumTests = 20 // number of tests we do per commit
+const MaxNumTests = 20 // max number of tests we do per commit
+
+const (
+	// The probability that a test run crashes in the same way on a commit that does not have the bug
+	// (e.g. due to a different bug with a similar crash).
+	falseCrashChance = 0.01
+	// The number of test runs we need per commit is chosen so that a "good" verdict
+	// has at least this confidence.
+	wantVerdictConfidence = 0.95
+	// We don't make a verdict if its probability is lower than that.
+	minVerdictConfidence = 0.8
+	// Repro.Reliability is considered as good as that number of test runs.
+	reliabilityPriorRuns = 10
+)
 
 // Result describes bisection result:
 // 1. if bisection is conclusive, the single cause/fix commit in Commits
@@ -126,10 +167,14 @@ const MaxNumTests = 20 // number of tests we do per commit
 //   - Commit points to the oldest/latest commit where crash happens.
 //
 // 4. Config contains kernel config used for bisection.
+//
+// 5. In the FirstParent mode, Merge is the merge that brought Commits into the branch
+// (nil if Commits were committed directly into the branch).
 type Result struct {
 	Commits    []*vcs.Commit
 	Report     *report.Report
 	Commit     *vcs.Commit
+	Merge      *vcs.Commit
 	Config     []byte
 	NoopChange bool
 	IsRelease  bool
@@ -154,10 +199,71 @@ func Run(cfg *Config) (*Result, error) {
 	if _, err = repo.CheckoutBranch(cfg.Kernel.Repo, cfg.Kernel.Branch); err != nil {
 		return nil, &build.InfraError{Title: fmt.Sprintf("%v", err)}
 	}
-	return runImpl(cfg, repo, inst)
+	var extra []*worker
+	if cfg.Parallel > 1 && !cfg.Fix && !cfg.FirstParent {
+		if extra, err = newWorkers(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return runImpl(cfg, repo, inst, extra)
+}
+
+// worker is a separate kernel checkout with its own build and test environment.
+type worker struct {
+	repo     vcs.Repo
+	bisecter vcs.Bisecter
+	inst     instance.Env
+	// If set, the messages about the commit tested on the worker go here (see testParallel).
+	trace debugtracer.DebugTracer
+}
+
+// logfOn logs a message about the commit that is being tested on the worker.
+func (env *env) logfOn(w *worker, msg string, args ...interface{}) {
+	if false {
+		_ = fmt.Sprintf(msg, args...) // enable printf checker
+	}
+	if w.trace != nil {
+		w.trace.Log(msg, args...)
+		return
+	}
+	env.cfg.Trace.Log(msg, args...)
 }
 
-func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*Result, error) {
+// newWorkers creates additional workers for parallel bisection.
+// The kernel checkouts are placed next to the main one.
+func newWorkers(cfg *Config) ([]*worker, error) {
+	var ret []*worker
+	for i := 1; i < cfg.Parallel; i++ {
+		mgrCfg := new(mgrconfig.Config)
+		*mgrCfg = *cfg.Manager
+		mgrCfg.KernelSrc = fmt.Sprintf("%v-bisect%v", filepath.Clean(cfg.Manager.KernelSrc), i)
+		mgrCfg.Workdir = filepath.Join(cfg.Manager.Workdir, fmt.Sprintf("bisect%v", i))
+		repo, err := vcs.NewRepo(mgrCfg.TargetOS, mgrCfg.Type, mgrCfg.KernelSrc)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := repo.CheckoutBranch(cfg.Kernel.Repo, cfg.Kernel.Branch); err != nil {
+			return nil, &build.InfraError{Title: fmt.Sprintf("%v", err)}
+		}
+		if cfg.CrossTree {
+			if _, err := repo.CheckoutCommit(cfg.Kernel.Repo, cfg.Kernel.Commit); err != nil {
+				return nil, &build.InfraError{Title: fmt.Sprintf("%v", err)}
+			}
+		}
+		inst, err := instance.NewEnv(mgrCfg, cfg.BuildSemaphore, cfg.TestSemaphore)
+		if err != nil {
+			return nil, err
+		}
+		bisecter, ok := repo.(vcs.Bisecter)
+		if !ok {
+			return nil, fmt.Errorf("bisection is not implemented for %v", cfg.Manager.TargetOS)
+		}
+		ret = append(ret, &worker{repo: repo, bisecter: bisecter, inst: inst})
+	}
+	return ret, nil
+}
+
+func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env, extraWorkers []*worker) (*Result, error) {
 	bisecter, ok := repo.(vcs.Bisecter)
 	if !ok {
 		return nil, fmt.Errorf("bisection is not implemented for %v", cfg.Manager.TargetOS)
@@ -186,6 +292,8 @@ func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*Result, error) {
 			BuildCPUs:    cfg.BuildCPUs,
 		},
 	}
+	env.workers = append([]*worker{{repo: repo, bisecter: bisecter, inst: inst}}, extraWorkers...)
+	env.resetReproChance()
 	head, err := repo.Commit(vcs.HEAD)
 	if err != nil {
 		return nil, err
@@ -230,6 +338,9 @@ func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*Result, error) {
 	if cfg.Fix {
 		what = "good"
 	}
+	if res.Merge != nil {
+		env.logf("the %v commit was merged by %v %v", what, res.Merge.Hash, res.Merge.Title)
+	}
 	if len(res.Commits) > 1 {
 		env.logf("bisection is inconclusive, the first %v commit could be any of:", what)
 		for _, com := range res.Commits {
@@ -281,7 +392,7 @@ func (env *env) bisect() (*Result, error) {
 		return nil, fmt.Errorf("the crash wasn't reproduced on the original commit")
 	}
 	env.reportTypes = testRes.types
-	env.reproChance = testRes.badRatio
+	env.updateReproChance(testRes.bad, testRes.good)
 
 	testRes1, err := env.minimizeConfig()
 	if err != nil {
@@ -292,7 +403,8 @@ func (env *env) bisect() (*Result, error) {
 		// would return a non-nil value of a new report.
 		testRes = testRes1
 		// Overwrite bug's reproducibility - it may be different after config minimization.
-		env.reproChance = testRes.badRatio
+		env.resetReproChance()
+		env.updateReproChance(testRes.bad, testRes.good)
 	}
 
 	bad, good, results1, fatalResult, err := env.commitRange()
@@ -308,13 +420,24 @@ func (env *env) bisect() (*Result, error) {
 	for _, res := range results1 {
 		env.results[res.com.Hash] = res
 	}
-	commits, err := env.bisecter.Bisect(bad.Hash, good.Hash, cfg.Trace, env.testPredicate)
+	var commits []*vcs.Commit
+	var merge *vcs.Commit
+	if cfg.Fix && len(cfg.Paths) != 0 {
+		commits, err = env.pathBisect(bad, good)
+	} else if cfg.FirstParent {
+		merge, commits, err = env.bisecter.BisectFirstParent(bad.Hash, good.Hash, cfg.Trace, env.testPredicate)
+	} else if len(env.workers) > 1 {
+		commits, err = env.karyBisect(bad, good)
+	} else {
+		commits, err = env.bisecter.Bisect(bad.Hash, good.Hash, cfg.Trace, env.testPredicate)
+	}
 	if err != nil {
 		return nil, err
 	}
 	env.logf("accumulated error probability: %0.2f", 1.0-env.confidence)
 	res := &Result{
 		Commits:    commits,
+		Merge:      merge,
 		Config:     env.kernelConfig,
 		Confidence: env.confidence,
 	}
@@ -449,7 +572,7 @@ func (env *env) detectNoopChange(com *vcs.Commit) (bool, error) {
 		if _, err := env.repo.SwitchCommit(parent); err != nil {
 			return false, err
 		}
-		_, kernelSign, err := env.build()
+		_, kernelSign, err := env.build(env.workers[0])
 		if err != nil {
 			return false, err
 		}
@@ -595,19 +718,22 @@ type testResult struct {
 	rep        *report.Report
 	types      []crash.Type
 	kernelSign string
+	// The number of crashed and not crashed test runs.
+	bad  int
+	good int
 	// The ratio of bad/(good+bad) results.
 	badRatio float64
 	// An estimate how much we can trust the result.
 	confidence float64
 }
 
-func (env *env) build() (*vcs.Commit, string, error) {
-	current, err := env.repo.Commit(vcs.HEAD)
+func (env *env) build(w *worker) (*vcs.Commit, string, error) {
+	current, err := w.repo.Commit(vcs.HEAD)
 	if err != nil {
 		return nil, "", err
 	}
 
-	bisectEnv, err := env.bisecter.EnvForCommit(
+	bisectEnv, err := w.bisecter.EnvForCommit(
 		env.cfg.DefaultCompiler, env.cfg.CompilerType,
 		env.cfg.BinDir, current.Hash, env.kernelConfig,
 		env.cfg.Kernel.Backports,
@@ -615,22 +741,24 @@ func (env *env) build() (*vcs.Commit, string, error) {
 	if err != nil {
 		return current, "", err
 	}
-	env.logf("testing commit %v %v", current.Hash, env.cfg.CompilerType)
+	env.logfOn(w, "testing commit %v %v", current.Hash, env.cfg.CompilerType)
 	buildStart := time.Now()
 	buildCfg := env.buildCfg
 	buildCfg.CompilerBin = bisectEnv.Compiler
 	buildCfg.KernelConfig = bisectEnv.KernelConfig
-	if err := env.inst.CleanKernel(&buildCfg); err != nil {
+	if err := w.inst.CleanKernel(&buildCfg); err != nil {
 		return current, "", fmt.Errorf("kernel clean failed: %w", err)
 	}
-	_, imageDetails, err := env.inst.BuildKernel(&buildCfg)
+	_, imageDetails, err := w.inst.BuildKernel(&buildCfg)
 	if imageDetails.CompilerID != "" {
-		env.logf("compiler: %v", imageDetails.CompilerID)
+		env.logfOn(w, "compiler: %v", imageDetails.CompilerID)
 	}
 	if imageDetails.Signature != "" {
-		env.logf("kernel signature: %v", imageDetails.Signature)
+		env.logfOn(w, "kernel signature: %v", imageDetails.Signature)
 	}
+	env.mu.Lock()
 	env.buildTime += time.Since(buildStart)
+	env.mu.Unlock()
 	return current, imageDetails.Signature, err
 }
 
@@ -638,11 +766,17 @@ func (env *env) build() (*vcs.Commit, string, error) {
 // Hence recoverable errors must be handled and the callers must treat testResult with care.
 // e.g. testResult.verdict will be vcs.BisectSkip for a broken build, but err will be nil.
 func (env *env) test() (*testResult, error) {
+	return env.testOn(env.workers[0])
+}
+
+// testOn tests the commit currently checked out in the worker's repo.
+// It can be called concurrently for different workers.
+func (env *env) testOn(w *worker) (*testResult, error) {
 	cfg := env.cfg
 	if cfg.Timeout != 0 && time.Since(env.startTime) > cfg.Timeout {
 		return nil, fmt.Errorf("bisection is taking too long (>%v), aborting", cfg.Timeout)
 	}
-	current, kernelSign, err := env.build()
+	current, kernelSign, err := env.build(w)
 	res := &testResult{
 		verdict:    vcs.BisectSkip,
 		com:        current,
@@ -665,43 +799,48 @@ func (env *env) test() (*testResult, error) {
 			env.saveDebugFile(current.Hash, 0, kerr.Output)
 		} else {
 			errInfo += err.Error()
-			env.logf("%v", err)
+			env.logfOn(w, "%v", err)
 		}
 
-		env.logf("%s", errInfo)
+		env.logfOn(w, "%s", errInfo)
 		res.rep = &report.Report{Title: errInfo}
 		return res, nil
 	}
 
-	numTests := MaxNumTests / 2
-	if env.flaky || env.numTests == 0 {
-		// Use twice as many instances if the bug is flaky and during initial testing
-		// (as we don't know yet if it's flaky or not).
-		numTests *= 2
+	numTests := env.runsNeeded()
+	env.mu.Lock()
+	if env.flaky {
+		numTests = MaxNumTests
 	}
 	env.numTests++
+	env.mu.Unlock()
 
 	testStart := time.Now()
 
-	results, err := env.inst.Test(numTests, cfg.Repro.Syz, cfg.Repro.Opts, cfg.Repro.C)
+	results, err := w.inst.Test(numTests, cfg.Repro.Syz, cfg.Repro.Opts, cfg.Repro.C)
+	env.mu.Lock()
 	env.testTime += time.Since(testStart)
+	env.mu.Unlock()
 	if err != nil {
 		problem := fmt.Sprintf("repro testing failure: %v", err)
-		env.log(problem)
+		env.logfOn(w, "%v", problem)
 		return res, &build.InfraError{Title: problem}
 	}
-	bad, good, infra, rep, types := env.processResults(current, results)
-	res.verdict, err = env.bisectionDecision(len(results), bad, good, infra)
+	bad, good, infra, rep, types := env.processResults(w, current, results)
+	res.verdict, res.confidence, err = env.bisectionDecision(len(results), bad, good, infra)
 	if err != nil {
 		return nil, err
 	}
+	if res.verdict == vcs.BisectSkip {
+		env.logfOn(w, "unable to determine the verdict: %d good and %d bad runs (wanted %d in total), "+
+			"bug probability %.3f", good, bad, len(results)/2, env.bugProbability(bad, good))
+	}
+	res.bad, res.good = bad, good
 	if bad+good > 0 {
 		res.badRatio = float64(bad) / float64(bad+good)
 	}
-	if res.verdict == vcs.BisectGood {
-		// The result could be a false negative.
-		res.confidence = 1.0 - math.Pow(1.0-env.reproChance, float64(good))
-		env.logf("false negative chance: %.3f", 1.0-res.confidence)
+	if res.verdict != vcs.BisectSkip {
+		env.logfOn(w, "verdict confidence: %.3f", res.confidence)
 	}
 	if res.verdict == vcs.BisectSkip {
 		res.rep = &report.Report{
@@ -824,37 +963,75 @@ func (env *env) revisionHadBug() (bool, error) {
 	return false, errUnknownBugPresence
 }
 
-func (env *env) bisectionDecision(total, bad, good, infra int) (vcs.BisectResult, error) {
+// bisectionDecision returns the verdict for the test results and the probability that it's correct.
+func (env *env) bisectionDecision(total, bad, good, infra int) (vcs.BisectResult, float64, error) {
 	// Boot errors, image test errors, skipped crashes.
 	skip := total - bad - good - infra
 
 	wantBadRuns := max(2, (total-infra)/6) // For 10 runs, require 2 crashes. For 20, require 3.
 	wantGoodRuns := total / 2
 	wantTotalRuns := total / 2
-	if env.flaky {
+	env.mu.Lock()
+	flaky := env.flaky
+	env.mu.Unlock()
+	if flaky {
 		// The reproducer works less than 50% of time, so we need really many good results.
 		wantGoodRuns = total * 3 / 4
 	}
-	if bad == 0 && good >= wantGoodRuns {
+	// On top of that, the verdict must be likely enough given the reproducer reliability.
+	hasBug := env.bugProbability(bad, good)
+	if bad == 0 && good >= wantGoodRuns && 1-hasBug >= minVerdictConfidence {
 		// We need a big enough number of good results, otherwise the chance of a false
 		// positive is too high.
-		return vcs.BisectGood, nil
-	} else if bad >= wantBadRuns && (good+bad) >= wantTotalRuns {
+		return vcs.BisectGood, 1 - hasBug, nil
+	} else if bad >= wantBadRuns && (good+bad) >= wantTotalRuns && hasBug >= minVerdictConfidence {
 		// We need enough (good+bad) results to conclude that the kernel revision itself
 		// is not too broken.
-		return vcs.BisectBad, nil
+		return vcs.BisectBad, hasBug, nil
 	} else if infra > skip {
 		// We have been unable to determine a verdict mostly because of infra errors.
 		// Abort the bisection.
-		return vcs.BisectSkip,
+		return vcs.BisectSkip, 1.0,
 			&build.InfraError{Title: "unable to determine the verdict because of infra errors"}
 	}
-	env.logf("unable to determine the verdict: %d good runs (wanted %d), for bad wanted %d in total, got %d",
-		good, wantGoodRuns, wantTotalRuns, good+bad)
-	return vcs.BisectSkip, nil
+	return vcs.BisectSkip, 1.0, nil
+}
+
+// bugProbability returns the posterior probability that the tested commit has the bug
+// given the number of crashed and not crashed test runs (the prior probability is 50%).
+func (env *env) bugProbability(bad, good int) float64 {
+	chance := env.reproChance
+	if env.reproRuns == 0 && bad+good != 0 {
+		// We know nothing about the reproducer yet, so use the best guess for this commit.
+		chance = float64(bad) / float64(bad+good)
+	}
+	chance = clampReproChance(chance)
+	withBug := float64(bad)*math.Log(chance) + float64(good)*math.Log(1-chance)
+	withoutBug := float64(bad)*math.Log(falseCrashChance) + float64(good)*math.Log(1-falseCrashChance)
+	return 1 / (1 + math.Exp(withoutBug-withBug))
 }
 
-func (env *env) processResults(current *vcs.Commit, results []instance.EnvTestResult) (
+// clampReproChance prevents a single unexpected test run result from being a definitive proof.
+func clampReproChance(chance float64) float64 {
+	return min(max(chance, 0.05), 0.95)
+}
+
+// runsNeeded returns the number of test runs that should be enough to confidently
+// say that a commit does not have the bug given the current reproducer reliability estimate.
+func (env *env) runsNeeded() int {
+	if env.reproRuns == 0 {
+		// This is the initial testing and we don't know the reliability yet.
+		return MaxNumTests
+	}
+	for runs := MaxNumTests / 2; runs < MaxNumTests; runs++ {
+		if 1-env.bugProbability(0, runs) >= wantVerdictConfidence {
+			return runs
+		}
+	}
+	return MaxNumTests
+}
+
+func (env *env) processResults(w *worker, current *vcs.Commit, results []instance.EnvTestResult) (
 	bad, good, infra int, rep *report.Report, types []crash.Type) {
 	var verdicts []string
 	var reports []*report.Report
@@ -904,10 +1081,10 @@ func (env *env) processResults(current *vcs.Commit, results []instance.EnvTestRe
 		unique[verdict] = true
 	}
 	if len(unique) == 1 {
-		env.logf("all runs: %v", verdicts[0])
+		env.logfOn(w, "all runs: %v", verdicts[0])
 	} else {
 		for i, verdict := range verdicts {
-			env.logf("run #%v: %v", i, verdict)
+			env.logfOn(w, "run #%v: %v", i, verdict)
 		}
 	}
 	var others bool
@@ -915,7 +1092,7 @@ func (env *env) processResults(current *vcs.Commit, results []instance.EnvTestRe
 	if rep != nil || others {
 		// TODO: set flaky=true or in some other way indicate that the bug
 		// triggers multiple different crashes?
-		env.logf("representative crash: %v, types: %v", rep.Title, types)
+		env.logfOn(w, "representative crash: %v, types: %v", rep.Title, types)
 	}
 	return
 }
@@ -925,10 +1102,7 @@ func (env *env) processResults(current *vcs.Commit, results []instance.EnvTestRe
 func (env *env) postTestResult(res *testResult) {
 	env.confidence *= res.confidence
 	if res.verdict == vcs.BisectBad {
-		// Let's be conservative and only decrease our reproduction likelihood estimate.
-		// As the estimate of each test() can also be flaky, only partially update the result.
-		avg := (env.reproChance + res.badRatio) / 2.0
-		env.reproChance = min(env.reproChance, avg)
+		env.updateReproChance(res.bad, res.good)
 	}
 }
 
@@ -941,7 +1115,28 @@ func (env *env) updateFlaky(res *testResult) {
 	const flakyThreshold = 0.5
 	if res.verdict == vcs.BisectBad && res.badRatio < flakyThreshold {
 		// Once flaky => always treat as flaky.
+		env.mu.Lock()
 		env.flaky = true
+		env.mu.Unlock()
+	}
+}
+
+// resetReproChance forgets all observed test runs, only the provided reproducer reliability is kept.
+func (env *env) resetReproChance() {
+	env.reproBad, env.reproRuns, env.reproChance = 0, 0, 0
+	if reliability := env.cfg.Repro.Reliability; reliability > 0 {
+		env.reproBad = reliability * reliabilityPriorRuns
+		env.reproRuns = reliabilityPriorRuns
+		env.reproChance = reliability
+	}
+}
+
+// updateReproChance accounts for test runs on a commit that has the bug.
+func (env *env) updateReproChance(bad, good int) {
+	env.reproBad += float64(bad)
+	env.reproRuns += float64(bad + good)
+	if env.reproRuns != 0 {
+		env.reproChance = env.reproBad / env.reproRuns
 	}
 }
 
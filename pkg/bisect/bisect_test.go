@@ -4,9 +4,13 @@
 package bisect
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/syzkaller/pkg/build"
@@ -14,6 +18,7 @@ import (
 	"github.com/google/syzkaller/pkg/hash"
 	"github.com/google/syzkaller/pkg/instance"
 	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/osutil"
 	"github.com/google/syzkaller/pkg/report"
 	"github.com/google/syzkaller/pkg/report/crash"
 	"github.com/google/syzkaller/pkg/vcs"
@@ -180,7 +185,15 @@ func createTestRepo(t *testing.T) string {
 				repo.Git("checkout", "master")
 				repo.Git("merge", "-m", "804", com.Hash)
 			} else {
-				repo.CommitChange(fmt.Sprintf("%v", rv*100+i))
+				title := fmt.Sprintf("%v", rv*100+i)
+				if i%2 == 1 {
+					// Odd commits touch odd.c (for the fix-paths tests).
+					if err := osutil.WriteFile(filepath.Join(baseDir, "odd.c"), []byte(title)); err != nil {
+						t.Fatal(err)
+					}
+					repo.Git("add", "odd.c")
+				}
+				repo.CommitChange(title)
 			}
 			if i == 0 {
 				repo.SetTag(fmt.Sprintf("v%v.0", rv))
@@ -234,13 +247,30 @@ func testBisection(t *testing.T, baseDir string, test BisectionTest) {
 			Config:         []byte("original config"),
 			BaselineConfig: []byte(test.baselineConfig),
 		},
-		CrossTree: test.crossTree,
+		CrossTree:   test.crossTree,
+		FirstParent: test.firstParent,
+		Paths:       test.paths,
 	}
 	inst := &testEnv{
 		t:    t,
 		r:    r,
 		test: test,
 	}
+	var workers []*worker
+	for i := 1; i < test.parallel; i++ {
+		repo, err := vcs.NewRepo(targets.TestOS, targets.TestArch64, t.TempDir(), vcs.OptDontSandbox)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.CheckoutBranch(baseDir, "master"); err != nil {
+			t.Fatal(err)
+		}
+		workers = append(workers, &worker{
+			repo:     repo,
+			bisecter: repo.(vcs.Bisecter),
+			inst:     &testEnv{t: t, r: repo, test: test},
+		})
+	}
 
 	checkBisectionError := func(test BisectionTest, res *Result, err error) {
 		if test.expectErr != (err != nil) {
@@ -261,12 +291,12 @@ func testBisection(t *testing.T, baseDir string, test BisectionTest) {
 		}
 	}
 
-	res, err := runImpl(cfg, r, inst)
+	res, err := runImpl(cfg, r, inst, workers)
 	checkBisectionError(test, res, err)
 	if !test.crossTree && !test.noFakeHashTest {
 		// Should be mitigated via GetCommitByTitle during bisection.
 		cfg.Kernel.Commit = fmt.Sprintf("fake-hash-for-%v-%v", cfg.Kernel.Commit, cfg.Kernel.CommitTitle)
-		res, err = runImpl(cfg, r, inst)
+		res, err = runImpl(cfg, r, inst, workers)
 		checkBisectionError(test, res, err)
 	}
 }
@@ -343,6 +373,11 @@ type BisectionTest struct {
 	resultingConfig string
 	crossTree       bool
 	noFakeHashTest  bool
+	// The number of commits tested in parallel.
+	parallel    int
+	firstParent bool
+	// Files that fix bisection is limited to.
+	paths []string
 
 	extraTest func(t *testing.T, res *Result)
 }
@@ -367,12 +402,68 @@ var bisectionTests = []BisectionTest{
 		flaky:       true,
 		introduced:  "605",
 		extraTest: func(t *testing.T, res *Result) {
-			// False negative probability of each run is ~4%.
-			// We get three "good" results, so our accumulated confidence is ~85%.
+			// The reproducer crashes the kernel in 15% of runs, so every "good" verdict
+			// is a false negative with ~0.5-4.5% probability. Our accumulated confidence is ~87%.
 			assert.Less(t, res.Confidence, 0.9)
 			assert.Greater(t, res.Confidence, 0.8)
 		},
 	},
+	{
+		name:        "cause-first-parent-merge",
+		startCommit: 905,
+		commitLen:   1,
+		expectRep:   true,
+		introduced:  "791",
+		firstParent: true,
+		// The commit is not buildable.
+		brokenStart: 792,
+		brokenEnd:   792,
+		extraTest: func(t *testing.T, res *Result) {
+			assert.Equal(t, "804", res.Merge.Title)
+		},
+	},
+	{
+		name:        "cause-first-parent-direct",
+		startCommit: 905,
+		commitLen:   1,
+		expectRep:   true,
+		introduced:  "602",
+		firstParent: true,
+		extraTest: func(t *testing.T, res *Result) {
+			assert.Nil(t, res.Merge)
+		},
+	},
+	{
+		name:        "cause-finds-cause-parallel",
+		startCommit: 905,
+		commitLen:   1,
+		expectRep:   true,
+		introduced:  "602",
+		parallel:    3,
+		extraTest: func(t *testing.T, res *Result) {
+			assert.Greater(t, res.Confidence, 0.99)
+		},
+	},
+	{
+		name:        "cause-finds-cause-flaky-parallel",
+		startCommit: 905,
+		commitLen:   1,
+		expectRep:   true,
+		flaky:       true,
+		introduced:  "605",
+		parallel:    4,
+		extraTest: func(t *testing.T, res *Result) {
+			assert.Greater(t, res.Confidence, 0.95)
+		},
+	},
+	{
+		name:        "cause-finds-cause-merge-parallel",
+		startCommit: 905,
+		commitLen:   1,
+		expectRep:   true,
+		introduced:  "791",
+		parallel:    2,
+	},
 	// Test bisection returns correct cause with different baseline/config combinations.
 	{
 		name:            "cause-finds-cause-baseline-repro",
@@ -462,6 +553,16 @@ var bisectionTests = []BisectionTest{
 		commitLen:   15,
 		introduced:  "605",
 	},
+	{
+		name:        "cause-inconclusive-parallel",
+		startCommit: 802,
+		brokenStart: 600,
+		brokenEnd:   605,
+		// 600-605 + 700 (merge of 605 and 650).
+		commitLen:  7,
+		introduced: "605",
+		parallel:   3,
+	},
 	// All releases are build broken.
 	{
 		name:        "all-releases-broken",
@@ -483,6 +584,25 @@ var bisectionTests = []BisectionTest{
 		fixCommit:   "500",
 		isRelease:   true,
 	},
+	// Tests that fix bisection limited to the commits touching odd.c finds the fix.
+	{
+		name:        "fix-paths-finds-fix",
+		fix:         true,
+		startCommit: 400,
+		commitLen:   1,
+		fixCommit:   "703",
+		paths:       []string{"odd.c"},
+	},
+	// Tests that fix bisection limited to the commits touching odd.c fails
+	// if the fix does not touch it.
+	{
+		name:        "fix-paths-other-fix",
+		fix:         true,
+		startCommit: 400,
+		fixCommit:   "704",
+		paths:       []string{"odd.c"},
+		expectErr:   true,
+	},
 	// Tests that we do not confuse revisions where the bug was not yet introduced and where it's fixed.
 	// In this case, we have a 700-790-791-792-804 branch, which will be visited during bisection.
 	// As the faulty commit 704 is not reachable from there, kernel wouldn't crash and, without the
@@ -792,54 +912,60 @@ func crashErrors(crashing, nonCrashing int, title string, typ crash.Type) []inst
 func TestBisectVerdict(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		name    string
-		flaky   bool
-		total   int
-		good    int
-		bad     int
-		infra   int
-		skip    int
-		verdict vcs.BisectResult
-		abort   bool
+		name        string
+		flaky       bool
+		reproChance float64
+		total       int
+		good        int
+		bad         int
+		infra       int
+		skip        int
+		verdict     vcs.BisectResult
+		abort       bool
 	}{
 		{
-			name:  "bad-but-many-infra",
-			total: 10,
-			bad:   1,
-			infra: 8,
-			skip:  1,
-			abort: true,
+			name:        "bad-but-many-infra",
+			reproChance: 0.9,
+			total:       10,
+			bad:         1,
+			infra:       8,
+			skip:        1,
+			abort:       true,
 		},
 		{
-			name:    "many-good-and-infra",
-			total:   10,
-			good:    5,
-			infra:   3,
-			skip:    2,
-			verdict: vcs.BisectGood,
+			name:        "many-good-and-infra",
+			reproChance: 0.9,
+			total:       10,
+			good:        5,
+			infra:       3,
+			skip:        2,
+			verdict:     vcs.BisectGood,
 		},
 		{
-			name:    "many-total-and-infra",
-			total:   10,
-			good:    4,
-			bad:     2,
-			infra:   2,
-			skip:    2,
-			verdict: vcs.BisectBad,
+			name:        "many-total-and-infra",
+			reproChance: 0.5,
+			total:       10,
+			good:        4,
+			bad:         2,
+			infra:       2,
+			skip:        2,
+			verdict:     vcs.BisectBad,
 		},
 		{
-			name:    "too-many-skips",
-			total:   10,
-			good:    2,
-			bad:     2,
-			infra:   3,
-			skip:    3,
-			verdict: vcs.BisectSkip,
+			name:        "too-many-skips",
+			reproChance: 0.9,
+			total:       10,
+			good:        2,
+			bad:         2,
+			infra:       3,
+			skip:        3,
+			verdict:     vcs.BisectSkip,
 		},
 		{
-			name:  "flaky-need-more-good",
-			flaky: true,
-			total: 20,
+			name:        "flaky-need-more-good",
+			flaky:       true,
+			reproChance: 0.3,
+			total:       20,
 			// For flaky bisections, we'd want 15.
 			good:    10,
 			infra:   3,
@@ -847,18 +973,20 @@ func TestBisectVerdict(t *testing.T) {
 			verdict: vcs.BisectSkip,
 		},
 		{
-			name:    "flaky-enough-good",
-			flaky:   true,
-			total:   20,
-			good:    15,
-			infra:   3,
-			skip:    2,
-			verdict: vcs.BisectGood,
+			name:        "flaky-enough-good",
+			flaky:       true,
+			reproChance: 0.3,
+			total:       20,
+			good:        15,
+			infra:       3,
+			skip:        2,
+			verdict:     vcs.BisectGood,
 		},
 		{
-			name:  "flaky-too-many-skips",
-			flaky: true,
-			total: 20,
+			name:        "flaky-too-many-skips",
+			flaky:       true,
+			reproChance: 0.3,
+			total:       20,
 			// We want (good+bad) take at least 50%.
 			good:    6,
 			bad:     1,
@@ -867,20 +995,53 @@ func TestBisectVerdict(t *testing.T) {
 			verdict: vcs.BisectSkip,
 		},
 		{
-			name:    "flaky-many-skips",
-			flaky:   true,
-			total:   20,
-			good:    7,
-			bad:     3,
+			name:        "flaky-many-skips",
+			flaky:       true,
+			reproChance: 0.3,
+			total:       20,
+			good:        7,
+			bad:         3,
+			infra:       0,
+			skip:        10,
+			verdict:     vcs.BisectBad,
+		},
+		{
+			name:        "outlier-bad",
+			reproChance: 0.9,
+			total:       10,
+			good:        9,
+			bad:         1,
+			infra:       0,
+			skip:        0,
+			verdict:     vcs.BisectSkip,
+		},
+		{
+			name:        "unreliable-need-more-good",
+			reproChance: 0.1,
+			total:       10,
+			// With 10% reliability, there's a 35% chance to get 10 good runs on a commit with the bug.
+			good:    10,
 			infra:   0,
-			skip:    10,
-			verdict: vcs.BisectBad,
+			skip:    0,
+			verdict: vcs.BisectSkip,
 		},
 		{
-			name:    "outlier-bad",
-			total:   10,
-			good:    9,
-			bad:     1,
+			name:        "unreliable-enough-good",
+			flaky:       true,
+			reproChance: 0.15,
+			total:       20,
+			good:        15,
+			infra:       3,
+			skip:        2,
+			verdict:     vcs.BisectGood,
+		},
+		{
+			name:        "reliable-too-few-bad",
+			reproChance: 0.95,
+			total:       10,
+			// A reliable reproducer would have crashed more than twice on a commit with the bug.
+			good:    8,
+			bad:     2,
 			infra:   0,
 			skip:    0,
 			verdict: vcs.BisectSkip,
@@ -895,17 +1056,40 @@ func TestBisectVerdict(t *testing.T) {
 				cfg: &Config{
 					Trace: &debugtracer.NullTracer{},
 				},
-				flaky: test.flaky,
+				flaky:       test.flaky,
+				reproChance: test.reproChance,
+				reproRuns:   MaxNumTests,
 			}
-			ret, err := env.bisectionDecision(test.total, test.bad, test.good, test.infra)
+			ret, confidence, err := env.bisectionDecision(test.total, test.bad, test.good, test.infra)
 			assert.Equal(t, test.abort, err != nil)
 			if !test.abort {
 				assert.Equal(t, test.verdict, ret)
+				if ret != vcs.BisectSkip {
+					assert.GreaterOrEqual(t, confidence, minVerdictConfidence)
+				}
 			}
 		})
 	}
 }
 
+func TestRunsNeeded(t *testing.T) {
+	t.Parallel()
+	env := &env{cfg: &Config{}}
+	env.resetReproChance()
+	// We don't know anything about the reproducer yet.
+	assert.Equal(t, MaxNumTests, env.runsNeeded())
+	env.cfg.Repro.Reliability = 0.9
+	env.resetReproChance()
+	assert.Equal(t, MaxNumTests/2, env.runsNeeded())
+	// The reproducer turned out to be much less reliable than reported.
+	env.updateReproChance(2, 28)
+	assert.InDelta(t, 0.275, env.reproChance, 0.001)
+	reliable := env.runsNeeded()
+	env.updateReproChance(2, 28)
+	assert.Greater(t, env.runsNeeded(), reliable)
+	assert.LessOrEqual(t, env.runsNeeded(), MaxNumTests)
+}
+
 // nolint: dupl
 func TestMostFrequentReport(t *testing.T) {
 	tests := []struct {
@@ -1069,3 +1253,28 @@ func TestPickReleaseTags(t *testing.T) {
 		})
 	}
 }
+
+func TestPrefixTracer(t *testing.T) {
+	var buf bytes.Buffer
+	trace := &debugtracer.GenericTracer{TraceWriter: &buf}
+	mu := new(sync.Mutex)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pt := &prefixTracer{DebugTracer: trace, mu: mu, prefix: fmt.Sprintf("[worker %v] ", i)}
+			for j := 0; j < 100; j++ {
+				pt.Log("message %v", j)
+			}
+		}()
+	}
+	wg.Wait()
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 400)
+	for _, line := range lines {
+		var worker, msg int
+		_, err := fmt.Sscanf(line, "[worker %d] message %d", &worker, &msg)
+		assert.NoError(t, err, line)
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package bisect
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+	"sync"
+
+	"github.com/google/syzkaller/pkg/debugtracer"
+	"github.com/google/syzkaller/pkg/vcs"
+)
+
+// K-ary bisection tests up to len(env.workers) commits of the range at once.
+// Instead of making a hard good/bad decision for every tested commit (bisectionDecision),
+// it maintains the posterior probability of each commit in the range being the culprit,
+// and updates it with the raw numbers of crashed/not crashed runs of every test.
+// This way a single flaky result does not send the bisection into a wrong direction,
+// and the final probability of the culprit is the confidence of the result.
+// Commits may be re-tested if the results are contradictory.
+
+const (
+	// Bisection stops once a commit has at least this posterior probability.
+	karyConfidence = 0.95
+	// Don't test the same commit more than that number of times.
+	karyMaxTests = 3
+	// A commit is re-tested only if the probability that it has the bug is in (1-karyDecided, karyDecided).
+	karyDecided = 0.99
+)
+
+type culpritModel struct {
+	// Candidate commits, parents go before children.
+	hashes []string
+	index  map[string]int
+	// The posterior probability that the commit is the culprit.
+	prob []float64
+	// The probability of a crash in a single run on a commit with the bug.
+	reproChance float64
+}
+
+func newCulpritModel(hashes []string, reproChance float64) *culpritModel {
+	m := &culpritModel{
+		hashes:      hashes,
+		index:       make(map[string]int),
+		prob:        make([]float64, len(hashes)),
+		reproChance: clampReproChance(reproChance),
+	}
+	for i, hash := range hashes {
+		m.index[hash] = i
+		m.prob[i] = 1 / float64(len(hashes))
+	}
+	return m
+}
+
+// update accounts for a test result of a commit, hasBug[i] says whether the tested commit
+// would have the bug if hashes[i] was the culprit (i.e. whether hashes[i] is reachable from it).
+func (m *culpritModel) update(hasBug []bool, bad, good int) {
+	logLikelihood := func(p float64) float64 {
+		return float64(bad)*math.Log(p) + float64(good)*math.Log(1-p)
+	}
+	withBug, withoutBug := logLikelihood(m.reproChance), logLikelihood(falseCrashChance)
+	base := max(withBug, withoutBug)
+	total := 0.0
+	for i := range m.prob {
+		if hasBug[i] {
+			m.prob[i] *= math.Exp(withBug - base)
+		} else {
+			m.prob[i] *= math.Exp(withoutBug - base)
+		}
+		total += m.prob[i]
+	}
+	for i := range m.prob {
+		m.prob[i] /= total
+	}
+}
+
+// uncertain says if we are not sure whether a tested commit has the bug or not.
+func (m *culpritModel) uncertain(hasBug []bool) bool {
+	if hasBug == nil {
+		return false
+	}
+	p := 0.0
+	for i, bug := range hasBug {
+		if bug {
+			p += m.prob[i]
+		}
+	}
+	return p > 1-karyDecided && p < karyDecided
+}
+
+func (m *culpritModel) best() (int, float64) {
+	best := 0
+	for i, p := range m.prob {
+		if p > m.prob[best] {
+			best = i
+		}
+	}
+	return best, m.prob[best]
+}
+
+// pick selects up to k commits to test next. The commits split the probability mass
+// into k+1 roughly equal parts assuming the range is linear (allowed[i] says
+// whether hashes[i] may be tested).
+func (m *culpritModel) pick(k int, allowed func(int) bool) []int {
+	cumulative := make([]float64, len(m.prob))
+	sum := 0.0
+	for i, p := range m.prob {
+		sum += p
+		cumulative[i] = sum
+	}
+	var ret []int
+	taken := make(map[int]bool)
+	for j := 1; j <= k; j++ {
+		q := float64(j) / float64(k+1)
+		best := -1
+		for i := range m.prob {
+			if taken[i] || !allowed(i) {
+				continue
+			}
+			if best == -1 || math.Abs(cumulative[i]-q) < math.Abs(cumulative[best]-q) {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+		taken[best] = true
+		ret = append(ret, best)
+	}
+	sort.Ints(ret)
+	return ret
+}
+
+// likely returns the most probable commits that together have at least karyConfidence probability,
+// but no less than minCount commits.
+func (m *culpritModel) likely(minCount int) []int {
+	idx := make([]int, len(m.prob))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return m.prob[idx[i]] > m.prob[idx[j]]
+	})
+	sum := 0.0
+	for i, id := range idx {
+		sum += m.prob[id]
+		if sum >= karyConfidence && i+1 >= minCount {
+			return idx[:i+1]
+		}
+	}
+	return idx
+}
+
+func (env *env) karyBisect(bad, good *vcs.Commit) ([]*vcs.Commit, error) {
+	hashes, err := env.bisecter.BisectRange(bad.Hash, []string{good.Hash})
+	if err != nil {
+		return nil, err
+	}
+	env.logf("k-ary bisection of %v commits with %v workers", len(hashes), len(env.workers))
+	model := newCulpritModel(hashes, env.reproChance)
+	// Cache of the candidates reachable from the tested commits.
+	reachable := make(map[string][]bool)
+	hasBug := func(hash string) ([]bool, error) {
+		if ret := reachable[hash]; ret != nil {
+			return ret, nil
+		}
+		reach, err := env.bisecter.BisectRange(hash, []string{good.Hash})
+		if err != nil {
+			return nil, err
+		}
+		ret := make([]bool, len(hashes))
+		for _, h := range reach {
+			if i, ok := model.index[h]; ok {
+				ret[i] = true
+			}
+		}
+		reachable[hash] = ret
+		return ret, nil
+	}
+	tests := make(map[string]int)
+	apply := func(res *testResult) error {
+		tests[res.com.Hash]++
+		if res.bad+res.good == 0 {
+			return nil
+		}
+		bug, err := hasBug(res.com.Hash)
+		if err != nil {
+			return err
+		}
+		model.update(bug, res.bad, res.good)
+		return nil
+	}
+	for _, res := range env.results {
+		if _, ok := model.index[res.com.Hash]; ok {
+			if err := apply(res); err != nil {
+				return nil, err
+			}
+		}
+	}
+	skipped := make(map[string]bool)
+	// Normally we need log2(N)/log2(K+1) steps, but leave some room for re-testing.
+	maxSteps := 2*bits.Len(uint(len(hashes))) + 2
+	for step := 0; ; step++ {
+		best, prob := model.best()
+		env.logf("k-ary step %v: most likely culprit %v with probability %.3f",
+			step, hashes[best], prob)
+		if prob >= karyConfidence && tests[hashes[best]] != 0 && !skipped[hashes[best]] {
+			break
+		}
+		next := model.pick(len(env.workers), func(i int) bool {
+			hash := hashes[i]
+			if tests[hash] == 0 {
+				return true
+			}
+			// Re-test only commits with contradictory results.
+			return !skipped[hash] && tests[hash] < karyMaxTests && model.uncertain(reachable[hash])
+		})
+		if len(next) == 0 || step >= maxSteps {
+			env.logf("k-ary bisection is out of commits to test")
+			break
+		}
+		results, err := env.testParallel(next, hashes)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range results {
+			if res.bad+res.good == 0 {
+				skipped[res.com.Hash] = true
+			}
+			env.postTestResult(res)
+			model.reproChance = clampReproChance(env.reproChance)
+			if err := apply(res); err != nil {
+				return nil, err
+			}
+			if prev := env.results[res.com.Hash]; prev == nil || prev.rep == nil || res.rep != nil {
+				env.results[res.com.Hash] = res
+			}
+		}
+	}
+	likely := model.likely(1)
+	if best, _ := model.best(); skipped[hashes[best]] || tests[hashes[best]] == 0 {
+		// We don't have a result for the most likely culprit itself,
+		// so we can't be sure and report the range (similar to git bisect with skipped commits).
+		likely = model.likely(2)
+	}
+	var commits []*vcs.Commit
+	for _, i := range likely {
+		com, err := env.repo.Commit(hashes[i])
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, com)
+	}
+	_, env.confidence = model.best()
+	return commits, nil
+}
+
+// testParallel tests the commits concurrently on different workers.
+// The log messages of each test are prefixed with the worker and the commit.
+func (env *env) testParallel(next []int, hashes []string) ([]*testResult, error) {
+	results := make([]*testResult, len(next))
+	errs := make([]error, len(next))
+	var wg sync.WaitGroup
+	traceMu := new(sync.Mutex)
+	for i, idx := range next {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := env.workers[i]
+			w.trace = &prefixTracer{
+				DebugTracer: env.cfg.Trace,
+				mu:          traceMu,
+				prefix:      fmt.Sprintf("[worker %v, %.12s] ", i, hashes[idx]),
+			}
+			defer func() { w.trace = nil }()
+			if _, err := w.repo.SwitchCommit(hashes[idx]); err != nil {
+				errs[i] = err
+				return
+			}
+			results[i], errs[i] = env.testOn(w)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// prefixTracer serializes the messages of concurrent tests and prepends a prefix to each of them.
+type prefixTracer struct {
+	debugtracer.DebugTracer
+	mu     *sync.Mutex
+	prefix string
+}
+
+func (pt *prefixTracer) Log(msg string, args ...interface{}) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.DebugTracer.Log("%s"+msg, append([]interface{}{pt.prefix}, args...)...)
+}
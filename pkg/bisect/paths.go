@@ -0,0 +1,94 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package bisect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/syzkaller/pkg/vcs"
+)
+
+// pathBisect is a lightweight fix bisection that only considers the commits touching cfg.Paths.
+// The candidate commits are binary searched in the topological order, and the parent
+// of the found commit is tested as well to make sure that the fix is not among
+// the commits that do not touch the paths.
+// Note: as in Bisect, for fix bisection "bad" means that the bug is already fixed.
+func (env *env) pathBisect(bad, good *vcs.Commit) ([]*vcs.Commit, error) {
+	paths := env.cfg.Paths
+	hashes, err := env.bisecter.BisectRange(bad.Hash, []string{good.Hash}, paths...)
+	if err != nil {
+		return nil, err
+	}
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("no commits touch %v in the range", strings.Join(paths, " "))
+	}
+	env.logf("bisecting %v commits touching %v", len(hashes), strings.Join(paths, " "))
+	// lo is the index of the last known good commit (-1 stands for the good commit itself),
+	// hi is the index of the first known bad commit (len(hashes) stands for the bad commit).
+	lo, hi := -1, len(hashes)
+	skipped := make(map[int]bool)
+	for {
+		var next []int
+		for i := lo + 1; i < hi; i++ {
+			if !skipped[i] {
+				next = append(next, i)
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		i := next[len(next)/2]
+		verdict, err := env.testCommit(hashes[i])
+		if err != nil {
+			return nil, err
+		}
+		switch verdict {
+		case vcs.BisectGood:
+			lo = i
+		case vcs.BisectBad:
+			hi = i
+		default:
+			skipped[i] = true
+		}
+	}
+	if hi == len(hashes) {
+		return nil, fmt.Errorf("the bug is not fixed by any of the commits touching %v",
+			strings.Join(paths, " "))
+	}
+	var commits []*vcs.Commit
+	for i := lo + 1; i <= hi; i++ {
+		com, err := env.repo.Commit(hashes[i])
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, com)
+	}
+	if len(commits) > 1 {
+		return commits, nil
+	}
+	prevGood := good.Hash
+	if lo >= 0 {
+		prevGood = hashes[lo]
+	}
+	if parents := commits[0].Parents; len(parents) != 0 && parents[0] != prevGood {
+		env.logf("testing the parent of %v", commits[0].Hash)
+		verdict, err := env.testCommit(parents[0])
+		if err != nil {
+			return nil, err
+		}
+		if verdict == vcs.BisectBad {
+			return nil, fmt.Errorf("the bug is fixed before %v by a commit that does not touch %v",
+				commits[0].Hash, strings.Join(paths, " "))
+		}
+	}
+	return commits, nil
+}
+
+func (env *env) testCommit(hash string) (vcs.BisectResult, error) {
+	if _, err := env.repo.SwitchCommit(hash); err != nil {
+		return 0, err
+	}
+	return env.testPredicate()
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package coordinator
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/google/syzkaller/pkg/flatrpc"
+)
+
+// Client is a manager-side connection to the coordinator.
+// Client is not thread-safe.
+type Client struct {
+	addr    string
+	manager string
+	conn    *flatrpc.Conn
+}
+
+func NewClient(addr, manager string) *Client {
+	return &Client{
+		addr:    addr,
+		manager: manager,
+	}
+}
+
+// Connected says whether the next Sync will use an already established connection.
+// If it's not the case, the coordinator has lost track of what it has already sent/received
+// to/from the manager, so the manager needs to send all of its max signal and crashes again.
+func (c *Client) Connected() bool {
+	return c.conn != nil
+}
+
+// Sync sends the local updates and returns the updates from other managers.
+// If the reply does not fit into one message, Sync fetches the rest as well.
+// On errors the connection is closed, and will be re-established on the next call.
+func (c *Client) Sync(req *SyncRequest) (*SyncReply, error) {
+	if c.conn == nil {
+		conn, err := net.DialTimeout("tcp", c.addr, time.Minute)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to the coordinator: %w", err)
+		}
+		c.conn = flatrpc.NewConn(conn)
+	}
+	req.Manager = c.manager
+	res := &SyncReply{}
+	for {
+		reply, err := c.roundtrip(req)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		res.Signal = append(res.Signal, reply.Signal...)
+		res.Inputs = append(res.Inputs, reply.Inputs...)
+		res.Crashes = append(res.Crashes, reply.Crashes...)
+		res.Released = append(res.Released, reply.Released...)
+		if reply.More == 0 {
+			return res, nil
+		}
+		req = &SyncRequest{Manager: c.manager}
+	}
+}
+
+func (c *Client) roundtrip(req *SyncRequest) (*SyncReply, error) {
+	if err := flatrpc.Send(c.conn, req); err != nil {
+		return nil, err
+	}
+	return flatrpc.Recv[*SyncReplyRaw](c.conn)
+}
+
+func (c *Client) Close() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
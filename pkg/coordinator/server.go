@@ -0,0 +1,305 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package coordinator allows several syz-manager processes that fuzz the same kernel
+// to behave like one large fuzzer. The managers periodically sync with the coordinator and
+// exchange new max signal, new corpus inputs and titles of crashes they are reproducing.
+// Unlike syz-hub, the exchange happens on the granularity of individual inputs and signal,
+// so that an input triaged by one manager is not triaged again by all others.
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/google/syzkaller/pkg/db"
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/osutil"
+)
+
+type Input = InputRawT
+type SyncRequest = SyncRequestRawT
+type SyncReply = SyncReplyRawT
+
+const (
+	// Limits on the size of a single reply, the rest is sent in subsequent replies.
+	maxReplyInputs = 1000
+	maxReplySignal = 1 << 20
+	// Crash claims expire unless the owner renews them by re-sending the titles.
+	// This way the crashes claimed by managers that died in the middle of reproduction
+	// are eventually reproduced by other managers.
+	ClaimLease = 6 * time.Hour
+)
+
+// Server holds the shared state of all managers.
+// Max signal is kept only in memory (managers re-send all of their signal on reconnect),
+// while corpus inputs and crash ownership are persisted in the working dir.
+type Server struct {
+	Addr *net.TCPAddr
+	serv *flatrpc.Serv
+	now  func() time.Time
+
+	mu        sync.Mutex
+	signal    []uint64
+	signalSet map[uint64]struct{}
+	inputs    []*Input
+	corpusSeq uint64
+	// The log of crash titles whose ownership has changed (claimed or released).
+	crashes   []string
+	owners    map[string]*claim
+	corpusDB  *db.DB
+	crashesDB *db.DB
+}
+
+type claim struct {
+	manager string
+	expires time.Time
+}
+
+// Listen loads the state from dir and starts listening on addr.
+func Listen(dir, addr string) (*Server, error) {
+	if err := osutil.MkdirAll(dir); err != nil {
+		return nil, err
+	}
+	serv := &Server{
+		now:       time.Now,
+		signalSet: make(map[uint64]struct{}),
+		owners:    make(map[string]*claim),
+	}
+	var err error
+	serv.corpusDB, err = db.Open(filepath.Join(dir, "corpus.db"), true)
+	if err != nil {
+		log.Errorf("failed to open corpus database: %v", err)
+	}
+	if serv.corpusDB == nil {
+		return nil, fmt.Errorf("failed to open corpus database: %w", err)
+	}
+	serv.crashesDB, err = db.Open(filepath.Join(dir, "crashes.db"), true)
+	if err != nil {
+		log.Errorf("failed to open crashes database: %v", err)
+	}
+	if serv.crashesDB == nil {
+		return nil, fmt.Errorf("failed to open crashes database: %w", err)
+	}
+	serv.load()
+	serv.serv, err = flatrpc.Listen(addr)
+	if err != nil {
+		return nil, err
+	}
+	serv.Addr = serv.serv.Addr
+	return serv, nil
+}
+
+func (serv *Server) load() {
+	// Restore the original order of inputs, so that managers receive older inputs first.
+	inputs := make([]*Input, len(serv.corpusDB.Records))
+	for key, rec := range serv.corpusDB.Records {
+		if rec.Seq >= uint64(len(inputs)) || inputs[rec.Seq] != nil {
+			log.Errorf("corpus record %v has bad seq %v", key, rec.Seq)
+			continue
+		}
+		inputs[rec.Seq] = GetRootAsInputRaw(rec.Val, 0).UnPack()
+		serv.corpusSeq = max(serv.corpusSeq, rec.Seq+1)
+	}
+	for _, inp := range inputs {
+		if inp != nil {
+			serv.inputs = append(serv.inputs, inp)
+			serv.addSignal(inp.Signal)
+		}
+	}
+	for title, rec := range serv.crashesDB.Records {
+		serv.crashes = append(serv.crashes, title)
+		// Seq keeps the claim expiration time.
+		serv.owners[title] = &claim{
+			manager: string(rec.Val),
+			expires: time.Unix(int64(rec.Seq), 0),
+		}
+	}
+	log.Logf(0, "loaded %v inputs, %v crashes", len(serv.inputs), len(serv.crashes))
+}
+
+// Serve handles manager connections until ctx is cancelled.
+func (serv *Server) Serve(ctx context.Context) error {
+	return serv.serv.Serve(ctx, func(ctx context.Context, conn *flatrpc.Conn) error {
+		if err := serv.handleConn(conn); err != nil {
+			log.Logf(0, "%v", err)
+		}
+		// Errors of individual connections must not stop the whole server.
+		return nil
+	})
+}
+
+// session holds positions of a single manager connection in the shared state.
+// A new connection starts from scratch, so a restarted manager receives everything.
+type session struct {
+	manager   string
+	signalPos int
+	inputPos  int
+	crashPos  int
+}
+
+func (serv *Server) handleConn(conn *flatrpc.Conn) error {
+	sess := new(session)
+	for {
+		req, err := flatrpc.Recv[*SyncRequestRaw](conn)
+		if err != nil {
+			return err
+		}
+		if sess.manager == "" {
+			if req.Manager == "" {
+				return fmt.Errorf("connection without manager name")
+			}
+			sess.manager = req.Manager
+			log.Logf(0, "manager %v connected", sess.manager)
+		}
+		if err := flatrpc.Send(conn, serv.sync(sess, req)); err != nil {
+			return fmt.Errorf("manager %v: %w", sess.manager, err)
+		}
+	}
+}
+
+func (serv *Server) sync(sess *session, req *SyncRequest) *SyncReply {
+	serv.mu.Lock()
+	defer serv.mu.Unlock()
+	// Don't send the manager's own signal back to it: everything is appended,
+	// so if the manager was up to date, we just skip the new items.
+	// Own inputs and crashes are filtered out by the manager name.
+	upToDate := sess.signalPos == len(serv.signal)
+	serv.addSignal(req.Signal)
+	if upToDate {
+		sess.signalPos = len(serv.signal)
+	}
+	serv.addInputs(sess, req.Inputs)
+	serv.expireCrashes()
+	serv.addCrashes(sess, req.Crashes)
+	serv.releaseCrashes(sess, req.Released)
+
+	reply := &SyncReply{}
+	end := min(len(serv.signal), sess.signalPos+maxReplySignal)
+	reply.Signal = serv.signal[sess.signalPos:end]
+	sess.signalPos = end
+	for ; sess.inputPos < len(serv.inputs) && len(reply.Inputs) < maxReplyInputs; sess.inputPos++ {
+		if inp := serv.inputs[sess.inputPos]; inp.Manager != sess.manager {
+			reply.Inputs = append(reply.Inputs, inp)
+		}
+	}
+	reply.More = int32(len(serv.inputs) - sess.inputPos + len(serv.signal) - sess.signalPos)
+	// The log may mention the same title several times, only the current state matters.
+	seen := make(map[string]bool)
+	for ; sess.crashPos < len(serv.crashes); sess.crashPos++ {
+		title := serv.crashes[sess.crashPos]
+		if seen[title] {
+			continue
+		}
+		seen[title] = true
+		if owner := serv.owners[title]; owner == nil {
+			reply.Released = append(reply.Released, title)
+		} else if owner.manager != sess.manager {
+			reply.Crashes = append(reply.Crashes, title)
+		}
+	}
+	return reply
+}
+
+func (serv *Server) addSignal(signal []uint64) {
+	for _, elem := range signal {
+		if _, ok := serv.signalSet[elem]; ok {
+			continue
+		}
+		serv.signalSet[elem] = struct{}{}
+		serv.signal = append(serv.signal, elem)
+	}
+}
+
+func (serv *Server) addInputs(sess *session, inputs []*Input) {
+	if len(inputs) == 0 {
+		return
+	}
+	builder := flatbuffers.NewBuilder(0)
+	for _, inp := range inputs {
+		inp.Manager = sess.manager
+		key := hash.String([]byte(inp.Prog))
+		if _, ok := serv.corpusDB.Records[key]; ok {
+			continue
+		}
+		builder.Reset()
+		builder.Finish(inp.Pack(builder))
+		serv.corpusDB.Save(key, builder.FinishedBytes(), serv.corpusSeq)
+		serv.corpusSeq++
+		serv.inputs = append(serv.inputs, inp)
+	}
+	if err := serv.corpusDB.Flush(); err != nil {
+		log.Errorf("failed to save corpus database: %v", err)
+	}
+}
+
+func (serv *Server) addCrashes(sess *session, crashes []string) {
+	if len(crashes) == 0 {
+		return
+	}
+	expires := serv.now().Add(ClaimLease)
+	for _, title := range crashes {
+		owner := serv.owners[title]
+		if owner != nil && owner.manager != sess.manager {
+			continue
+		}
+		serv.owners[title] = &claim{manager: sess.manager, expires: expires}
+		serv.crashesDB.Save(title, []byte(sess.manager), uint64(expires.Unix()))
+		if owner == nil {
+			serv.crashes = append(serv.crashes, title)
+		}
+	}
+	serv.flushCrashes()
+}
+
+func (serv *Server) releaseCrashes(sess *session, crashes []string) {
+	if len(crashes) == 0 {
+		return
+	}
+	for _, title := range crashes {
+		if owner := serv.owners[title]; owner != nil && owner.manager == sess.manager {
+			log.Logf(1, "manager %v released crash %q", sess.manager, title)
+			serv.releaseCrash(title)
+		}
+	}
+	serv.flushCrashes()
+}
+
+func (serv *Server) expireCrashes() {
+	now := serv.now()
+	var expired []string
+	for title, owner := range serv.owners {
+		if now.After(owner.expires) {
+			expired = append(expired, title)
+		}
+	}
+	if len(expired) == 0 {
+		return
+	}
+	sort.Strings(expired)
+	for _, title := range expired {
+		log.Logf(0, "claim of crash %q by %v has expired", title, serv.owners[title].manager)
+		serv.releaseCrash(title)
+	}
+	serv.flushCrashes()
+}
+
+func (serv *Server) releaseCrash(title string) {
+	delete(serv.owners, title)
+	serv.crashesDB.Delete(title)
+	serv.crashes = append(serv.crashes, title)
+}
+
+func (serv *Server) flushCrashes() {
+	if err := serv.crashesDB.Flush(); err != nil {
+		log.Errorf("failed to save crashes database: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package coordinator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	dir := t.TempDir()
+	serv, stop := startServer(t, dir)
+	c0 := NewClient(serv.Addr.String(), "mgr0")
+	c1 := NewClient(serv.Addr.String(), "mgr1")
+	defer c0.Close()
+	defer c1.Close()
+
+	reply, err := c0.Sync(&SyncRequest{
+		Signal:  []uint64{1, 2, 3},
+		Inputs:  []*Input{{Prog: "prog0", Call: 1, Signal: []uint64{1, 2}}},
+		Crashes: []string{"crash0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &SyncReply{}, reply)
+	assert.True(t, c0.Connected())
+
+	reply, err = c1.Sync(&SyncRequest{
+		Signal:  []uint64{3, 4},
+		Inputs:  []*Input{{Prog: "prog1", Call: 0, Signal: []uint64{4}}},
+		Crashes: []string{"crash0", "crash1"},
+	})
+	require.NoError(t, err)
+	// The manager was not up to date, so it receives some of its own signal back.
+	assert.Equal(t, []uint64{1, 2, 3, 4}, reply.Signal)
+	assert.Equal(t, []*Input{{Manager: "mgr0", Prog: "prog0", Call: 1, Signal: []uint64{1, 2}, Cover: []uint64{}}},
+		reply.Inputs)
+	assert.Equal(t, []string{"crash0"}, reply.Crashes)
+
+	reply, err = c0.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4}, reply.Signal)
+	assert.Len(t, reply.Inputs, 1)
+	assert.Equal(t, "prog1", reply.Inputs[0].Prog)
+	assert.Equal(t, []string{"crash1"}, reply.Crashes)
+
+	// Nothing new.
+	reply, err = c0.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, reply.Signal)
+	assert.Empty(t, reply.Inputs)
+	assert.Empty(t, reply.Crashes)
+
+	// After a restart the corpus and crashes must be preserved.
+	stop()
+	serv, stop = startServer(t, dir)
+	defer stop()
+	c2 := NewClient(serv.Addr.String(), "mgr2")
+	defer c2.Close()
+	reply, err = c2.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint64{1, 2, 4}, reply.Signal)
+	require.Len(t, reply.Inputs, 2)
+	assert.Equal(t, "prog0", reply.Inputs[0].Prog)
+	assert.Equal(t, "prog1", reply.Inputs[1].Prog)
+	assert.ElementsMatch(t, []string{"crash0", "crash1"}, reply.Crashes)
+}
+
+func TestCrashClaims(t *testing.T) {
+	dir := t.TempDir()
+	serv, stop := startServer(t, dir)
+	c0 := NewClient(serv.Addr.String(), "mgr0")
+	c1 := NewClient(serv.Addr.String(), "mgr1")
+	defer c0.Close()
+	defer c1.Close()
+
+	_, err := c0.Sync(&SyncRequest{Crashes: []string{"crash0", "crash1"}})
+	require.NoError(t, err)
+	reply, err := c1.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"crash0", "crash1"}, reply.Crashes)
+
+	// Failed reproduction releases the claim, so that others can reproduce the crash.
+	_, err = c0.Sync(&SyncRequest{Released: []string{"crash0"}})
+	require.NoError(t, err)
+	reply, err = c1.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, reply.Crashes)
+	assert.Equal(t, []string{"crash0"}, reply.Released)
+	_, err = c1.Sync(&SyncRequest{Crashes: []string{"crash0"}})
+	require.NoError(t, err)
+	reply, err = c0.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"crash0"}, reply.Crashes)
+	assert.Empty(t, reply.Released)
+
+	// Only the owner can release the claim.
+	_, err = c0.Sync(&SyncRequest{Released: []string{"crash0"}})
+	require.NoError(t, err)
+	reply, err = c1.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, reply.Released)
+
+	// mgr1 keeps renewing its claim, while mgr0 is gone.
+	setNow(serv, time.Now().Add(ClaimLease/2))
+	_, err = c1.Sync(&SyncRequest{Crashes: []string{"crash0"}})
+	require.NoError(t, err)
+	setNow(serv, time.Now().Add(ClaimLease*5/4))
+	reply, err = c1.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"crash1"}, reply.Released)
+
+	// Claims and their expiration are preserved across restarts.
+	stop()
+	serv, stop = startServer(t, dir)
+	defer stop()
+	c2 := NewClient(serv.Addr.String(), "mgr2")
+	defer c2.Close()
+	reply, err = c2.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"crash0"}, reply.Crashes)
+	setNow(serv, time.Now().Add(ClaimLease*2))
+	reply, err = c2.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"crash0"}, reply.Released)
+}
+
+func setNow(serv *Server, now time.Time) {
+	serv.mu.Lock()
+	defer serv.mu.Unlock()
+	serv.now = func() time.Time { return now }
+}
+
+func TestSyncMore(t *testing.T) {
+	serv, stop := startServer(t, t.TempDir())
+	defer stop()
+	c0 := NewClient(serv.Addr.String(), "mgr0")
+	defer c0.Close()
+	var inputs []*Input
+	for i := 0; i < maxReplyInputs*2+10; i++ {
+		inputs = append(inputs, &Input{Prog: string(rune('a'+i%26)) + string(rune(i))})
+	}
+	_, err := c0.Sync(&SyncRequest{Inputs: inputs})
+	require.NoError(t, err)
+	c1 := NewClient(serv.Addr.String(), "mgr1")
+	defer c1.Close()
+	reply, err := c1.Sync(&SyncRequest{})
+	require.NoError(t, err)
+	assert.Len(t, reply.Inputs, len(inputs))
+}
+
+func startServer(t *testing.T, dir string) (*Server, func()) {
+	serv, err := Listen(dir, ":0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- serv.Serve(ctx)
+	}()
+	return serv, func() {
+		cancel()
+		assert.NoError(t, <-done)
+	}
+}
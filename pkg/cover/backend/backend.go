@@ -18,6 +18,11 @@ type Impl struct {
 	Symbolize       func(pcs map[*vminfo.KernelModule][]uint64) ([]*Frame, error)
 	CallbackPoints  []uint64
 	PreciseCoverage bool
+	// Branches fills in Symbol.Branches for the symbols, nil if the target is not supported.
+	Branches func(syms []*Symbol) error
+	// Callers fills in Symbol.Callers for all symbols of the main kernel binary,
+	// nil if the target is not supported.
+	Callers func() error
 }
 
 type CompileUnit struct {
@@ -33,6 +38,11 @@ type Symbol struct {
 	Start      uint64
 	End        uint64
 	Symbolized bool
+	// Conditional branches of the symbol, filled in by Impl.Branches.
+	Branches     []*Branch
+	BranchesRead bool
+	// Symbols that directly call the symbol, filled in by Impl.Callers.
+	Callers []*Symbol
 }
 
 // ObjectUnit represents either CompileUnit or Symbol.
@@ -0,0 +1,303 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/vminfo"
+	"github.com/google/syzkaller/sys/targets"
+)
+
+// Branch is a conditional branch instruction in the kernel binary.
+// Taken and NotTaken are coverage callback PCs at the beginning of the two branch directions.
+// Since -fsanitize-coverage=trace-pc inserts callbacks at the beginning of basic blocks,
+// covered callbacks tell us which directions of the branch were taken.
+type Branch struct {
+	PC       uint64
+	Taken    uint64
+	NotTaken uint64
+}
+
+// branchArch describes how to classify disassembled instructions of the arch.
+type branchArch struct {
+	// Instruction prefixes that are printed before the mnemonic.
+	prefixes []string
+	isCond   func(mnemonic string) bool
+	isJump   func(mnemonic string) bool
+	isReturn func(mnemonic string) bool
+}
+
+var x86BranchArch = &branchArch{
+	prefixes: []string{"notrack", "bnd", "rep", "repz", "ds", "cs"},
+	isCond: func(mnemonic string) bool {
+		return strings.HasPrefix(mnemonic, "j") && !strings.HasPrefix(mnemonic, "jmp")
+	},
+	isJump: func(mnemonic string) bool {
+		return strings.HasPrefix(mnemonic, "jmp")
+	},
+	isReturn: func(mnemonic string) bool {
+		return strings.HasPrefix(mnemonic, "ret") || strings.HasPrefix(mnemonic, "iret") || mnemonic == "ud2"
+	},
+}
+
+var branchArches = map[string]*branchArch{
+	targets.AMD64: x86BranchArch,
+	targets.I386:  x86BranchArch,
+	targets.ARM64: {
+		isCond: func(mnemonic string) bool {
+			switch mnemonic {
+			case "cbz", "cbnz", "tbz", "tbnz":
+				return true
+			}
+			return strings.HasPrefix(mnemonic, "b.")
+		},
+		isJump: func(mnemonic string) bool {
+			return mnemonic == "b"
+		},
+		isReturn: func(mnemonic string) bool {
+			switch mnemonic {
+			case "ret", "br", "eret", "brk":
+				return true
+			}
+			return false
+		},
+	},
+}
+
+type branchInsnKind int
+
+const (
+	insnFunc branchInsnKind = iota // function start
+	insnCover
+	insnCond
+	insnJump
+	insnReturn // also indirect jumps and jumps with unknown targets
+)
+
+// branchInsn is a disassembled instruction that affects the branch analysis.
+// All other instructions are not stored to save memory.
+type branchInsn struct {
+	pc     uint64
+	kind   branchInsnKind
+	target uint64 // for insnCond and insnJump
+	next   uint64 // for insnCond, pc of the next instruction
+}
+
+// Max number of unconditional jumps we follow to find a coverage callback.
+const maxBranchJumps = 2
+
+// readBranches fills in Branches for the symbols. Only the main kernel binary is supported,
+// since calls to coverage callbacks in modules are not resolved before they are loaded.
+func readBranches(target *targets.Target, syms []*Symbol) error {
+	arch := branchArches[target.Arch]
+	mods := make(map[*vminfo.KernelModule][]*Symbol)
+	for _, s := range syms {
+		if s.BranchesRead {
+			continue
+		}
+		s.BranchesRead = true
+		if s.Module.Name == "" {
+			mods[s.Module] = append(mods[s.Module], s)
+		}
+	}
+	for mod, modSyms := range mods {
+		sort.Slice(modSyms, func(i, j int) bool {
+			return modSyms[i].Start < modSyms[j].Start
+		})
+		insns, err := objdumpBranches(target, arch, mod, modSyms[0].Start, modSyms[len(modSyms)-1].End)
+		if err != nil {
+			return err
+		}
+		assignBranches(modSyms, findBranches(insns))
+	}
+	return nil
+}
+
+func objdumpBranches(target *targets.Target, arch *branchArch, mod *vminfo.KernelModule,
+	start, end uint64) ([]branchInsn, error) {
+	cmd := osutil.Command(target.Objdump, "-d", "--no-show-raw-insn",
+		fmt.Sprintf("--start-address=0x%x", start), fmt.Sprintf("--stop-address=0x%x", end), mod.Path)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	defer stdout.Close()
+	// Collect stderr concurrently, otherwise objdump blocks once the stderr pipe is full.
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run objdump on %v: %w", mod.Path, err)
+	}
+	insns, err := parseBranchInsns(target, arch, stdout)
+	if err != nil {
+		// objdump may be blocked on writing the rest of stdout.
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, fmt.Errorf("failed to run objdump on %v: %w\n%s", mod.Path, err, stderr.Bytes())
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to run objdump on %v: %w\n%s", mod.Path, err, stderr.Bytes())
+	}
+	return insns, nil
+}
+
+func parseBranchInsns(target *targets.Target, arch *branchArch, r io.Reader) ([]branchInsn, error) {
+	callInsns, traceFuncs := archCallInsn(target)
+	var insns []branchInsn
+	pendingNext := -1
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		ln := s.Bytes()
+		if bytes.HasSuffix(ln, []byte(">:")) {
+			// Function header, e.g. "ffffffff81000000 <foo>:".
+			if sp := bytes.IndexByte(ln, ' '); sp != -1 {
+				if pc, err := strconv.ParseUint(string(ln[:sp]), 16, 64); err == nil {
+					insns = append(insns, branchInsn{pc: pc, kind: insnFunc})
+				}
+			}
+			continue
+		}
+		trimmed := bytes.TrimLeft(ln, " ")
+		colon := bytes.IndexByte(trimmed, ':')
+		if colon == -1 {
+			continue
+		}
+		pc, err := strconv.ParseUint(string(trimmed[:colon]), 16, 64)
+		if err != nil {
+			continue
+		}
+		fields := strings.Fields(string(trimmed[colon+1:]))
+		if len(fields) == 0 {
+			continue
+		}
+		if pendingNext != -1 {
+			insns[pendingNext].next = pc
+			pendingNext = -1
+		}
+		if parseLine(callInsns, traceFuncs, ln) != 0 {
+			insns = append(insns, branchInsn{pc: pc, kind: insnCover})
+			continue
+		}
+		for len(fields) > 1 && slices.Contains(arch.prefixes, fields[0]) {
+			fields = fields[1:]
+		}
+		insn := branchInsn{pc: pc}
+		switch mnemonic := fields[0]; {
+		case arch.isCond(mnemonic):
+			insn.kind = insnCond
+		case arch.isJump(mnemonic):
+			insn.kind = insnJump
+		case arch.isReturn(mnemonic):
+			insn.kind = insnReturn
+		default:
+			continue
+		}
+		if insn.kind != insnReturn {
+			insn.target = parseBranchTarget(fields[1:])
+			if insn.target == 0 {
+				insn.kind = insnReturn
+			}
+		}
+		if insn.kind == insnCond {
+			pendingNext = len(insns)
+		}
+		insns = append(insns, insn)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(insns, func(i, j int) bool {
+		return insns[i].pc < insns[j].pc
+	})
+	return insns, nil
+}
+
+// parseBranchTarget extracts the target address from the instruction operands, e.g.
+// "ffffffff81000020 <foo+0x20>" or "w0, #3, ffff800008010020 <foo+0x20>".
+func parseBranchTarget(operands []string) uint64 {
+	for i := len(operands) - 1; i >= 0; i-- {
+		op := operands[i]
+		if strings.HasPrefix(op, "<") {
+			continue
+		}
+		op = strings.TrimPrefix(strings.TrimSuffix(op, ","), "0x")
+		target, err := strconv.ParseUint(op, 16, 64)
+		if err != nil {
+			return 0
+		}
+		return target
+	}
+	return 0
+}
+
+func findBranches(insns []branchInsn) []*Branch {
+	var branches []*Branch
+	for _, insn := range insns {
+		if insn.kind != insnCond || insn.next == 0 {
+			continue
+		}
+		taken := firstCallback(insns, insn.target, maxBranchJumps)
+		notTaken := firstCallback(insns, insn.next, maxBranchJumps)
+		if taken == 0 || notTaken == 0 || taken == notTaken {
+			continue
+		}
+		branches = append(branches, &Branch{
+			PC:       insn.pc,
+			Taken:    taken,
+			NotTaken: notTaken,
+		})
+	}
+	return branches
+}
+
+// firstCallback returns the first coverage callback executed after a jump to pc,
+// or 0 if it's not known.
+func firstCallback(insns []branchInsn, pc uint64, jumps int) uint64 {
+	idx := sort.Search(len(insns), func(i int) bool {
+		return insns[i].pc >= pc
+	})
+	for i := idx; i < len(insns); i++ {
+		insn := insns[i]
+		switch insn.kind {
+		case insnFunc:
+			// Either a tail call or we've reached the end of the function.
+			// In both cases callbacks of another function don't tell anything about the branch.
+			return 0
+		case insnCover:
+			return insn.pc
+		case insnJump:
+			if jumps == 0 {
+				return 0
+			}
+			return firstCallback(insns, insn.target, jumps-1)
+		default:
+			return 0
+		}
+	}
+	return 0
+}
+
+// assignBranches assigns sorted branches to sorted symbols.
+func assignBranches(syms []*Symbol, branches []*Branch) {
+	idx := 0
+	for _, b := range branches {
+		for ; idx < len(syms) && b.PC >= syms[idx].End; idx++ {
+		}
+		if idx == len(syms) {
+			break
+		}
+		if b.PC >= syms[idx].Start {
+			syms[idx].Branches = append(syms[idx].Branches, b)
+		}
+	}
+}
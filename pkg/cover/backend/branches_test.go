@@ -0,0 +1,70 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package backend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindBranches(t *testing.T) {
+	const objdump = `
+ffffffff81000000 <foo>:
+ffffffff81000000:	push   %rbp
+ffffffff81000001:	call   ffffffff81100000 <__sanitizer_cov_trace_pc>
+ffffffff81000006:	test   %edi,%edi
+ffffffff81000008:	je     ffffffff81000014 <foo+0x14>
+ffffffff8100000a:	call   ffffffff81100000 <__sanitizer_cov_trace_pc>
+ffffffff8100000f:	cmp    $0x1,%esi
+ffffffff81000012:	jne    ffffffff81000020 <foo+0x20>
+ffffffff81000014:	call   ffffffff81100000 <__sanitizer_cov_trace_pc>
+ffffffff81000019:	jmp    ffffffff81000030 <foo+0x30>
+ffffffff8100001b:	nop
+ffffffff81000020:	jmp    ffffffff8100002b <foo+0x2b>
+ffffffff81000025:	js     ffffffff81000030 <foo+0x30>
+ffffffff8100002b:	call   ffffffff81100000 <__sanitizer_cov_trace_pc>
+ffffffff81000030:	pop    %rbp
+ffffffff81000031:	ret
+ffffffff81000032:	jb     ffffffff81000040 <bar>
+ffffffff81000034:	call   ffffffff81100000 <__sanitizer_cov_trace_pc>
+ffffffff81000039:	notrack jmp *%rax
+
+ffffffff81000040 <bar>:
+ffffffff81000040:	nop
+ffffffff81000041:	call   ffffffff81100000 <__sanitizer_cov_trace_pc>
+ffffffff81000046:	ret
+`
+	target := targets.List[targets.Linux][targets.AMD64]
+	insns, err := parseBranchInsns(target, branchArches[targets.AMD64], strings.NewReader(objdump))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Branch{
+		{
+			PC:       0xffffffff81000008,
+			Taken:    0xffffffff81000014,
+			NotTaken: 0xffffffff8100000a,
+		},
+		{
+			// The taken direction goes through an unconditional jump.
+			PC:       0xffffffff81000012,
+			Taken:    0xffffffff8100002b,
+			NotTaken: 0xffffffff81000014,
+		},
+		// js: the taken direction does not have a callback.
+		// jb: the taken direction is a tail call of another function.
+	}, findBranches(insns))
+}
+
+func TestAssignBranches(t *testing.T) {
+	syms := []*Symbol{
+		{Start: 0x10, End: 0x20},
+		{Start: 0x30, End: 0x40},
+	}
+	branches := []*Branch{{PC: 0x5}, {PC: 0x15}, {PC: 0x25}, {PC: 0x35}, {PC: 0x45}}
+	assignBranches(syms, branches)
+	assert.Equal(t, []*Branch{{PC: 0x15}}, syms[0].Branches)
+	assert.Equal(t, []*Branch{{PC: 0x35}}, syms[1].Branches)
+}
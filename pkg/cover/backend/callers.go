@@ -0,0 +1,56 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package backend
+
+import (
+	"sort"
+)
+
+// readCallers fills in Callers for the symbols of the main kernel binary using direct calls found in its text.
+// Indirect calls are not resolved, so the call graph is incomplete (e.g. it misses calls through file_operations).
+func readCallers(arch *Arch, textAddr uint64, data []byte, syms []*Symbol) {
+	starts := make(map[uint64]*Symbol)
+	for _, s := range syms {
+		if s.Module.Name == "" {
+			starts[s.Start] = s
+		}
+	}
+	type edge struct {
+		caller *Symbol
+		callee *Symbol
+	}
+	seen := make(map[edge]bool)
+	for i := 0; ; {
+		callTarget, pc := nextCallTarget(arch, textAddr, data, &i)
+		if callTarget == 0 {
+			break
+		}
+		callee := starts[callTarget]
+		if callee == nil {
+			continue
+		}
+		caller := findSymbol(syms, pc)
+		if caller == nil || caller == callee || seen[edge{caller, callee}] {
+			continue
+		}
+		seen[edge{caller, callee}] = true
+		callee.Callers = append(callee.Callers, caller)
+	}
+	for _, s := range starts {
+		sort.Slice(s.Callers, func(i, j int) bool {
+			return s.Callers[i].Start < s.Callers[j].Start
+		})
+	}
+}
+
+// findSymbol returns the symbol that contains pc, syms must be sorted by Start.
+func findSymbol(syms []*Symbol, pc uint64) *Symbol {
+	idx := sort.Search(len(syms), func(i int) bool {
+		return pc < syms[i].End
+	})
+	if idx == len(syms) || pc < syms[idx].Start {
+		return nil
+	}
+	return syms[idx]
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package backend
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/vminfo"
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCallers(t *testing.T) {
+	const textAddr = 0x1000
+	data := bytes.Repeat([]byte{0x90}, 0x40)
+	call := func(pc, target uint64) {
+		off := pc - textAddr
+		data[off] = 0xe8
+		binary.LittleEndian.PutUint32(data[off+1:], uint32(target-pc-5))
+	}
+	mod := &vminfo.KernelModule{Path: "vmlinux"}
+	foo := &Symbol{Module: mod, ObjectUnit: ObjectUnit{Name: "foo"}, Start: 0x1000, End: 0x1010}
+	bar := &Symbol{Module: mod, ObjectUnit: ObjectUnit{Name: "bar"}, Start: 0x1010, End: 0x1020}
+	baz := &Symbol{Module: mod, ObjectUnit: ObjectUnit{Name: "baz"}, Start: 0x1020, End: 0x1040}
+	call(0x1000, bar.Start)
+	call(0x1008, bar.Start)   // duplicate edge
+	call(0x1010, baz.Start)   // bar -> baz
+	call(0x1018, baz.Start+4) // not a function start
+	call(0x1020, foo.Start)   // baz -> foo
+	call(0x1028, baz.Start)   // recursion
+	call(0x1030, 0x2000)      // outside of text
+	readCallers(arches[targets.AMD64], textAddr, data, []*Symbol{foo, bar, baz})
+	assert.Equal(t, []*Symbol{baz}, foo.Callers)
+	assert.Equal(t, []*Symbol{foo}, bar.Callers)
+	assert.Equal(t, []*Symbol{bar}, baz.Callers)
+}
@@ -17,6 +17,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/google/syzkaller/pkg/log"
 	"github.com/google/syzkaller/pkg/mgrconfig"
@@ -166,6 +167,7 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 		coverPoints [2][]uint64
 		ranges      []pcRange
 		units       []*CompileUnit
+		textAddr    uint64
 		err         error
 	}
 	binC := make(chan binResult, len(modules))
@@ -192,17 +194,23 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 				binC <- binResult{err: err}
 				return
 			}
-			binC <- binResult{symbols: result.Symbols, coverPoints: result.CoverPoints, ranges: ranges, units: units}
+			binC <- binResult{symbols: result.Symbols, coverPoints: result.CoverPoints, ranges: ranges, units: units,
+				textAddr: info.textAddr}
 		}()
 		if isKcovBrokenInCompiler(params.getCompilerVersion(module.Path)) {
 			preciseCoverage = false
 		}
 	}
+	var mainModule *vminfo.KernelModule
+	var mainTextAddr uint64
 	for range modules {
 		result := <-binC
 		if err := result.err; err != nil {
 			return nil, err
 		}
+		if len(result.symbols) != 0 && result.symbols[0].Module.Name == "" {
+			mainModule, mainTextAddr = result.symbols[0].Module, result.textAddr
+		}
 		allSymbols = append(allSymbols, result.symbols...)
 		allCoverPoints[0] = append(allCoverPoints[0], result.coverPoints[0]...)
 		allCoverPoints[1] = append(allCoverPoints[1], result.coverPoints[1]...)
@@ -258,6 +266,25 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 		CallbackPoints:  allCoverPoints[0],
 		PreciseCoverage: preciseCoverage,
 	}
+	if branchArches[target.Arch] != nil {
+		impl.Branches = func(syms []*Symbol) error {
+			return readBranches(target, syms)
+		}
+	}
+	if arch := arches[target.Arch]; arch != nil && mainModule != nil {
+		var once sync.Once
+		var callersErr error
+		impl.Callers = func() error {
+			once.Do(func() {
+				var data []byte
+				data, callersErr = params.readTextData(mainModule)
+				if callersErr == nil {
+					readCallers(arch, mainTextAddr, data, allSymbols)
+				}
+			})
+			return callersErr
+		}
+	}
 	return impl, nil
 }
 
@@ -783,7 +810,7 @@ func parseLine(callInsns, traceFuncs [][]byte, ln []byte) uint64 {
 func archCallInsn(target *targets.Target) ([][]byte, [][]byte) {
 	callName := [][]byte{[]byte(" <__sanitizer_cov_trace_pc>")}
 	switch target.Arch {
-	case targets.I386:
+	case targets.AMD64, targets.I386:
 		// c1000102:       call   c10001f0 <__sanitizer_cov_trace_pc>
 		return [][]byte{[]byte("\tcall ")}, callName
 	case targets.ARM64:
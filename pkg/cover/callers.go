@@ -0,0 +1,160 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+)
+
+// coveredCaller is a covered function from which an uncovered function is reachable through direct calls.
+// It explains why the function is not covered: the programs reach the caller, but don't take the path
+// to the function. Extending descriptions of the listed syscalls is the most likely way to cover it.
+type coveredCaller struct {
+	name     string
+	depth    int      // number of calls between the caller and the uncovered function
+	progs    []int    // indices of some (the shortest) programs that cover the caller
+	syscalls []string // syscalls of the programs that cover the caller
+}
+
+const (
+	// Max number of calls between an uncovered function and its covered callers.
+	maxCallerDepth = 3
+	// Max number of uncovered callers we walk through for a single function.
+	maxCallerVisits = 1000
+	// Max number of example programs we remember for a covered caller.
+	maxCallerProgs = 5
+)
+
+// addCoveredCallers finds the nearest covered callers for the uncovered functions.
+// The call graph is read from the binary on demand since it requires scanning the whole kernel text.
+func (rg *ReportGenerator) addCoveredCallers(files fileMap, progs []Prog) error {
+	if rg.Callers == nil {
+		return fmt.Errorf("callers of uncovered functions are not supported for %v", rg.target.Arch)
+	}
+	if err := rg.Callers(); err != nil {
+		return err
+	}
+	pcToProgs := make(map[uint64][]int)
+	for i, prog := range progs {
+		for _, pc := range prog.PCs {
+			pcToProgs[pc] = append(pcToProgs[pc], i)
+		}
+	}
+	covered := func(s *backend.Symbol) bool {
+		for _, pc := range s.PCs {
+			if len(pcToProgs[pc]) != 0 {
+				return true
+			}
+		}
+		return false
+	}
+	// Hot callers are reported for lots of functions, so compute their info only once.
+	callerInfo := make(map[*backend.Symbol]*coveredCaller)
+	getCallerInfo := func(s *backend.Symbol) *coveredCaller {
+		if info := callerInfo[s]; info != nil {
+			return info
+		}
+		info := &coveredCaller{name: s.Name}
+		dedupProgs := make(map[int]bool)
+		for _, pc := range s.PCs {
+			for _, idx := range pcToProgs[pc] {
+				if !dedupProgs[idx] {
+					dedupProgs[idx] = true
+					info.progs = append(info.progs, idx)
+				}
+			}
+		}
+		dedupCalls := make(map[string]bool)
+		for _, idx := range info.progs {
+			for _, call := range progSyscalls(progs[idx].Data) {
+				if !dedupCalls[call] {
+					dedupCalls[call] = true
+					info.syscalls = append(info.syscalls, call)
+				}
+			}
+		}
+		sort.Strings(info.syscalls)
+		// Prefer the shortest programs as examples.
+		sort.Slice(info.progs, func(i, j int) bool {
+			a, b := info.progs[i], info.progs[j]
+			if len(progs[a].Data) != len(progs[b].Data) {
+				return len(progs[a].Data) < len(progs[b].Data)
+			}
+			return a < b
+		})
+		if len(info.progs) > maxCallerProgs {
+			info.progs = info.progs[:maxCallerProgs]
+		}
+		callerInfo[s] = info
+		return info
+	}
+	for _, f := range files {
+		for _, fun := range f.functions {
+			if fun.covered != 0 || fun.sym == nil {
+				continue
+			}
+			callers, depth := findCoveredCallers(fun.sym, covered)
+			for _, caller := range callers {
+				info := *getCallerInfo(caller)
+				info.depth = depth
+				fun.callers = append(fun.callers, info)
+			}
+		}
+	}
+	return nil
+}
+
+// findCoveredCallers walks the call graph up from the uncovered symbol and returns the covered callers
+// that are the closest to it, and the number of calls between them and the symbol.
+// Covered callers are not walked through, since they are already reached.
+func findCoveredCallers(sym *backend.Symbol, covered func(*backend.Symbol) bool) ([]*backend.Symbol, int) {
+	visited := map[*backend.Symbol]bool{sym: true}
+	level := []*backend.Symbol{sym}
+	for depth := 1; depth <= maxCallerDepth && len(level) != 0 && len(visited) < maxCallerVisits; depth++ {
+		var ret, next []*backend.Symbol
+		for _, s := range level {
+			for _, caller := range s.Callers {
+				if visited[caller] {
+					continue
+				}
+				visited[caller] = true
+				if covered(caller) {
+					ret = append(ret, caller)
+				} else {
+					next = append(next, caller)
+				}
+			}
+		}
+		if len(ret) != 0 {
+			sort.Slice(ret, func(i, j int) bool {
+				return ret[i].Name < ret[j].Name
+			})
+			return ret, depth
+		}
+		level = next
+	}
+	return nil, 0
+}
+
+// progSyscalls returns names of the syscalls used in the serialized program.
+func progSyscalls(data string) []string {
+	var ret []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if eq := strings.Index(line, " = "); eq != -1 && !strings.Contains(line[:eq], "(") {
+			line = line[eq+3:]
+		}
+		if paren := strings.IndexByte(line, '('); paren > 0 {
+			ret = append(ret, line[:paren])
+		}
+	}
+	return ret
+}
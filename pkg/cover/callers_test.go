@@ -0,0 +1,45 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"testing"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCoveredCallers(t *testing.T) {
+	sym := func(name string, callers ...*backend.Symbol) *backend.Symbol {
+		return &backend.Symbol{ObjectUnit: backend.ObjectUnit{Name: name}, Callers: callers}
+	}
+	syscall := sym("syscall")
+	ioctl := sym("ioctl", syscall)
+	helper := sym("helper", ioctl)
+	other := sym("other", syscall)
+	uncovered := sym("uncovered", helper, other)
+	covered := map[*backend.Symbol]bool{syscall: true, ioctl: true}
+	isCovered := func(s *backend.Symbol) bool { return covered[s] }
+
+	callers, depth := findCoveredCallers(uncovered, isCovered)
+	assert.Equal(t, []*backend.Symbol{ioctl, syscall}, callers)
+	assert.Equal(t, 2, depth)
+
+	callers, depth = findCoveredCallers(helper, isCovered)
+	assert.Equal(t, []*backend.Symbol{ioctl}, callers)
+	assert.Equal(t, 1, depth)
+
+	callers, _ = findCoveredCallers(sym("unreachable"), isCovered)
+	assert.Empty(t, callers)
+}
+
+func TestProgSyscalls(t *testing.T) {
+	data := `# comment
+r0 = openat$dir(0xffffffffffffff9c, &(0x7f0000000000)='./file0\x00', 0x0, 0x0)
+ioctl$FOO(r0, 0x1, &(0x7f0000000040)={0x1, 0x2})
+
+close(r0)
+`
+	assert.Equal(t, []string{"openat$dir", "ioctl$FOO", "close"}, progSyscalls(data))
+}
@@ -0,0 +1,259 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/syzkaller/pkg/covermerger"
+)
+
+// fileSnapshot is coverage of a source file in terms of source locations,
+// so that it can be compared with coverage of a different kernel build.
+type fileSnapshot struct {
+	filename  string // path to the source file of the build
+	covered   map[int]bool
+	functions map[string]bool // function name -> covered
+}
+
+func (rg *ReportGenerator) coverSnapshot(params HandlerParams) (map[string]*fileSnapshot, error) {
+	progs := fixUpPCs(params.Progs, params.Filter)
+	files, err := rg.prepareFileMap(progs, params.Force, params.Debug)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]*fileSnapshot)
+	for name, f := range files {
+		snap := &fileSnapshot{
+			filename:  f.filename,
+			covered:   make(map[int]bool),
+			functions: make(map[string]bool),
+		}
+		for ln := range f.lines {
+			snap.covered[ln] = true
+		}
+		for _, fun := range f.functions {
+			snap.functions[fun.name] = fun.covered != 0
+		}
+		ret[name] = snap
+	}
+	return ret, nil
+}
+
+// coverDiff is the difference between two coverage snapshots (possibly of different kernel builds).
+// Lines are matched by the source file contents, so only lines that are present in both builds
+// are compared (coverage of the changed lines is not considered to be gained or lost).
+// Coverage of the files that are present in only one of the snapshots is all gained or lost.
+type coverDiff struct {
+	NewFunctions  []diffFunction
+	LostFunctions []diffFunction
+	NewLines      []diffLines
+	LostLines     []diffLines
+}
+
+type diffFunction struct {
+	File string
+	Name string
+}
+
+type diffLines struct {
+	File  string
+	Lines []int // line numbers in the new version of the file (in the old one for removed files)
+}
+
+// DoCoverDiff writes the difference between coverage of baseParams.Progs on the base kernel build
+// and coverage of params.Progs on the rg kernel build. base may be the same as rg.
+func (rg *ReportGenerator) DoCoverDiff(w io.Writer, base *ReportGenerator, baseParams, params HandlerParams) error {
+	diff, err := rg.makeCoverDiff(base, baseParams, params)
+	if err != nil {
+		return err
+	}
+	return diff.write(w)
+}
+
+func (rg *ReportGenerator) makeCoverDiff(base *ReportGenerator, baseParams, params HandlerParams) (*coverDiff, error) {
+	oldFiles, err := base.coverSnapshot(baseParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare base coverage: %w", err)
+	}
+	newFiles, err := rg.coverSnapshot(params)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(oldFiles, newFiles, readLineMatcher), nil
+}
+
+// lineMapping maps line numbers between the old and new versions of a file (0 if there is no such line).
+type lineMapping struct {
+	toNew func(int) int
+	toOld func(int) int
+}
+
+type lineMatcher func(oldFile, newFile string) lineMapping
+
+func readLineMatcher(oldFile, newFile string) lineMapping {
+	sameLine := func(line int) int { return line }
+	if oldFile == newFile {
+		return lineMapping{sameLine, sameLine}
+	}
+	oldText, err1 := os.ReadFile(oldFile)
+	newText, err2 := os.ReadFile(newFile)
+	if err1 != nil || err2 != nil || string(oldText) == string(newText) {
+		// Without sources we can only assume that the file has not changed.
+		return lineMapping{sameLine, sameLine}
+	}
+	return lineMapping{
+		toNew: makeLineMatcher(string(oldText), string(newText)),
+		toOld: makeLineMatcher(string(newText), string(oldText)),
+	}
+}
+
+func makeLineMatcher(textFrom, textTo string) func(int) int {
+	matcher := covermerger.MakeLineToLineMatcher(textFrom, textTo)
+	lines := strings.Count(textFrom, "\n") + 1
+	return func(line int) int {
+		if line < 1 || line > lines {
+			return 0
+		}
+		return matcher.SameLinePos(line-1) + 1
+	}
+}
+
+func diffSnapshots(oldFiles, newFiles map[string]*fileSnapshot, match lineMatcher) *coverDiff {
+	diff := new(coverDiff)
+	var names []string
+	for name := range newFiles {
+		names = append(names, name)
+	}
+	for name := range oldFiles {
+		if newFiles[name] == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		oldFile, newFile := oldFiles[name], newFiles[name]
+		if oldFile == nil {
+			diff.NewFunctions, diff.NewLines = appendFileCover(diff.NewFunctions, diff.NewLines, name, newFile)
+			continue
+		}
+		if newFile == nil {
+			diff.LostFunctions, diff.LostLines = appendFileCover(diff.LostFunctions, diff.LostLines, name, oldFile)
+			continue
+		}
+		var funcs []string
+		for fun := range newFile.functions {
+			funcs = append(funcs, fun)
+		}
+		sort.Strings(funcs)
+		for _, fun := range funcs {
+			oldCovered, ok := oldFile.functions[fun]
+			if !ok {
+				continue
+			}
+			if newCovered := newFile.functions[fun]; newCovered && !oldCovered {
+				diff.NewFunctions = append(diff.NewFunctions, diffFunction{File: name, Name: fun})
+			} else if !newCovered && oldCovered {
+				diff.LostFunctions = append(diff.LostFunctions, diffFunction{File: name, Name: fun})
+			}
+		}
+		mapping := match(oldFile.filename, newFile.filename)
+		lost := diffLines{File: name}
+		for line := range oldFile.covered {
+			if newLine := mapping.toNew(line); newLine != 0 && !newFile.covered[newLine] {
+				lost.Lines = append(lost.Lines, newLine)
+			}
+		}
+		added := diffLines{File: name}
+		for line := range newFile.covered {
+			if oldLine := mapping.toOld(line); oldLine != 0 && !oldFile.covered[oldLine] {
+				added.Lines = append(added.Lines, line)
+			}
+		}
+		if len(lost.Lines) != 0 {
+			sort.Ints(lost.Lines)
+			diff.LostLines = append(diff.LostLines, lost)
+		}
+		if len(added.Lines) != 0 {
+			sort.Ints(added.Lines)
+			diff.NewLines = append(diff.NewLines, added)
+		}
+	}
+	return diff
+}
+
+// appendFileCover appends all covered functions and lines of the file.
+func appendFileCover(funcs []diffFunction, lines []diffLines, name string, file *fileSnapshot) (
+	[]diffFunction, []diffLines) {
+	var names []string
+	for fun, covered := range file.functions {
+		if covered {
+			names = append(names, fun)
+		}
+	}
+	sort.Strings(names)
+	for _, fun := range names {
+		funcs = append(funcs, diffFunction{File: name, Name: fun})
+	}
+	covered := diffLines{File: name}
+	for line := range file.covered {
+		covered.Lines = append(covered.Lines, line)
+	}
+	if len(covered.Lines) != 0 {
+		sort.Ints(covered.Lines)
+		lines = append(lines, covered)
+	}
+	return funcs, lines
+}
+
+// write writes the diff in a human-readable text form.
+func (diff *coverDiff) write(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+	writeFuncs := func(title string, funcs []diffFunction) {
+		fmt.Fprintf(buf, "%v (%v):\n", title, len(funcs))
+		for _, fun := range funcs {
+			fmt.Fprintf(buf, "\t%v: %v\n", fun.File, fun.Name)
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+	writeLines := func(title string, files []diffLines) {
+		total := 0
+		for _, file := range files {
+			total += len(file.Lines)
+		}
+		fmt.Fprintf(buf, "%v (%v):\n", title, total)
+		for _, file := range files {
+			fmt.Fprintf(buf, "\t%v: %v\n", file.File, formatLineRanges(file.Lines))
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+	writeFuncs("Newly covered functions", diff.NewFunctions)
+	writeFuncs("No longer covered functions", diff.LostFunctions)
+	writeLines("Newly covered lines", diff.NewLines)
+	writeLines("No longer covered lines", diff.LostLines)
+	return buf.Flush()
+}
+
+// formatLineRanges formats sorted line numbers as "1-3, 5, 7-8".
+func formatLineRanges(lines []int) string {
+	var ranges []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, fmt.Sprint(lines[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%v-%v", lines[i], lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ", ")
+}
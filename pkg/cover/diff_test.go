@@ -0,0 +1,107 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	oldFiles := map[string]*fileSnapshot{
+		"a.c": {
+			filename:  "old/a.c",
+			covered:   map[int]bool{1: true, 2: true, 5: true},
+			functions: map[string]bool{"foo": true, "bar": false, "removed": true},
+		},
+		"removed.c": {
+			filename:  "old/removed.c",
+			covered:   map[int]bool{1: true},
+			functions: map[string]bool{"baz": true},
+		},
+	}
+	newFiles := map[string]*fileSnapshot{
+		"a.c": {
+			filename:  "new/a.c",
+			covered:   map[int]bool{2: true, 3: true, 4: true, 7: true},
+			functions: map[string]bool{"foo": false, "bar": true, "added": true},
+		},
+		"added.c": {
+			filename:  "new/added.c",
+			covered:   map[int]bool{1: true},
+			functions: map[string]bool{"qux": true},
+		},
+	}
+	// Lines 1-4 are not changed, line 5 is moved to line 6, line 7 is new.
+	match := func(oldFile, newFile string) lineMapping {
+		assert.Equal(t, "old/a.c", oldFile)
+		assert.Equal(t, "new/a.c", newFile)
+		return lineMapping{
+			toNew: func(line int) int {
+				if line == 5 {
+					return 6
+				}
+				return line
+			},
+			toOld: func(line int) int {
+				switch line {
+				case 6:
+					return 5
+				case 7:
+					return 0
+				}
+				return line
+			},
+		}
+	}
+	diff := diffSnapshots(oldFiles, newFiles, match)
+	assert.Equal(t, &coverDiff{
+		NewFunctions:  []diffFunction{{File: "a.c", Name: "bar"}, {File: "added.c", Name: "qux"}},
+		LostFunctions: []diffFunction{{File: "a.c", Name: "foo"}, {File: "removed.c", Name: "baz"}},
+		NewLines:      []diffLines{{File: "a.c", Lines: []int{3, 4}}, {File: "added.c", Lines: []int{1}}},
+		LostLines:     []diffLines{{File: "a.c", Lines: []int{1, 6}}, {File: "removed.c", Lines: []int{1}}},
+	}, diff)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, diff.write(buf))
+	assert.Equal(t, `Newly covered functions (2):
+	a.c: bar
+	added.c: qux
+
+No longer covered functions (2):
+	a.c: foo
+	removed.c: baz
+
+Newly covered lines (3):
+	a.c: 3-4
+	added.c: 1
+
+No longer covered lines (3):
+	a.c: 1, 6
+	removed.c: 1
+
+`, buf.String())
+}
+
+func TestMakeLineMatcher(t *testing.T) {
+	oldText := "a\nb\nc\nd\n"
+	newText := "a\nnew\nb\nc\nd\n"
+	toNew := makeLineMatcher(oldText, newText)
+	assert.Equal(t, 1, toNew(1))
+	assert.Equal(t, 3, toNew(2))
+	assert.Equal(t, 5, toNew(4))
+	assert.Equal(t, 0, toNew(0))
+	assert.Equal(t, 0, toNew(100))
+	toOld := makeLineMatcher(newText, oldText)
+	assert.Equal(t, 0, toOld(2))
+	assert.Equal(t, 3, toOld(4))
+}
+
+func TestFormatLineRanges(t *testing.T) {
+	assert.Equal(t, "", formatLineRanges(nil))
+	assert.Equal(t, "1", formatLineRanges([]int{1}))
+	assert.Equal(t, "1-3, 5, 7-8", formatLineRanges([]int{1, 2, 3, 5, 7, 8}))
+}
@@ -0,0 +1,469 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+)
+
+// exportFile is per-file line and function coverage in the form that is easy
+// to convert to the standard coverage formats (LCOV, Cobertura, llvm-cov JSON).
+type exportFile struct {
+	name      string
+	lines     []exportLine
+	functions []exportFunction
+	branches  []branchCover
+}
+
+type exportLine struct {
+	line int
+	hits int // number of programs that cover the line
+}
+
+type exportFunction struct {
+	name string
+	line int // the first line of the function
+	hits int // max number of programs that cover a PC of the function
+}
+
+func (rg *ReportGenerator) prepareExport(params HandlerParams) ([]*exportFile, error) {
+	// Standard formats also need the not covered functions and lines.
+	if rg.CallbackPoints != nil {
+		if err := rg.symbolizePCs(rg.CallbackPoints); err != nil {
+			return nil, fmt.Errorf("failed to symbolize PCs(): %w", err)
+		}
+	}
+	progs := fixUpPCs(params.Progs, params.Filter)
+	files, err := rg.prepareFileMap(progs, params.Force, params.Debug)
+	if err != nil {
+		return nil, err
+	}
+	if params.Branches {
+		if err := rg.addBranchCoverage(files, progs); err != nil {
+			return nil, err
+		}
+	}
+	pcProgCount := make(map[uint64]int)
+	for _, prog := range progs {
+		for _, pc := range prog.PCs {
+			pcProgCount[pc]++
+		}
+	}
+	pcLine := make(map[uint64]int)
+	for _, frame := range rg.Frames {
+		if !frame.Inline && frame.StartLine > 0 {
+			pcLine[frame.PC] = frame.StartLine
+		}
+	}
+	funcs := make(map[string][]exportFunction)
+	for _, s := range rg.Symbols {
+		fun := exportFunction{name: s.Name}
+		for _, pc := range s.PCs {
+			if line := pcLine[pc]; line != 0 && (fun.line == 0 || line < fun.line) {
+				fun.line = line
+			}
+			fun.hits = max(fun.hits, pcProgCount[pc])
+		}
+		if fun.line != 0 {
+			funcs[s.Unit.Name] = append(funcs[s.Unit.Name], fun)
+		}
+	}
+	var ret []*exportFile
+	for name, f := range files {
+		lines := make(map[int]int)
+		for _, r := range f.uncovered {
+			if r.StartLine > 0 {
+				lines[r.StartLine] = 0
+			}
+		}
+		for ln, info := range f.lines {
+			if ln > 0 {
+				lines[ln] = len(info.progCount)
+			}
+		}
+		ef := &exportFile{
+			name:      name,
+			functions: funcs[name],
+			branches:  f.branches,
+		}
+		for ln, hits := range lines {
+			ef.lines = append(ef.lines, exportLine{line: ln, hits: hits})
+		}
+		if len(ef.lines) == 0 && len(ef.functions) == 0 {
+			continue
+		}
+		sort.Slice(ef.lines, func(i, j int) bool {
+			return ef.lines[i].line < ef.lines[j].line
+		})
+		sort.Slice(ef.functions, func(i, j int) bool {
+			fi, fj := ef.functions[i], ef.functions[j]
+			if fi.line != fj.line {
+				return fi.line < fj.line
+			}
+			return fi.name < fj.name
+		})
+		ret = append(ret, ef)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].name < ret[j].name
+	})
+	return ret, nil
+}
+
+func (ef *exportFile) coveredLines() int {
+	covered := 0
+	for _, ln := range ef.lines {
+		if ln.hits != 0 {
+			covered++
+		}
+	}
+	return covered
+}
+
+// coveredBranches returns the number of taken branch directions.
+func (ef *exportFile) coveredBranches() int {
+	covered := 0
+	for _, b := range ef.branches {
+		for _, count := range []int{b.taken, b.notTaken} {
+			if count != 0 {
+				covered++
+			}
+		}
+	}
+	return covered
+}
+
+func (ef *exportFile) coveredFunctions() int {
+	covered := 0
+	for _, fun := range ef.functions {
+		if fun.hits != 0 {
+			covered++
+		}
+	}
+	return covered
+}
+
+// DoLCOV is a handler for "/cover?lcov=1".
+// It generates the LCOV tracefile (see geninfo(1)) with line, function and (optionally) branch coverage,
+// hit counts are numbers of programs that cover the line/function/branch direction.
+func (rg *ReportGenerator) DoLCOV(w io.Writer, params HandlerParams) error {
+	files, err := rg.prepareExport(params)
+	if err != nil {
+		return err
+	}
+	return writeLCOV(w, files)
+}
+
+func writeLCOV(w io.Writer, files []*exportFile) error {
+	buf := bufio.NewWriter(w)
+	for _, ef := range files {
+		fmt.Fprintf(buf, "TN:\nSF:%v\n", ef.name)
+		for _, fun := range ef.functions {
+			fmt.Fprintf(buf, "FN:%v,%v\n", fun.line, fun.name)
+		}
+		for _, fun := range ef.functions {
+			fmt.Fprintf(buf, "FNDA:%v,%v\n", fun.hits, fun.name)
+		}
+		fmt.Fprintf(buf, "FNF:%v\nFNH:%v\n", len(ef.functions), ef.coveredFunctions())
+		if len(ef.branches) != 0 {
+			block := 0
+			for i, b := range ef.branches {
+				if i != 0 && ef.branches[i-1].line == b.line {
+					block++
+				} else {
+					block = 0
+				}
+				for dir, count := range []int{b.taken, b.notTaken} {
+					taken := "-"
+					if b.taken+b.notTaken != 0 {
+						taken = fmt.Sprint(count)
+					}
+					fmt.Fprintf(buf, "BRDA:%v,%v,%v,%v\n", b.line, block, dir, taken)
+				}
+			}
+			fmt.Fprintf(buf, "BRF:%v\nBRH:%v\n", 2*len(ef.branches), ef.coveredBranches())
+		}
+		for _, ln := range ef.lines {
+			fmt.Fprintf(buf, "DA:%v,%v\n", ln.line, ln.hits)
+		}
+		fmt.Fprintf(buf, "LF:%v\nLH:%v\nend_of_record\n", len(ef.lines), ef.coveredLines())
+	}
+	return buf.Flush()
+}
+
+type coberturaCoverage struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        float64            `xml:"line-rate,attr"`
+	BranchRate      float64            `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      float64            `xml:"complexity,attr"`
+	Version         string             `xml:"version,attr"`
+	Timestamp       int64              `xml:"timestamp,attr"`
+	Sources         []string           `xml:"sources>source"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   float64          `xml:"line-rate,attr"`
+	BranchRate float64          `xml:"branch-rate,attr"`
+	Complexity float64          `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Name       string            `xml:"name,attr"`
+	Filename   string            `xml:"filename,attr"`
+	LineRate   float64           `xml:"line-rate,attr"`
+	BranchRate float64           `xml:"branch-rate,attr"`
+	Complexity float64           `xml:"complexity,attr"`
+	Methods    []coberturaMethod `xml:"methods>method"`
+	Lines      []coberturaLine   `xml:"lines>line"`
+}
+
+type coberturaMethod struct {
+	Name       string          `xml:"name,attr"`
+	Signature  string          `xml:"signature,attr"`
+	LineRate   float64         `xml:"line-rate,attr"`
+	BranchRate float64         `xml:"branch-rate,attr"`
+	Complexity float64         `xml:"complexity,attr"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr,omitempty"`
+}
+
+// DoCobertura is a handler for "/cover?cobertura=1".
+// It generates the Cobertura XML report with line, function and (optionally) branch coverage.
+// Source directories are packages and source files are classes.
+func (rg *ReportGenerator) DoCobertura(w io.Writer, params HandlerParams) error {
+	files, err := rg.prepareExport(params)
+	if err != nil {
+		return err
+	}
+	return writeCobertura(w, files, rg.srcDir, time.Now())
+}
+
+func writeCobertura(w io.Writer, files []*exportFile, srcDir string, now time.Time) error {
+	cov := &coberturaCoverage{
+		Version:   "syzkaller",
+		Timestamp: now.Unix(),
+		Sources:   []string{srcDir},
+	}
+	packages := make(map[string]*coberturaPackage)
+	packageLines := make(map[string][2]int)
+	packageBranches := make(map[string][2]int)
+	var packageNames []string
+	for _, ef := range files {
+		dir := path.Dir(ef.name)
+		pkg := packages[dir]
+		if pkg == nil {
+			pkg = &coberturaPackage{Name: dir}
+			packages[dir] = pkg
+			packageNames = append(packageNames, dir)
+		}
+		class := coberturaClass{
+			Name:       path.Base(ef.name),
+			Filename:   ef.name,
+			LineRate:   lineRate(ef.coveredLines(), len(ef.lines)),
+			BranchRate: lineRate(ef.coveredBranches(), 2*len(ef.branches)),
+		}
+		for _, fun := range ef.functions {
+			rate := 0.0
+			if fun.hits != 0 {
+				rate = 1
+			}
+			class.Methods = append(class.Methods, coberturaMethod{
+				Name:     fun.name,
+				LineRate: rate,
+				Lines:    []coberturaLine{{Number: fun.line, Hits: fun.hits}},
+			})
+		}
+		branches := branchesPerLine(ef.branches)
+		for _, ln := range ef.lines {
+			line := coberturaLine{Number: ln.line, Hits: ln.hits}
+			if lb, ok := branches[ln.line]; ok {
+				line.Branch = true
+				line.ConditionCoverage = fmt.Sprintf("%v%% (%v/%v)", Percent(lb.taken, lb.total), lb.taken, lb.total)
+			}
+			class.Lines = append(class.Lines, line)
+		}
+		pkg.Classes = append(pkg.Classes, class)
+		stats := packageLines[dir]
+		stats[0] += ef.coveredLines()
+		stats[1] += len(ef.lines)
+		packageLines[dir] = stats
+		stats = packageBranches[dir]
+		stats[0] += ef.coveredBranches()
+		stats[1] += 2 * len(ef.branches)
+		packageBranches[dir] = stats
+		cov.LinesCovered += ef.coveredLines()
+		cov.LinesValid += len(ef.lines)
+		cov.BranchesCovered += ef.coveredBranches()
+		cov.BranchesValid += 2 * len(ef.branches)
+	}
+	sort.Strings(packageNames)
+	for _, name := range packageNames {
+		pkg := packages[name]
+		pkg.LineRate = lineRate(packageLines[name][0], packageLines[name][1])
+		pkg.BranchRate = lineRate(packageBranches[name][0], packageBranches[name][1])
+		cov.Packages = append(cov.Packages, *pkg)
+	}
+	cov.LineRate = lineRate(cov.LinesCovered, cov.LinesValid)
+	cov.BranchRate = lineRate(cov.BranchesCovered, cov.BranchesValid)
+	if _, err := io.WriteString(w, xml.Header+
+		`<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">`+"\n"); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(cov); err != nil {
+		return fmt.Errorf("failed to encode cobertura report: %w", err)
+	}
+	return nil
+}
+
+func lineRate(covered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) / float64(total)
+}
+
+// llvm-cov export JSON format, see https://llvm.org/docs/CommandGuide/llvm-cov.html#llvm-cov-export.
+// Only line, function and branch data is filled in, there are no code regions.
+type llvmCovExport struct {
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Data    []llvmCovData `json:"data"`
+}
+
+type llvmCovData struct {
+	Files     []llvmCovFile     `json:"files"`
+	Functions []llvmCovFunction `json:"functions"`
+	Totals    llvmCovSummary    `json:"totals"`
+}
+
+type llvmCovFile struct {
+	Filename string `json:"filename"`
+	// Each segment is [line, col, count, has count, is region entry, is gap region].
+	Segments [][]any `json:"segments"`
+	// Each branch is [line start, col start, line end, col end, true count, false count,
+	// file id, expanded file id, kind].
+	Branches [][]int        `json:"branches"`
+	Summary  llvmCovSummary `json:"summary"`
+}
+
+type llvmCovFunction struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	// Each region is [line start, col start, line end, col end, count, file id, expanded file id, kind].
+	Regions   [][]int  `json:"regions"`
+	Filenames []string `json:"filenames"`
+}
+
+type llvmCovSummary struct {
+	Lines     llvmCovStat `json:"lines"`
+	Functions llvmCovStat `json:"functions"`
+	Branches  llvmCovStat `json:"branches"`
+}
+
+type llvmCovStat struct {
+	Count   int     `json:"count"`
+	Covered int     `json:"covered"`
+	Percent float64 `json:"percent"`
+}
+
+func makeLLVMCovStat(covered, total int) llvmCovStat {
+	return llvmCovStat{
+		Count:   total,
+		Covered: covered,
+		Percent: lineRate(covered, total) * 100,
+	}
+}
+
+// DoLLVMCovJSON is a handler for "/cover?llvmjson=1".
+// It generates the report in the llvm-cov export JSON format with line, function and (optionally)
+// branch coverage.
+func (rg *ReportGenerator) DoLLVMCovJSON(w io.Writer, params HandlerParams) error {
+	files, err := rg.prepareExport(params)
+	if err != nil {
+		return err
+	}
+	return writeLLVMCovJSON(w, files)
+}
+
+func writeLLVMCovJSON(w io.Writer, files []*exportFile) error {
+	data := llvmCovData{
+		Files:     []llvmCovFile{},
+		Functions: []llvmCovFunction{},
+	}
+	var linesCovered, linesTotal, funcsCovered, funcsTotal, branchesCovered, branchesTotal int
+	for _, ef := range files {
+		file := llvmCovFile{
+			Filename: ef.name,
+			Segments: [][]any{},
+			Branches: [][]int{},
+			Summary: llvmCovSummary{
+				Lines:     makeLLVMCovStat(ef.coveredLines(), len(ef.lines)),
+				Functions: makeLLVMCovStat(ef.coveredFunctions(), len(ef.functions)),
+				Branches:  makeLLVMCovStat(ef.coveredBranches(), 2*len(ef.branches)),
+			},
+		}
+		for _, b := range ef.branches {
+			// 4 is the llvm BranchRegion kind.
+			file.Branches = append(file.Branches, []int{b.line, 1, b.line, 1, b.taken, b.notTaken, 0, 0, 4})
+		}
+		for i, ln := range ef.lines {
+			// The line is a region that starts at the beginning of the line and ends at the next line.
+			file.Segments = append(file.Segments, []any{ln.line, 1, ln.hits, true, true, false})
+			if i == len(ef.lines)-1 || ef.lines[i+1].line != ln.line+1 {
+				file.Segments = append(file.Segments, []any{ln.line + 1, 1, 0, false, false, false})
+			}
+		}
+		data.Files = append(data.Files, file)
+		for _, fun := range ef.functions {
+			data.Functions = append(data.Functions, llvmCovFunction{
+				Name:      fun.name,
+				Count:     fun.hits,
+				Regions:   [][]int{{fun.line, 1, fun.line, 1, fun.hits, 0, 0, 0}},
+				Filenames: []string{ef.name},
+			})
+		}
+		linesCovered += ef.coveredLines()
+		linesTotal += len(ef.lines)
+		funcsCovered += ef.coveredFunctions()
+		funcsTotal += len(ef.functions)
+		branchesCovered += ef.coveredBranches()
+		branchesTotal += 2 * len(ef.branches)
+	}
+	data.Totals = llvmCovSummary{
+		Lines:     makeLLVMCovStat(linesCovered, linesTotal),
+		Functions: makeLLVMCovStat(funcsCovered, funcsTotal),
+		Branches:  makeLLVMCovStat(branchesCovered, branchesTotal),
+	}
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(&llvmCovExport{
+		Type:    "llvm.coverage.json.export",
+		Version: "2.0.1",
+		Data:    []llvmCovData{data},
+	}); err != nil {
+		return fmt.Errorf("failed to encode llvm-cov report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testExportFiles = []*exportFile{
+	{
+		name: "fs/file.c",
+		lines: []exportLine{
+			{line: 10, hits: 2},
+			{line: 11, hits: 0},
+			{line: 20, hits: 1},
+		},
+		functions: []exportFunction{
+			{name: "foo", line: 9, hits: 2},
+			{name: "bar", line: 19, hits: 0},
+		},
+		branches: []branchCover{
+			{line: 10, taken: 2, notTaken: 0},
+			{line: 10, taken: 1, notTaken: 1},
+			{line: 11, taken: 0, notTaken: 0},
+		},
+	},
+	{
+		name: "fs/inode.c",
+		lines: []exportLine{
+			{line: 5, hits: 0},
+		},
+	},
+}
+
+func TestExportLCOV(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.NoError(t, writeLCOV(buf, testExportFiles))
+	assert.Equal(t, `TN:
+SF:fs/file.c
+FN:9,foo
+FN:19,bar
+FNDA:2,foo
+FNDA:0,bar
+FNF:2
+FNH:1
+BRDA:10,0,0,2
+BRDA:10,0,1,0
+BRDA:10,1,0,1
+BRDA:10,1,1,1
+BRDA:11,0,0,-
+BRDA:11,0,1,-
+BRF:6
+BRH:3
+DA:10,2
+DA:11,0
+DA:20,1
+LF:3
+LH:2
+end_of_record
+TN:
+SF:fs/inode.c
+FNF:0
+FNH:0
+DA:5,0
+LF:1
+LH:0
+end_of_record
+`, buf.String())
+}
+
+func TestExportCobertura(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.NoError(t, writeCobertura(buf, testExportFiles, "/src", time.Unix(1000, 0)))
+	var cov coberturaCoverage
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &cov))
+	assert.Equal(t, int64(1000), cov.Timestamp)
+	assert.Equal(t, []string{"/src"}, cov.Sources)
+	assert.Equal(t, 2, cov.LinesCovered)
+	assert.Equal(t, 4, cov.LinesValid)
+	assert.Equal(t, 0.5, cov.LineRate)
+	assert.Len(t, cov.Packages, 1)
+	pkg := cov.Packages[0]
+	assert.Equal(t, "fs", pkg.Name)
+	assert.Len(t, pkg.Classes, 2)
+	assert.Equal(t, "file.c", pkg.Classes[0].Name)
+	assert.Equal(t, "fs/file.c", pkg.Classes[0].Filename)
+	assert.Equal(t, []coberturaLine{
+		{Number: 10, Hits: 2, Branch: true, ConditionCoverage: "75% (3/4)"},
+		{Number: 11, Branch: true, ConditionCoverage: "0% (0/2)"},
+		{Number: 20, Hits: 1},
+	}, pkg.Classes[0].Lines)
+	assert.Equal(t, 3, cov.BranchesCovered)
+	assert.Equal(t, 6, cov.BranchesValid)
+	assert.Len(t, pkg.Classes[0].Methods, 2)
+	assert.Equal(t, "bar", pkg.Classes[0].Methods[1].Name)
+	assert.Equal(t, 0.0, pkg.Classes[0].Methods[1].LineRate)
+}
+
+func TestExportLLVMCovJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.NoError(t, writeLLVMCovJSON(buf, testExportFiles))
+	var export llvmCovExport
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &export))
+	assert.Equal(t, "llvm.coverage.json.export", export.Type)
+	assert.Len(t, export.Data, 1)
+	data := export.Data[0]
+	assert.Len(t, data.Files, 2)
+	assert.Equal(t, llvmCovSummary{
+		Lines:     makeLLVMCovStat(2, 3),
+		Functions: llvmCovStat{Count: 2, Covered: 1, Percent: 50},
+		Branches:  llvmCovStat{Count: 6, Covered: 3, Percent: 50},
+	}, data.Files[0].Summary)
+	assert.Equal(t, []int{10, 1, 10, 1, 2, 0, 0, 0, 4}, data.Files[0].Branches[0])
+	// Lines 10 and 11 are adjacent, so there is no segment end between them.
+	assert.Len(t, data.Files[0].Segments, 5)
+	assert.Len(t, data.Functions, 2)
+	assert.Equal(t, llvmCovStat{Count: 4, Covered: 2, Percent: 50}, data.Totals.Lines)
+}
+
+func TestBranchesPerLine(t *testing.T) {
+	branches := branchesPerLine(testExportFiles[0].branches)
+	assert.Equal(t, map[int]lineBranches{
+		10: {taken: 3, total: 4},
+		11: {taken: 0, total: 2},
+	}, branches)
+	assert.True(t, branches[10].partial())
+	assert.False(t, branches[11].partial())
+}
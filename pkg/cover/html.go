@@ -29,6 +29,10 @@ type HandlerParams struct {
 	Filter map[uint64]struct{}
 	Debug  bool
 	Force  bool
+	// Report branch coverage in addition to line coverage (requires disassembling the binary).
+	Branches bool
+	// Explain uncovered functions by their nearest covered callers (requires scanning the binary).
+	Callers bool
 }
 
 func (rg *ReportGenerator) DoHTML(w io.Writer, params HandlerParams) error {
@@ -37,6 +41,16 @@ func (rg *ReportGenerator) DoHTML(w io.Writer, params HandlerParams) error {
 	if err != nil {
 		return err
 	}
+	if params.Branches {
+		if err := rg.addBranchCoverage(files, progs); err != nil {
+			return err
+		}
+	}
+	if params.Callers {
+		if err := rg.addCoveredCallers(files, progs); err != nil {
+			return err
+		}
+	}
 	d := &templateData{
 		Root:     new(templateDir),
 		RawCover: rg.rawCoverEnabled,
@@ -84,7 +98,7 @@ func (rg *ReportGenerator) DoHTML(w io.Writer, params HandlerParams) error {
 		if file.coveredPCs == 0 {
 			continue
 		}
-		addFunctionCoverage(file, d)
+		addFunctionCoverage(file, d, haveProgs)
 		contents := ""
 		lines, err := parseFile(file.filename)
 		if err == nil {
@@ -125,6 +139,8 @@ type lineCoverExport struct {
 	Covered   []int `json:",omitempty"`
 	Uncovered []int `json:",omitempty"`
 	Both      []int `json:",omitempty"`
+	// Lines with conditional branches where only some of the directions were taken.
+	PartialBranches []int `json:",omitempty"`
 }
 
 func (rg *ReportGenerator) DoLineJSON(w io.Writer, params HandlerParams) error {
@@ -133,6 +149,11 @@ func (rg *ReportGenerator) DoLineJSON(w io.Writer, params HandlerParams) error {
 	if err != nil {
 		return err
 	}
+	if params.Branches {
+		if err := rg.addBranchCoverage(files, progs); err != nil {
+			return err
+		}
+	}
 	var entries []lineCoverExport
 	for _, file := range files {
 		lines, err := parseFile(file.filename)
@@ -173,6 +194,13 @@ func fileLineContents(file *file, lines [][]byte) lineCoverExport {
 			}
 		}
 	}
+	branches := branchesPerLine(file.branches)
+	for ln, lb := range branches {
+		if lb.partial() {
+			lce.PartialBranches = append(lce.PartialBranches, ln)
+		}
+	}
+	sort.Ints(lce.PartialBranches)
 	return lce
 }
 
@@ -259,6 +287,7 @@ func (rg *ReportGenerator) DoCoverJSONL(w io.Writer, params HandlerParams) error
 type ProgramCoverage struct {
 	Repo         string          `json:"repo,omitempty"`
 	Commit       string          `json:"commit,omitempty"`
+	Sig          string          `json:"sig,omitempty"`
 	Program      string          `json:"program"`
 	CoveredFiles []*FileCoverage `json:"coverage"`
 }
@@ -340,6 +369,7 @@ func (rg *ReportGenerator) DoCoverPrograms(w io.Writer, params HandlerParams) er
 		}
 
 		if err := encoder.Encode(&ProgramCoverage{
+			Sig:          prog.Sig,
 			Program:      prog.Data,
 			CoveredFiles: progCoverage,
 		}); err != nil {
@@ -765,6 +795,22 @@ func fileContents(file *file, lines [][]byte, haveProgs bool) string {
 			buf.WriteByte('\n')
 		}
 	}
+	if len(file.branches) != 0 {
+		// Number of taken branch directions out of all branch directions on the line.
+		branches := branchesPerLine(file.branches)
+		buf.WriteString("</td><td class='branch'>")
+		for i := range lines {
+			if lb, ok := branches[i+1]; ok {
+				class := ""
+				if lb.partial() {
+					class = "branch-partial"
+				}
+				buf.WriteString(fmt.Sprintf("<span class='%v' title='%v of %v branch directions taken'>%v/%v</span>",
+					class, lb.taken, lb.total, lb.taken, lb.total))
+			}
+			buf.WriteByte('\n')
+		}
+	}
 	buf.WriteString("</td><td>")
 	for i := range lines {
 		buf.WriteString(fmt.Sprintf("%d\n", i+1))
@@ -869,7 +915,7 @@ func mergeLine(chunks []lineCoverChunk, start, end int, covered bool) []lineCove
 	return res
 }
 
-func addFunctionCoverage(file *file, data *templateData) {
+func addFunctionCoverage(file *file, data *templateData, haveProgs bool) {
 	var buf bytes.Buffer
 	var coveredTotal int
 	var TotalInCoveredFunc int
@@ -886,6 +932,7 @@ func addFunctionCoverage(file *file, data *templateData) {
 		buf.WriteString(fmt.Sprintf("<span class='cover hover'>%v", percentage))
 		buf.WriteString(fmt.Sprintf("<span class='cover-right'>of %v", strconv.Itoa(function.pcs)))
 		buf.WriteString("</span></span></span><br>\n")
+		writeCoveredCallers(&buf, function.callers, haveProgs)
 	}
 	buf.WriteString("-----------<br>\n")
 	buf.WriteString("<span class='hover'>SUMMARY")
@@ -901,6 +948,26 @@ func addFunctionCoverage(file *file, data *templateData) {
 	data.Functions = append(data.Functions, template.HTML(buf.String()))
 }
 
+func writeCoveredCallers(buf *bytes.Buffer, callers []coveredCaller, haveProgs bool) {
+	for _, caller := range callers {
+		buf.WriteString("<div class='callers'>")
+		via := ""
+		if caller.depth > 1 {
+			via = fmt.Sprintf(" (via %v calls)", caller.depth)
+		}
+		buf.WriteString(fmt.Sprintf("called from covered <b>%v</b>%v", html.EscapeString(caller.name), via))
+		if len(caller.syscalls) != 0 {
+			buf.WriteString(fmt.Sprintf(", reached by %v", html.EscapeString(strings.Join(caller.syscalls, ", "))))
+		}
+		if haveProgs {
+			for _, idx := range caller.progs {
+				buf.WriteString(fmt.Sprintf(" <span class='prog' onclick='onProgClick(%v, this)'>[prog]</span>", idx))
+			}
+		}
+		buf.WriteString("</div>\n")
+	}
+}
+
 func processDir(dir *templateDir) {
 	for len(dir.Dirs) == 1 && len(dir.Files) == 0 {
 		for _, child := range dir.Dirs {
@@ -0,0 +1,166 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// FuncPrograms is a single record of the DoLinePrograms jsonl stream.
+// It attributes coverage of a function and its lines to the corpus programs.
+type FuncPrograms struct {
+	FilePath string `json:"file_path"`
+	FuncName string `json:"func_name"`
+	// A small set of programs (signatures) that together cover all covered lines of the function.
+	Programs []string       `json:"programs"`
+	Lines    []LinePrograms `json:"lines"`
+}
+
+// LinePrograms attributes coverage of a single source line to the corpus programs.
+type LinePrograms struct {
+	Line int `json:"line"`
+	// Signatures of the smallest programs that cover the line, the smallest first.
+	Programs []string `json:"programs"`
+	// Total number of programs that cover the line.
+	Total int `json:"total"`
+}
+
+// Max number of programs listed for a single line.
+const maxLinePrograms = 5
+
+// DoLinePrograms is a handler for "/cover?lineprogs=1".
+// It writes a FuncPrograms record for every covered function in a jsonl stream.
+// Programs are identified by Prog.Sig, the reverse mapping can be obtained with DoCoverPrograms.
+func (rg *ReportGenerator) DoLinePrograms(w io.Writer, params HandlerParams) error {
+	for _, prog := range params.Progs {
+		if prog.Sig == "" {
+			return fmt.Errorf("the programs attribution requires the corpus programs")
+		}
+	}
+	progs := fixUpPCs(params.Progs, params.Filter)
+	if err := rg.symbolizePCs(uniquePCs(progs...)); err != nil {
+		return err
+	}
+	pcToProgs := make(map[uint64][]int)
+	for i, prog := range progs {
+		for _, pc := range uniquePCs(prog) {
+			pcToProgs[pc] = append(pcToProgs[pc], i)
+		}
+	}
+	type funcKey struct {
+		file string
+		fn   string
+	}
+	funcLines := make(map[funcKey]map[int]map[int]bool)
+	for _, frame := range rg.Frames {
+		coveredBy := pcToProgs[frame.PC]
+		if frame.StartLine <= 0 || len(coveredBy) == 0 {
+			continue
+		}
+		key := funcKey{frame.Name, frame.FuncName}
+		if funcLines[key] == nil {
+			funcLines[key] = make(map[int]map[int]bool)
+		}
+		if funcLines[key][frame.StartLine] == nil {
+			funcLines[key][frame.StartLine] = make(map[int]bool)
+		}
+		for _, idx := range coveredBy {
+			funcLines[key][frame.StartLine][idx] = true
+		}
+	}
+	var keys []funcKey
+	for key := range funcLines {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].file != keys[j].file {
+			return keys[i].file < keys[j].file
+		}
+		return keys[i].fn < keys[j].fn
+	})
+	// Smaller programs are more convenient to start with.
+	smaller := func(a, b int) bool {
+		if len(progs[a].Data) != len(progs[b].Data) {
+			return len(progs[a].Data) < len(progs[b].Data)
+		}
+		return a < b
+	}
+	encoder := json.NewEncoder(w)
+	for _, key := range keys {
+		lines := funcLines[key]
+		rec := &FuncPrograms{
+			FilePath: key.file,
+			FuncName: key.fn,
+		}
+		for _, idx := range minimalProgramSet(lines, smaller) {
+			rec.Programs = append(rec.Programs, progs[idx].Sig)
+		}
+		for line, coveredBy := range lines {
+			var sorted []int
+			for idx := range coveredBy {
+				sorted = append(sorted, idx)
+			}
+			sort.Slice(sorted, func(i, j int) bool {
+				return smaller(sorted[i], sorted[j])
+			})
+			lp := LinePrograms{
+				Line:  line,
+				Total: len(sorted),
+			}
+			for _, idx := range sorted[:min(len(sorted), maxLinePrograms)] {
+				lp.Programs = append(lp.Programs, progs[idx].Sig)
+			}
+			rec.Lines = append(rec.Lines, lp)
+		}
+		sort.Slice(rec.Lines, func(i, j int) bool {
+			return rec.Lines[i].Line < rec.Lines[j].Line
+		})
+		if err := encoder.Encode(rec); err != nil {
+			return fmt.Errorf("failed to json.Encode(): %w", err)
+		}
+	}
+	return nil
+}
+
+// minimalProgramSet greedily selects programs that together cover all the lines:
+// at each step it takes the program that covers the most of the remaining lines (the smaller one on ties).
+func minimalProgramSet(lines map[int]map[int]bool, smaller func(a, b int) bool) []int {
+	progLines := make(map[int][]int)
+	for line, coveredBy := range lines {
+		for idx := range coveredBy {
+			progLines[idx] = append(progLines[idx], line)
+		}
+	}
+	remaining := make(map[int]bool)
+	for line := range lines {
+		remaining[line] = true
+	}
+	var ret []int
+	for len(remaining) != 0 {
+		best, bestCount := -1, 0
+		for idx, covered := range progLines {
+			count := 0
+			for _, line := range covered {
+				if remaining[line] {
+					count++
+				}
+			}
+			if count > bestCount || count == bestCount && count != 0 && smaller(idx, best) {
+				best, bestCount = idx, count
+			}
+		}
+		for _, line := range progLines[best] {
+			delete(remaining, line)
+		}
+		delete(progLines, best)
+		ret = append(ret, best)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return smaller(ret[i], ret[j])
+	})
+	return ret
+}
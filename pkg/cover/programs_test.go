@@ -0,0 +1,81 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoLinePrograms(t *testing.T) {
+	frame := func(pc uint64, file, fn string, line int) *backend.Frame {
+		return &backend.Frame{PC: pc, Name: file, FuncName: fn, Range: backend.Range{StartLine: line}}
+	}
+	rg := &ReportGenerator{
+		Impl: &backend.Impl{
+			Frames: []*backend.Frame{
+				frame(0x10, "fs/foo.c", "foo", 10),
+				frame(0x11, "fs/foo.c", "foo", 11),
+				frame(0x12, "fs/foo.c", "foo", 12),
+				frame(0x13, "fs/foo.c", "foo", 13),
+				frame(0x20, "fs/bar.c", "bar", 5),
+			},
+		},
+	}
+	progs := []Prog{
+		{Sig: "long", Data: "long program\n", PCs: []uint64{0x10, 0x11, 0x12}},
+		{Sig: "short", Data: "short\n", PCs: []uint64{0x10, 0x20}},
+		{Sig: "medium", Data: "medium prog\n", PCs: []uint64{0x11, 0x12}},
+		{Sig: "other", Data: "other program\n", PCs: []uint64{0x12}},
+	}
+	buf := new(bytes.Buffer)
+	assert.NoError(t, rg.DoLinePrograms(buf, HandlerParams{Progs: progs}))
+	var got []FuncPrograms
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var rec FuncPrograms
+		assert.NoError(t, dec.Decode(&rec))
+		got = append(got, rec)
+	}
+	assert.Equal(t, []FuncPrograms{
+		{
+			FilePath: "fs/bar.c",
+			FuncName: "bar",
+			Programs: []string{"short"},
+			Lines:    []LinePrograms{{Line: 5, Programs: []string{"short"}, Total: 1}},
+		},
+		{
+			FilePath: "fs/foo.c",
+			FuncName: "foo",
+			Programs: []string{"long"},
+			Lines: []LinePrograms{
+				{Line: 10, Programs: []string{"short", "long"}, Total: 2},
+				{Line: 11, Programs: []string{"medium", "long"}, Total: 2},
+				{Line: 12, Programs: []string{"medium", "long", "other"}, Total: 3},
+			},
+		},
+	}, got)
+}
+
+func TestMinimalProgramSet(t *testing.T) {
+	lines := map[int]map[int]bool{
+		1: {0: true, 1: true},
+		2: {1: true, 2: true},
+		3: {2: true},
+		4: {3: true},
+	}
+	// Programs 1 and 2 cover the same number of lines first, the smaller one (by index here) wins.
+	smaller := func(a, b int) bool { return a < b }
+	assert.Equal(t, []int{1, 2, 3}, minimalProgramSet(lines, smaller))
+}
+
+func TestDoLineProgramsNoCorpus(t *testing.T) {
+	rg := &ReportGenerator{Impl: &backend.Impl{}}
+	err := rg.DoLinePrograms(new(bytes.Buffer), HandlerParams{Progs: []Prog{{PCs: []uint64{0x10}}}})
+	assert.Error(t, err)
+}
@@ -62,12 +62,33 @@ type file struct {
 	uncovered  []backend.Range
 	totalPCs   int
 	coveredPCs int
+	branches   []branchCover
+}
+
+// branchCover is coverage of the two directions of a conditional branch.
+type branchCover struct {
+	line     int
+	taken    int // number of programs that took the branch
+	notTaken int // number of programs that did not take the branch
+}
+
+// lineBranches is the number of taken branch directions and the total number of branch directions on a line.
+type lineBranches struct {
+	taken int
+	total int
+}
+
+func (lb lineBranches) partial() bool {
+	return lb.taken != 0 && lb.taken != lb.total
 }
 
 type function struct {
 	name    string
 	pcs     int
 	covered int
+	sym     *backend.Symbol
+	// The nearest covered callers of an uncovered function, filled in by addCoveredCallers.
+	callers []coveredCaller
 }
 
 type line struct {
@@ -125,6 +146,7 @@ func (rg *ReportGenerator) prepareFileMap(progs []Prog, force, debug bool) (file
 		fun := &function{
 			name: s.Name,
 			pcs:  len(s.PCs),
+			sym:  s,
 		}
 		for _, pc := range s.PCs {
 			if pcToProgs[pc] != nil {
@@ -178,6 +200,86 @@ func (rg *ReportGenerator) frame2line(files fileMap, pcToProgs map[uint64]map[in
 	return nil
 }
 
+// addBranchCoverage attributes conditional branches of the covered functions to source lines.
+// Branches are read from the binary on demand since it requires disassembling it.
+func (rg *ReportGenerator) addBranchCoverage(files fileMap, progs []Prog) error {
+	if rg.Branches == nil {
+		return fmt.Errorf("branch coverage is not supported for %v", rg.target.Arch)
+	}
+	pcProgCount := make(map[uint64]int)
+	for _, prog := range progs {
+		for _, pc := range prog.PCs {
+			pcProgCount[pc]++
+		}
+	}
+	var syms []*backend.Symbol
+	for _, s := range rg.Symbols {
+		for _, pc := range s.PCs {
+			if pcProgCount[pc] != 0 {
+				syms = append(syms, s)
+				break
+			}
+		}
+	}
+	if err := rg.Branches(syms); err != nil {
+		return err
+	}
+	branches := make(map[uint64]*backend.Branch)
+	pcs := make(map[*vminfo.KernelModule][]uint64)
+	for _, s := range syms {
+		for _, b := range s.Branches {
+			branches[b.PC] = b
+			pcs[s.Module] = append(pcs[s.Module], b.PC)
+		}
+	}
+	if len(branches) == 0 {
+		return nil
+	}
+	frames, err := rg.Symbolize(pcs)
+	if err != nil {
+		return err
+	}
+	seen := make(map[uint64]bool)
+	for _, frame := range frames {
+		// The first frame is the innermost one, that's where the condition is.
+		if seen[frame.PC] {
+			continue
+		}
+		seen[frame.PC] = true
+		if frame.StartLine <= 0 {
+			continue
+		}
+		b := branches[frame.PC]
+		f := fileByFrame(files, frame)
+		f.branches = append(f.branches, branchCover{
+			line:     frame.StartLine,
+			taken:    pcProgCount[b.Taken],
+			notTaken: pcProgCount[b.NotTaken],
+		})
+	}
+	for _, f := range files {
+		sort.SliceStable(f.branches, func(i, j int) bool {
+			return f.branches[i].line < f.branches[j].line
+		})
+	}
+	return nil
+}
+
+func branchesPerLine(branches []branchCover) map[int]lineBranches {
+	ret := make(map[int]lineBranches)
+	for _, b := range branches {
+		lb := ret[b.line]
+		lb.total += 2
+		for _, count := range []int{b.taken, b.notTaken} {
+			if count != 0 {
+				lb.taken++
+			}
+		}
+		ret[b.line] = lb
+	}
+	return ret
+}
+
 func contains(pcs []uint64, pc uint64) bool {
 	idx := sort.Search(len(pcs), func(i int) bool { return pcs[i] >= pc })
 	return idx < len(pcs) && pcs[idx] == pc
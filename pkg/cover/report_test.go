@@ -396,6 +396,16 @@ func generateReport(t *testing.T, target *targets.Target, test *Test) (*reports,
 	assert.NoError(t, rg.DoFuncCover(res.csv, params))
 	assert.NoError(t, rg.DoCoverJSONL(res.jsonl, params))
 	assert.NoError(t, rg.DoCoverPrograms(res.jsonlPrograms, params))
+	lineProgsParams := params
+	lineProgsParams.Progs = nil
+	for i, prog := range params.Progs {
+		prog.Sig = fmt.Sprint(i)
+		lineProgsParams.Progs = append(lineProgsParams.Progs, prog)
+	}
+	assert.NoError(t, rg.DoLinePrograms(new(bytes.Buffer), lineProgsParams))
+	assert.NoError(t, rg.DoLCOV(new(bytes.Buffer), params))
+	assert.NoError(t, rg.DoCobertura(new(bytes.Buffer), params))
+	assert.NoError(t, rg.DoLLVMCovJSON(new(bytes.Buffer), params))
 	return res, nil
 }
 
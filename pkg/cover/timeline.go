@@ -0,0 +1,322 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bufio"
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/osutil"
+)
+
+// Timeline remembers when each PC was covered for the first time.
+// It's persisted in an append-only file, so the times survive manager restarts:
+// coverage that is re-discovered after a restart keeps its original time.
+// PCs of different kernel builds are not comparable, so the file is bound to the kernel build
+// and the timeline starts from scratch when the kernel changes.
+//
+// The file starts with the kernel build hash (see TimelineBuild) followed by records of the form:
+// unix time (int64), number of PCs (uint32), PCs (uint64 each); all little-endian.
+type Timeline struct {
+	mu    sync.Mutex
+	file  *os.File
+	first map[uint64]uint32 // PC -> unix time
+}
+
+// TimelineBuild returns the hash that identifies the kernel build for the timeline.
+// It's based on the build tag if the manager has one, otherwise on the GNU build ID
+// of the kernel binary, or, if there is none, on the binary size and modification time.
+func TimelineBuild(cfg *mgrconfig.Config) (hash.Sig, error) {
+	if cfg.Tag != "" {
+		return hash.Hash([]byte(cfg.Tag)), nil
+	}
+	bin := filepath.Join(cfg.KernelObj, cfg.SysTarget.KernelObject)
+	if id := elfBuildID(bin); id != nil {
+		return hash.Hash(id), nil
+	}
+	stat, err := os.Stat(bin)
+	if err != nil {
+		return hash.Sig{}, fmt.Errorf("failed to stat the kernel binary: %w", err)
+	}
+	return hash.Hash([]byte(fmt.Sprintf("%v-%v", stat.Size(), stat.ModTime().UnixNano()))), nil
+}
+
+// elfBuildID returns the GNU build ID note of the ELF binary, or nil if it has none.
+func elfBuildID(bin string) []byte {
+	f, err := elf.Open(bin)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	for _, sec := range f.Sections {
+		if sec.Type != elf.SHT_NOTE {
+			continue
+		}
+		data, err := sec.Data()
+		if err != nil {
+			continue
+		}
+		if id := parseBuildIDNote(data, f.ByteOrder); id != nil {
+			return id
+		}
+	}
+	return nil
+}
+
+func parseBuildIDNote(data []byte, order binary.ByteOrder) []byte {
+	const ntGNUBuildID = 3
+	align := func(n uint32) int { return int((n + 3) &^ 3) }
+	for len(data) >= 12 {
+		nameSize, descSize, typ := order.Uint32(data), order.Uint32(data[4:]), order.Uint32(data[8:])
+		data = data[12:]
+		if align(nameSize) > len(data) || align(nameSize)+align(descSize) > len(data) {
+			return nil
+		}
+		name, desc := data[:nameSize], data[align(nameSize):align(nameSize)+int(descSize)]
+		if typ == ntGNUBuildID && bytes.Equal(name, []byte("GNU\x00")) {
+			return desc
+		}
+		data = data[align(nameSize)+align(descSize):]
+	}
+	return nil
+}
+
+// OpenTimeline loads the timeline of the kernel build from the file and opens it for appending.
+// If the file belongs to a different build, the timeline is reset.
+func OpenTimeline(filename string, build hash.Sig) (*Timeline, error) {
+	tl, size, err := readTimeline(filename, build)
+	if err != nil {
+		return nil, err
+	}
+	tl.file, err = os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, osutil.DefaultFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open coverage timeline: %w", err)
+	}
+	// Drop the partially written last record (if any), otherwise new records would be misaligned.
+	if err := tl.file.Truncate(size); err != nil {
+		tl.file.Close()
+		return nil, fmt.Errorf("failed to truncate coverage timeline: %w", err)
+	}
+	if size == 0 {
+		if _, err := tl.file.Write(build[:]); err != nil {
+			tl.file.Close()
+			return nil, fmt.Errorf("failed to write coverage timeline: %w", err)
+		}
+	}
+	return tl, nil
+}
+
+// ReadTimeline loads the timeline of the kernel build from the file for reading only.
+// A missing file is an empty timeline.
+func ReadTimeline(filename string, build hash.Sig) (*Timeline, error) {
+	tl, size, err := readTimeline(filename, build)
+	if err == nil && size == 0 && osutil.IsExist(filename) {
+		err = fmt.Errorf("coverage timeline %v belongs to a different kernel build", filename)
+	}
+	return tl, err
+}
+
+// readTimeline returns the size of the valid part of the file
+// (0 if the file does not exist or belongs to a different build).
+func readTimeline(filename string, build hash.Sig) (*Timeline, int64, error) {
+	tl := &Timeline{first: make(map[uint64]uint32)}
+	f, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return tl, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open coverage timeline: %w", err)
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to stat coverage timeline: %w", err)
+	}
+	r := bufio.NewReader(f)
+	var fileBuild hash.Sig
+	if _, err := io.ReadFull(r, fileBuild[:]); err != nil || fileBuild != build {
+		return tl, 0, nil
+	}
+	size, err := tl.load(r, stat.Size()-int64(len(build)))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read coverage timeline %v: %w", filename, err)
+	}
+	return tl, int64(len(build)) + size, nil
+}
+
+// load reads the records of the total size and returns the size of the complete records.
+func (tl *Timeline) load(r io.Reader, total int64) (int64, error) {
+	var hdr struct {
+		Time int64
+		N    uint32
+	}
+	const hdrSize, pcSize = 12, 8
+	size := int64(0)
+	for {
+		err := binary.Read(r, binary.LittleEndian, &hdr)
+		if err == nil && int64(hdr.N)*pcSize > total-size-hdrSize {
+			// Don't trust N if the file does not have that many PCs.
+			err = io.ErrUnexpectedEOF
+		}
+		if err == nil {
+			pcs := make([]uint64, hdr.N)
+			if err = binary.Read(r, binary.LittleEndian, pcs); err == nil {
+				for _, pc := range pcs {
+					if _, ok := tl.first[pc]; !ok {
+						tl.first[pc] = uint32(hdr.Time)
+					}
+				}
+				size += hdrSize + int64(len(pcs))*pcSize
+				continue
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			// The last record may be partially written if the manager crashed.
+			return size, nil
+		}
+		return 0, err
+	}
+}
+
+// Add records the PCs that are covered at the time, if they were not covered before.
+func (tl *Timeline) Add(pcs []uint64, now time.Time) error {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	var newPCs []uint64
+	for _, pc := range pcs {
+		if _, ok := tl.first[pc]; !ok {
+			tl.first[pc] = uint32(now.Unix())
+			newPCs = append(newPCs, pc)
+		}
+	}
+	if len(newPCs) == 0 || tl.file == nil {
+		return nil
+	}
+	buf := binary.LittleEndian.AppendUint64(nil, uint64(now.Unix()))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(newPCs)))
+	for _, pc := range newPCs {
+		buf = binary.LittleEndian.AppendUint64(buf, pc)
+	}
+	// A single write, so that a crash does not leave a corrupted record in the middle of the file.
+	if _, err := tl.file.Write(buf); err != nil {
+		return fmt.Errorf("failed to write coverage timeline: %w", err)
+	}
+	return nil
+}
+
+// Since returns the sorted PCs that were covered for the first time at or after the time.
+func (tl *Timeline) Since(since time.Time) []uint64 {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	var ret []uint64
+	for pc, t := range tl.first {
+		if int64(t) >= since.Unix() {
+			ret = append(ret, pc)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i] < ret[j]
+	})
+	return ret
+}
+
+// TimelineBucket is the number of PCs covered for the first time during [Start, Start+step).
+type TimelineBucket struct {
+	Start time.Time
+	PCs   int
+}
+
+// Buckets groups the PCs by the time they were covered for the first time, the most recent bucket first.
+func (tl *Timeline) Buckets(step time.Duration) []TimelineBucket {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	counts := make(map[int64]int)
+	for _, t := range tl.first {
+		counts[time.Unix(int64(t), 0).Truncate(step).Unix()]++
+	}
+	var ret []TimelineBucket
+	for start, n := range counts {
+		ret = append(ret, TimelineBucket{Start: time.Unix(start, 0), PCs: n})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Start.After(ret[j].Start)
+	})
+	return ret
+}
+
+// Close closes the file, but keeps the timeline in memory.
+func (tl *Timeline) Close() error {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	if tl.file == nil {
+		return nil
+	}
+	err := tl.file.Close()
+	tl.file = nil
+	return err
+}
+
+// FunctionCover describes when a function was covered for the first time.
+type FunctionCover struct {
+	Function     string
+	File         string
+	Module       string `json:",omitempty"`
+	FirstCovered time.Time
+	// Total number of PCs in the function and the number of PCs covered so far.
+	PCs        int
+	CoveredPCs int
+}
+
+// FunctionTimeline returns the covered functions sorted by the time they were covered for the first time,
+// the most recently covered first. A function is covered when any of its PCs is covered.
+func (rg *ReportGenerator) FunctionTimeline(tl *Timeline) []FunctionCover {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	var ret []FunctionCover
+	for _, s := range rg.Symbols {
+		var first uint32
+		covered := 0
+		for _, pc := range s.PCs {
+			if t, ok := tl.first[pc]; ok {
+				covered++
+				if first == 0 || t < first {
+					first = t
+				}
+			}
+		}
+		if covered == 0 {
+			continue
+		}
+		ret = append(ret, FunctionCover{
+			Function:     s.Name,
+			File:         s.Unit.Name,
+			Module:       s.Module.Name,
+			FirstCovered: time.Unix(int64(first), 0),
+			PCs:          len(s.PCs),
+			CoveredPCs:   covered,
+		})
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if !ret[i].FirstCovered.Equal(ret[j].FirstCovered) {
+			return ret[i].FirstCovered.After(ret[j].FirstCovered)
+		}
+		if ret[i].File != ret[j].File {
+			return ret[i].File < ret[j].File
+		}
+		return ret[i].Function < ret[j].Function
+	})
+	return ret
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/vminfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeline(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cover_timeline")
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	build := hash.Hash([]byte("build1"))
+	tl, err := OpenTimeline(filename, build)
+	assert.NoError(t, err)
+	assert.NoError(t, tl.Add([]uint64{0x10, 0x20}, start))
+	assert.NoError(t, tl.Add([]uint64{0x20, 0x30}, start.Add(time.Hour)))
+	assert.NoError(t, tl.Add([]uint64{0x10}, start.Add(2*time.Hour)))
+	assert.NoError(t, tl.Close())
+
+	// Simulate a crash in the middle of writing a record.
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0)
+	assert.NoError(t, err)
+	_, err = f.Write([]byte{1, 2, 3})
+	assert.NoError(t, err)
+	f.Close()
+
+	// Coverage re-discovered after a restart keeps the original time.
+	tl, err = OpenTimeline(filename, build)
+	assert.NoError(t, err)
+	assert.NoError(t, tl.Add([]uint64{0x10, 0x40}, start.Add(48*time.Hour)))
+	assert.NoError(t, tl.Close())
+
+	tl, err = ReadTimeline(filename, build)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0x10, 0x20, 0x30, 0x40}, tl.Since(start))
+	assert.Equal(t, []uint64{0x30, 0x40}, tl.Since(start.Add(time.Hour)))
+	assert.Equal(t, []uint64{0x40}, tl.Since(start.Add(2*time.Hour)))
+	assert.Equal(t, []TimelineBucket{
+		{Start: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), PCs: 1},
+		{Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), PCs: 3},
+	}, utcBuckets(tl.Buckets(24*time.Hour)))
+}
+
+func TestTimelineNewBuild(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cover_timeline")
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	build1 := hash.Hash([]byte("build1"))
+	build2 := hash.Hash([]byte("build2"))
+
+	tl, err := OpenTimeline(filename, build1)
+	assert.NoError(t, err)
+	assert.NoError(t, tl.Add([]uint64{0x10, 0x20}, start))
+	assert.NoError(t, tl.Close())
+
+	_, err = ReadTimeline(filename, build2)
+	assert.Error(t, err)
+
+	// The PCs of the old build mean nothing for the new one.
+	tl, err = OpenTimeline(filename, build2)
+	assert.NoError(t, err)
+	assert.Empty(t, tl.Since(time.Time{}))
+	assert.NoError(t, tl.Add([]uint64{0x20}, start.Add(time.Hour)))
+	assert.NoError(t, tl.Close())
+
+	tl, err = ReadTimeline(filename, build2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0x20}, tl.Since(start.Add(time.Hour)))
+}
+
+func utcBuckets(buckets []TimelineBucket) []TimelineBucket {
+	for i := range buckets {
+		buckets[i].Start = buckets[i].Start.UTC()
+	}
+	return buckets
+}
+
+func TestTimelineCorruptCount(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cover_timeline")
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	build := hash.Hash([]byte("build1"))
+	tl, err := OpenTimeline(filename, build)
+	assert.NoError(t, err)
+	assert.NoError(t, tl.Add([]uint64{0x10}, start))
+	assert.NoError(t, tl.Close())
+
+	// A record that claims more PCs than the file has must not be allocated.
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0)
+	assert.NoError(t, err)
+	hdr := binary.LittleEndian.AppendUint64(nil, uint64(start.Unix()))
+	hdr = binary.LittleEndian.AppendUint32(hdr, math.MaxUint32)
+	_, err = f.Write(append(hdr, 1, 2, 3, 4, 5, 6, 7, 8))
+	assert.NoError(t, err)
+	f.Close()
+
+	tl, err = OpenTimeline(filename, build)
+	assert.NoError(t, err)
+	assert.NoError(t, tl.Add([]uint64{0x20}, start.Add(time.Hour)))
+	assert.NoError(t, tl.Close())
+
+	tl, err = ReadTimeline(filename, build)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0x10, 0x20}, tl.Since(start))
+}
+
+func TestParseBuildIDNote(t *testing.T) {
+	var notes []byte
+	addNote := func(name string, typ uint32, desc []byte) {
+		notes = binary.LittleEndian.AppendUint32(notes, uint32(len(name)))
+		notes = binary.LittleEndian.AppendUint32(notes, uint32(len(desc)))
+		notes = binary.LittleEndian.AppendUint32(notes, typ)
+		notes = append(notes, name...)
+		notes = append(notes, make([]byte, (4-len(name)%4)%4)...)
+		notes = append(notes, desc...)
+		notes = append(notes, make([]byte, (4-len(desc)%4)%4)...)
+	}
+	addNote("Xen\x00", 3, []byte{1, 2, 3, 4})
+	addNote("GNU\x00", 1, []byte{5, 6, 7, 8})
+	addNote("GNU\x00", 3, []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee})
+	assert.Equal(t, []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee}, parseBuildIDNote(notes, binary.LittleEndian))
+	assert.Nil(t, parseBuildIDNote(notes[:len(notes)-4], binary.LittleEndian))
+}
+
+func TestReadMissingTimeline(t *testing.T) {
+	tl, err := ReadTimeline(filepath.Join(t.TempDir(), "cover_timeline"), hash.Sig{})
+	assert.NoError(t, err)
+	assert.Empty(t, tl.Since(time.Time{}))
+}
+
+func TestFunctionTimeline(t *testing.T) {
+	mod := &vminfo.KernelModule{}
+	unit := &backend.CompileUnit{ObjectUnit: backend.ObjectUnit{Name: "kernel/foo.c"}}
+	rg := &ReportGenerator{
+		Impl: &backend.Impl{
+			Symbols: []*backend.Symbol{
+				{ObjectUnit: backend.ObjectUnit{Name: "foo", PCs: []uint64{0x10, 0x11, 0x12}}, Module: mod, Unit: unit},
+				{ObjectUnit: backend.ObjectUnit{Name: "bar", PCs: []uint64{0x20, 0x21}}, Module: mod, Unit: unit},
+				{ObjectUnit: backend.ObjectUnit{Name: "baz", PCs: []uint64{0x30}}, Module: mod, Unit: unit},
+			},
+		},
+	}
+	start := time.Unix(1735725600, 0)
+	tl, err := ReadTimeline(filepath.Join(t.TempDir(), "cover_timeline"), hash.Sig{})
+	assert.NoError(t, err)
+	assert.NoError(t, tl.Add([]uint64{0x11}, start))
+	assert.NoError(t, tl.Add([]uint64{0x10, 0x20}, start.Add(time.Hour)))
+	assert.Equal(t, []FunctionCover{
+		{Function: "bar", File: "kernel/foo.c", FirstCovered: start.Add(time.Hour), PCs: 2, CoveredPCs: 1},
+		{Function: "foo", File: "kernel/foo.c", FirstCovered: start, PCs: 3, CoveredPCs: 2},
+	}, rg.FunctionTimeline(tl))
+}
@@ -29,7 +29,7 @@ func makeFileLineCoverMerger(fvs FileVersions, base RepoCommit) FileCoverageMerg
 		lostFrames: map[RepoCommit]int64{},
 	}
 	for repoBranch, fv := range fvs {
-		a.matchers[repoBranch] = makeLineToLineMatcher(fv, baseFile)
+		a.matchers[repoBranch] = MakeLineToLineMatcher(fv, baseFile)
 	}
 	return a
 }
@@ -9,7 +9,8 @@ import (
 	dmp "github.com/sergi/go-diff/diffmatchpatch"
 )
 
-func makeLineToLineMatcher(textFrom, textTo string) *LineToLineMatcher {
+// MakeLineToLineMatcher matches lines of two versions of a source file.
+func MakeLineToLineMatcher(textFrom, textTo string) *LineToLineMatcher {
 	diffMatcher := dmp.New()
 	diffMatcher.DiffTimeout = 0
 	diffs := diffMatcher.DiffMain(textFrom, textTo, false)
@@ -43,6 +44,7 @@ type LineToLineMatcher struct {
 	lineToLine []int
 }
 
+// SameLinePos returns the position of the textFrom line in textTo, or -1 if the line was changed or removed.
 func (lm *LineToLineMatcher) SameLinePos(line int) int {
 	return lm.lineToLine[line]
 }
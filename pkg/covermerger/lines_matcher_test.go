@@ -64,7 +64,7 @@ func TestMatching(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			m := makeLineToLineMatcher(test.textFrom, test.textTo)
+			m := MakeLineToLineMatcher(test.textFrom, test.textTo)
 			assert.NotNil(t, m)
 			got := m.SameLinePos(test.lineFrom)
 			if got != test.lineTo {
@@ -0,0 +1,462 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package dashlocal implements a self-contained stand-in for the App Engine dashboard
+// (dashboard/app) that serves the dashapi API from a local file store.
+// It allows to run syz-ci and syz-manager fully on-premises without sending anything
+// to a cloud service. Only the parts of the dashboard workflow that make sense for
+// a single deployment are supported: bug deduplication, reproduction requests,
+// cause bisection and patch testing jobs, and a single reporting stage that can be
+// consumed by external tools. There is also a simple web UI for bugs, crashes and reproducers.
+package dashlocal
+
+import (
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/osutil"
+)
+
+type Config struct {
+	// Name of the deployment shown in the UI and used as the namespace in bug reports.
+	Name string `json:"name"`
+	// Externally visible URL of the dashboard, used for links in bug reports.
+	URL string `json:"url"`
+	// Clients maps client names to keys (dashboard_client/dashboard_key in syz-ci/syz-manager configs).
+	// At least one client is required.
+	Clients map[string]string `json:"clients"`
+	// Users maps user names to passwords for the web UI (HTTP basic auth).
+	// If empty, the web UI is disabled.
+	Users map[string]string `json:"users"`
+	// Request cause bisection for bugs with reproducers (needs bisect_bin in syz-ci config).
+	BisectCause bool `json:"bisect_cause"`
+}
+
+type Dashboard struct {
+	cfg *Config
+	dir string
+	mu  sync.Mutex
+	st  state
+	// The key for CSRF tokens of the web UI forms.
+	csrfKey []byte
+	// Overridable for testing.
+	now func() time.Time
+}
+
+const (
+	maxReproPerBug   = 10
+	reproRetryPeriod = 24 * time.Hour
+	reproStalePeriod = 100 * 24 * time.Hour
+
+	corruptedReportTitle  = "corrupted report"
+	suppressedReportTitle = "suppressed report"
+)
+
+var hashRe = regexp.MustCompile("^[0-9a-f]{40}$")
+
+// New loads the dashboard state from dir (creates an empty one if dir is empty).
+func New(cfg *Config, dir string) (*Dashboard, error) {
+	if cfg.Name == "" {
+		cfg.Name = "local"
+	}
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	if len(cfg.Clients) == 0 {
+		return nil, fmt.Errorf("no clients are configured")
+	}
+	if err := osutil.MkdirAll(blobDir(dir)); err != nil {
+		return nil, err
+	}
+	csrfKey := make([]byte, 32)
+	if _, err := rand.Read(csrfKey); err != nil {
+		return nil, err
+	}
+	dash := &Dashboard{
+		cfg:     cfg,
+		dir:     dir,
+		csrfKey: csrfKey,
+		now:     time.Now,
+	}
+	if err := dash.st.load(dir); err != nil {
+		return nil, err
+	}
+	if err := gcBlobs(dir, dash.st.blobs()); err != nil {
+		return nil, err
+	}
+	log.Logf(0, "loaded %v bugs, %v builds, %v jobs", len(dash.st.Bugs), len(dash.st.Builds), len(dash.st.Jobs))
+	return dash, nil
+}
+
+// Handler returns the handler that serves both the API and the web UI.
+func (dash *Dashboard) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api", dash.httpAPI)
+	dash.initUI(mux)
+	return mux
+}
+
+type apiHandler func(dash *Dashboard, payload io.Reader) (interface{}, error)
+
+var apiHandlers = map[string]apiHandler{
+	"log_error":             (*Dashboard).apiLogError,
+	"upload_build":          (*Dashboard).apiUploadBuild,
+	"builder_poll":          (*Dashboard).apiBuilderPoll,
+	"report_build_error":    (*Dashboard).apiReportBuildError,
+	"report_crash":          (*Dashboard).apiReportCrash,
+	"report_failed_repro":   (*Dashboard).apiReportFailedRepro,
+	"need_repro":            (*Dashboard).apiNeedRepro,
+	"log_to_repro":          (*Dashboard).apiLogToRepro,
+	"manager_stats":         (*Dashboard).apiManagerStats,
+	"commit_poll":           (*Dashboard).apiCommitPoll,
+	"upload_commits":        (*Dashboard).apiUploadCommits,
+	"add_build_assets":      (*Dashboard).apiAddBuildAssets,
+	"needed_assets":         (*Dashboard).apiNeededAssets,
+	"job_poll":              (*Dashboard).apiJobPoll,
+	"job_reset":             (*Dashboard).apiJobReset,
+	"job_done":              (*Dashboard).apiJobDone,
+	"reporting_poll_bugs":   (*Dashboard).apiReportingPollBugs,
+	"reporting_poll_notifs": (*Dashboard).apiReportingPollNotifications,
+	"reporting_poll_closed": (*Dashboard).apiReportingPollClosed,
+	"reporting_update":      (*Dashboard).apiReportingUpdate,
+	"bug_list":              (*Dashboard).apiBugList,
+	"load_bug":              (*Dashboard).apiLoadBug,
+}
+
+// readOnlyMethods don't change the state, so we don't need to save it after them.
+var readOnlyMethods = map[string]bool{
+	"log_error":             true,
+	"builder_poll":          true,
+	"need_repro":            true,
+	"log_to_repro":          true,
+	"commit_poll":           true,
+	"upload_commits":        true,
+	"needed_assets":         true,
+	"reporting_poll_bugs":   true,
+	"reporting_poll_notifs": true,
+	"reporting_poll_closed": true,
+	"bug_list":              true,
+	"load_bug":              true,
+}
+
+func (dash *Dashboard) httpAPI(w http.ResponseWriter, r *http.Request) {
+	reply, err := dash.handleAPI(r)
+	if err != nil {
+		log.Logf(0, "api %q from %q failed: %v", r.PostFormValue("method"), r.PostFormValue("client"), err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(reply); err != nil {
+		log.Logf(0, "failed to encode reply: %v", err)
+	}
+}
+
+func (dash *Dashboard) handleAPI(r *http.Request) (interface{}, error) {
+	client := r.PostFormValue("client")
+	method := r.PostFormValue("method")
+	log.Logf(1, "api %q from %q", method, client)
+	if client == "" {
+		return nil, fmt.Errorf("client is empty")
+	}
+	key, ok := dash.cfg.Clients[client]
+	if !ok || subtle.ConstantTimeCompare([]byte(key), []byte(r.PostFormValue("key"))) != 1 {
+		return nil, fmt.Errorf("unauthorized client %q", client)
+	}
+	handler := apiHandlers[method]
+	if handler == nil {
+		return nil, fmt.Errorf("unknown api method %q", method)
+	}
+	var payload io.Reader = strings.NewReader("null")
+	if str := r.PostFormValue("payload"); str != "" {
+		gr, err := gzip.NewReader(strings.NewReader(str))
+		if err != nil {
+			return nil, fmt.Errorf("failed to ungzip payload: %w", err)
+		}
+		defer gr.Close()
+		payload = gr
+	}
+	dash.mu.Lock()
+	defer dash.mu.Unlock()
+	reply, err := handler(dash, payload)
+	if err != nil {
+		return nil, fmt.Errorf("method %q: %w", method, err)
+	}
+	if readOnlyMethods[method] {
+		return reply, nil
+	}
+	if err := dash.st.save(dash.dir); err != nil {
+		return nil, fmt.Errorf("failed to save state: %w", err)
+	}
+	return reply, nil
+}
+
+func decode[T any](payload io.Reader) (*T, error) {
+	req := new(T)
+	if err := json.NewDecoder(payload).Decode(req); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request: %w", err)
+	}
+	return req, nil
+}
+
+func (dash *Dashboard) apiLogError(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.LogEntry](payload)
+	if err != nil {
+		return nil, err
+	}
+	log.Logf(0, "%v: %v", req.Name, req.Text)
+	return nil, nil
+}
+
+func (dash *Dashboard) apiUploadBuild(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.Build](payload)
+	if err != nil {
+		return nil, err
+	}
+	return nil, dash.uploadBuild(req)
+}
+
+func (dash *Dashboard) uploadBuild(req *dashapi.Build) error {
+	if req.ID == "" {
+		return fmt.Errorf("empty build ID")
+	}
+	if dash.st.Builds[req.ID] != nil {
+		return nil
+	}
+	config, err := putBlob(dash.dir, req.KernelConfig)
+	if err != nil {
+		return err
+	}
+	req.KernelConfig = nil
+	dash.st.Builds[req.ID] = &Build{
+		Build:        req,
+		KernelConfig: config,
+		Time:         dash.now(),
+	}
+	return nil
+}
+
+func (dash *Dashboard) apiBuilderPoll(payload io.Reader) (interface{}, error) {
+	// We don't track fixing commits, so there is nothing pending.
+	return &dashapi.BuilderPollResp{}, nil
+}
+
+func (dash *Dashboard) apiCommitPoll(payload io.Reader) (interface{}, error) {
+	return &dashapi.CommitPollResp{}, nil
+}
+
+func (dash *Dashboard) apiUploadCommits(payload io.Reader) (interface{}, error) {
+	return nil, nil
+}
+
+func (dash *Dashboard) apiReportBuildError(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.BuildErrorReq](payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := dash.uploadBuild(&req.Build); err != nil {
+		return nil, err
+	}
+	req.Crash.BuildID = req.Build.ID
+	_, err = dash.reportCrash(&req.Crash)
+	return nil, err
+}
+
+func (dash *Dashboard) apiReportCrash(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.Crash](payload)
+	if err != nil {
+		return nil, err
+	}
+	bug, err := dash.reportCrash(req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dashapi.ReportCrashResp{
+		NeedRepro: len(req.ReproSyz) == 0 && dash.needRepro(bug),
+	}
+	return resp, nil
+}
+
+func (dash *Dashboard) reportCrash(req *dashapi.Crash) (*Bug, error) {
+	build := dash.st.Builds[req.BuildID]
+	if build == nil {
+		return nil, fmt.Errorf("unknown build %q", req.BuildID)
+	}
+	now := dash.now()
+	req.Title = canonicalizeCrashTitle(req.Title, req.Corrupted, req.Suppressed)
+	bug := dash.st.findBug(append([]string{req.Title}, req.AltTitles...))
+	if bug == nil {
+		bug = dash.st.createBug(req.Title, now)
+	}
+	crash := &Crash{
+		ID:               dash.st.NextCrashID,
+		BuildID:          req.BuildID,
+		Manager:          build.Build.Manager,
+		Title:            req.Title,
+		Time:             now,
+		ReproReliability: req.ReproReliability,
+	}
+	dash.st.NextCrashID++
+	blobs := []struct {
+		id   *string
+		data []byte
+	}{
+		{&crash.Log, req.Log},
+		{&crash.Report, req.Report},
+		{&crash.MachineInfo, req.MachineInfo},
+		{&crash.ReproOpts, req.ReproOpts},
+		{&crash.ReproSyz, req.ReproSyz},
+		{&crash.ReproC, req.ReproC},
+		{&crash.ReproLog, req.ReproLog},
+	}
+	for _, blob := range blobs {
+		var err error
+		if *blob.id, err = putBlob(dash.dir, blob.data); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.ReproC) != 0 {
+		crash.ReproLevel = dashapi.ReproLevelC
+	} else if len(req.ReproSyz) != 0 {
+		crash.ReproLevel = dashapi.ReproLevelSyz
+	}
+	if crash.ReproLevel != dashapi.ReproLevelNone {
+		bug.NumRepro++
+		bug.LastReproTime = now
+		bug.ReproLevel = max(bug.ReproLevel, crash.ReproLevel)
+	}
+	bug.addCrash(crash)
+	bug.NumCrashes++
+	bug.LastTime = now
+	if !stringInList(bug.Managers, crash.Manager) {
+		bug.Managers = append(bug.Managers, crash.Manager)
+	}
+	if dash.cfg.BisectCause && crash.ReproLevel != dashapi.ReproLevelNone && bug.BisectCauseJob == "" {
+		job := dash.createJob(dashapi.JobBisectCause, bug, crash)
+		bug.BisectCauseJob = job.ID
+	}
+	return bug, nil
+}
+
+func canonicalizeCrashTitle(title string, corrupted, suppressed bool) string {
+	if corrupted {
+		return corruptedReportTitle
+	}
+	if suppressed {
+		return suppressedReportTitle
+	}
+	return title
+}
+
+func (dash *Dashboard) needRepro(bug *Bug) bool {
+	if bug.Title == corruptedReportTitle || bug.Title == suppressedReportTitle ||
+		bug.Status != dashapi.BugStatusOpen {
+		return false
+	}
+	if bug.ReproLevel < dashapi.ReproLevelC {
+		return bug.NumRepro < maxReproPerBug || dash.now().Sub(bug.LastReproTime) >= reproRetryPeriod
+	}
+	return dash.now().Sub(bug.LastReproTime) >= reproStalePeriod
+}
+
+func (dash *Dashboard) apiNeedRepro(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.CrashID](payload)
+	if err != nil {
+		return nil, err
+	}
+	if req.Corrupted {
+		return &dashapi.NeedReproResp{}, nil
+	}
+	bug := dash.st.findBug([]string{canonicalizeCrashTitle(req.Title, req.Corrupted, req.Suppressed)})
+	if bug == nil {
+		if req.MayBeMissing {
+			return &dashapi.NeedReproResp{NeedRepro: true}, nil
+		}
+		return nil, fmt.Errorf("can't find bug for crash %q", req.Title)
+	}
+	return &dashapi.NeedReproResp{NeedRepro: dash.needRepro(bug)}, nil
+}
+
+func (dash *Dashboard) apiReportFailedRepro(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.CrashID](payload)
+	if err != nil {
+		return nil, err
+	}
+	bug := dash.st.findBug([]string{canonicalizeCrashTitle(req.Title, req.Corrupted, req.Suppressed)})
+	if bug == nil {
+		return nil, fmt.Errorf("can't find bug for crash %q", req.Title)
+	}
+	bug.NumRepro++
+	bug.LastReproTime = dash.now()
+	return nil, nil
+}
+
+func (dash *Dashboard) apiLogToRepro(payload io.Reader) (interface{}, error) {
+	// Reproduction of older crash logs is requested only manually on the App Engine dashboard.
+	return &dashapi.LogToReproResp{}, nil
+}
+
+func (dash *Dashboard) apiManagerStats(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.ManagerStatsReq](payload)
+	if err != nil {
+		return nil, err
+	}
+	mgr := dash.st.Managers[req.Name]
+	if mgr == nil {
+		mgr = &Manager{Name: req.Name}
+		dash.st.Managers[req.Name] = mgr
+	}
+	mgr.Addr = req.Addr
+	mgr.LastActive = dash.now()
+	mgr.UpTime = req.UpTime
+	mgr.Corpus = req.Corpus
+	mgr.PCs = req.PCs
+	mgr.Cover = req.Cover
+	mgr.CrashTypes = req.CrashTypes
+	mgr.FuzzingTime += req.FuzzingTime
+	mgr.Crashes += req.Crashes
+	mgr.Execs += req.Execs
+	return nil, nil
+}
+
+func (dash *Dashboard) apiAddBuildAssets(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.AddBuildAssetsReq](payload)
+	if err != nil {
+		return nil, err
+	}
+	build := dash.st.Builds[req.BuildID]
+	if build == nil {
+		return nil, fmt.Errorf("unknown build %q", req.BuildID)
+	}
+	build.Build.Assets = append(build.Build.Assets, req.Assets...)
+	return nil, nil
+}
+
+func (dash *Dashboard) apiNeededAssets(payload io.Reader) (interface{}, error) {
+	// We never expire assets, so all of them are needed.
+	resp := new(dashapi.NeededAssetsResp)
+	for _, build := range dash.st.Builds {
+		for _, asset := range build.Build.Assets {
+			resp.DownloadURLs = append(resp.DownloadURLs, asset.DownloadURL)
+		}
+	}
+	return resp, nil
+}
+
+func stringInList(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
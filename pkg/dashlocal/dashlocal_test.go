@@ -0,0 +1,380 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package dashlocal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnv struct {
+	t    *testing.T
+	dir  string
+	cfg  *Config
+	dash *Dashboard
+	srv  *httptest.Server
+	api  *dashapi.Dashboard
+	now  time.Time
+}
+
+func newTestEnv(t *testing.T, cfg *Config) *testEnv {
+	env := &testEnv{
+		t:   t,
+		dir: t.TempDir(),
+		cfg: cfg,
+		now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	env.restart()
+	return env
+}
+
+// restart re-creates the dashboard from the state on disk.
+func (env *testEnv) restart() {
+	if env.srv != nil {
+		env.srv.Close()
+	}
+	dash, err := New(env.cfg, env.dir)
+	require.NoError(env.t, err)
+	dash.now = func() time.Time { return env.now }
+	env.dash = dash
+	env.srv = httptest.NewServer(dash.Handler())
+	env.t.Cleanup(env.srv.Close)
+	env.api, err = dashapi.New("ci", env.srv.URL, "secret")
+	require.NoError(env.t, err)
+}
+
+func testBuild(id string) *dashapi.Build {
+	return &dashapi.Build{
+		Manager:         "ci-upstream",
+		ID:              id,
+		OS:              "linux",
+		Arch:            "amd64",
+		VMArch:          "amd64",
+		KernelRepo:      "git://repo",
+		KernelBranch:    "master",
+		KernelCommit:    "1111111111111111111111111111111111111111",
+		SyzkallerCommit: "2222222222222222222222222222222222222222",
+		KernelConfig:    []byte("CONFIG_KASAN=y"),
+	}
+}
+
+func testCrash(buildID, title string) *dashapi.Crash {
+	return &dashapi.Crash{
+		BuildID: buildID,
+		Title:   title,
+		Log:     []byte("log of " + title),
+		Report:  []byte("report of " + title),
+	}
+}
+
+func TestCrashes(t *testing.T) {
+	env := newTestEnv(t, &Config{Clients: map[string]string{"ci": "secret"}})
+	require.NoError(t, env.api.UploadBuild(testBuild("build1")))
+
+	resp, err := env.api.ReportCrash(testCrash("build1", "WARNING in foo"))
+	require.NoError(t, err)
+	assert.True(t, resp.NeedRepro)
+	resp, err = env.api.ReportCrash(testCrash("build1", "WARNING in foo"))
+	require.NoError(t, err)
+	assert.True(t, resp.NeedRepro)
+
+	need, err := env.api.NeedRepro(&dashapi.CrashID{BuildID: "build1", Title: "WARNING in foo"})
+	require.NoError(t, err)
+	assert.True(t, need)
+	need, err = env.api.NeedRepro(&dashapi.CrashID{BuildID: "build1", Title: "WARNING in foo", Corrupted: true})
+	require.NoError(t, err)
+	assert.False(t, need)
+	_, err = env.api.NeedRepro(&dashapi.CrashID{BuildID: "build1", Title: "WARNING in bar"})
+	assert.Error(t, err)
+	need, err = env.api.NeedRepro(&dashapi.CrashID{BuildID: "build1", Title: "WARNING in bar", MayBeMissing: true})
+	require.NoError(t, err)
+	assert.True(t, need)
+
+	for i := 0; i < maxReproPerBug; i++ {
+		require.NoError(t, env.api.ReportFailedRepro(&dashapi.CrashID{BuildID: "build1", Title: "WARNING in foo"}))
+	}
+	need, err = env.api.NeedRepro(&dashapi.CrashID{BuildID: "build1", Title: "WARNING in foo"})
+	require.NoError(t, err)
+	assert.False(t, need)
+	env.now = env.now.Add(reproRetryPeriod)
+	need, err = env.api.NeedRepro(&dashapi.CrashID{BuildID: "build1", Title: "WARNING in foo"})
+	require.NoError(t, err)
+	assert.True(t, need)
+
+	crash := testCrash("build1", "WARNING in foo")
+	crash.ReproOpts = []byte("{}")
+	crash.ReproSyz = []byte("getpid()")
+	crash.ReproC = []byte("int main() {}")
+	resp, err = env.api.ReportCrash(crash)
+	require.NoError(t, err)
+	assert.False(t, resp.NeedRepro)
+	need, err = env.api.NeedRepro(&dashapi.CrashID{BuildID: "build1", Title: "WARNING in foo"})
+	require.NoError(t, err)
+	assert.False(t, need)
+
+	_, err = env.api.ReportCrash(testCrash("build2", "WARNING in foo"))
+	assert.Error(t, err, "unknown build")
+
+	env.restart()
+	bugs, err := env.api.BugList()
+	require.NoError(t, err)
+	require.Len(t, bugs.List, 1)
+	rep, err := env.api.LoadBug(bugs.List[0])
+	require.NoError(t, err)
+	assert.Equal(t, "WARNING in foo", rep.Title)
+	assert.Equal(t, int64(3), rep.NumCrashes)
+	assert.Equal(t, []byte("CONFIG_KASAN=y"), rep.KernelConfig)
+	assert.Equal(t, []byte("getpid()"), rep.ReproSyz)
+	assert.Equal(t, []byte("int main() {}"), rep.ReproC)
+	assert.Equal(t, []byte("report of WARNING in foo"), rep.Report)
+	assert.Equal(t, []string{"ci-upstream"}, rep.HappenedOn)
+
+	unauthorized, err := dashapi.New("ci", env.srv.URL, "wrong")
+	require.NoError(t, err)
+	_, err = unauthorized.BugList()
+	assert.Error(t, err)
+}
+
+func TestReporting(t *testing.T) {
+	env := newTestEnv(t, &Config{
+		URL:     "http://dashboard/",
+		Clients: map[string]string{"ci": "secret"},
+	})
+	require.NoError(t, env.api.UploadBuild(testBuild("build1")))
+	_, err := env.api.ReportCrash(testCrash("build1", "WARNING in foo"))
+	require.NoError(t, err)
+	_, err = env.api.ReportCrash(testCrash("build1", "corrupted"))
+	require.NoError(t, err)
+	crash := testCrash("build1", "title")
+	crash.Corrupted = true
+	_, err = env.api.ReportCrash(crash)
+	require.NoError(t, err)
+
+	polled, err := env.api.ReportingPollBugs("email")
+	require.NoError(t, err)
+	require.Len(t, polled.Reports, 2)
+	rep := polled.Reports[0]
+	assert.Equal(t, "WARNING in foo", rep.Title)
+	assert.Equal(t, dashapi.ReportNew, rep.Type)
+	assert.True(t, rep.First)
+	assert.Equal(t, "local", rep.Namespace)
+	assert.Equal(t, "http://dashboard/bug?id="+rep.ID, rep.Link)
+	assert.True(t, strings.HasPrefix(rep.LogLink, "http://dashboard/text?id="))
+	assert.Equal(t, []byte("log of WARNING in foo"), rep.Log)
+
+	reply, err := env.api.ReportingUpdate(&dashapi.BugUpdate{
+		ID:     rep.ID,
+		ExtID:  "ext-id",
+		Status: dashapi.BugStatusOpen,
+	})
+	require.NoError(t, err)
+	assert.True(t, reply.OK, reply.Text)
+	otherID := polled.Reports[1].ID
+	reply, err = env.api.ReportingUpdate(&dashapi.BugUpdate{
+		ID:     otherID,
+		Status: dashapi.BugStatusOpen,
+	})
+	require.NoError(t, err)
+	assert.True(t, reply.OK, reply.Text)
+	polled, err = env.api.ReportingPollBugs("email")
+	require.NoError(t, err)
+	assert.Len(t, polled.Reports, 0)
+
+	// A reproducer is reported separately.
+	crash = testCrash("build1", "WARNING in foo")
+	crash.ReproSyz = []byte("getpid()")
+	_, err = env.api.ReportCrash(crash)
+	require.NoError(t, err)
+	polled, err = env.api.ReportingPollBugs("email")
+	require.NoError(t, err)
+	require.Len(t, polled.Reports, 1)
+	assert.Equal(t, dashapi.ReportRepro, polled.Reports[0].Type)
+	assert.Equal(t, "ext-id", polled.Reports[0].ExtID)
+	reply, err = env.api.ReportingUpdate(&dashapi.BugUpdate{
+		ID:         rep.ID,
+		Status:     dashapi.BugStatusOpen,
+		ReproLevel: dashapi.ReproLevelSyz,
+	})
+	require.NoError(t, err)
+	assert.True(t, reply.OK, reply.Text)
+	polled, err = env.api.ReportingPollBugs("email")
+	require.NoError(t, err)
+	assert.Len(t, polled.Reports, 0)
+
+	// Once the bug is fixed, the same crash creates a new bug.
+	reply, err = env.api.ReportingUpdate(&dashapi.BugUpdate{
+		ID:         rep.ID,
+		Status:     dashapi.BugStatusOpen,
+		FixCommits: []string{"foo: fix the warning"},
+	})
+	require.NoError(t, err)
+	assert.True(t, reply.OK, reply.Text)
+	closed, err := env.api.ReportingPollClosed([]string{rep.ID, otherID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{rep.ID}, closed)
+	_, err = env.api.ReportCrash(testCrash("build1", "WARNING in foo"))
+	require.NoError(t, err)
+	polled, err = env.api.ReportingPollBugs("email")
+	require.NoError(t, err)
+	require.Len(t, polled.Reports, 1)
+	assert.NotEqual(t, rep.ID, polled.Reports[0].ID)
+	assert.Equal(t, dashapi.ReportNew, polled.Reports[0].Type)
+}
+
+func TestJobs(t *testing.T) {
+	env := newTestEnv(t, &Config{
+		Clients:     map[string]string{"ci": "secret"},
+		Users:       map[string]string{"admin": "password"},
+		BisectCause: true,
+	})
+	require.NoError(t, env.api.UploadBuild(testBuild("build1")))
+	crash := testCrash("build1", "WARNING in foo")
+	crash.ReproOpts = []byte("{}")
+	crash.ReproSyz = []byte("getpid()")
+	crash.ReproReliability = 0.5
+	_, err := env.api.ReportCrash(crash)
+	require.NoError(t, err)
+
+	managers := map[string]dashapi.ManagerJobs{
+		"ci-upstream": {TestPatches: true},
+	}
+	job, err := env.api.JobPoll(&dashapi.JobPollReq{Managers: managers})
+	require.NoError(t, err)
+	assert.Empty(t, job.ID)
+
+	managers["ci-upstream"] = dashapi.ManagerJobs{TestPatches: true, BisectCause: true}
+	job, err = env.api.JobPoll(&dashapi.JobPollReq{Managers: managers})
+	require.NoError(t, err)
+	assert.Equal(t, &dashapi.JobPollResp{
+		ID:               job.ID,
+		Type:             dashapi.JobBisectCause,
+		Manager:          "ci-upstream",
+		KernelRepo:       "git://repo",
+		KernelBranch:     "master",
+		KernelCommit:     "1111111111111111111111111111111111111111",
+		KernelConfig:     []byte("CONFIG_KASAN=y"),
+		SyzkallerCommit:  "2222222222222222222222222222222222222222",
+		ReproOpts:        []byte("{}"),
+		ReproSyz:         []byte("getpid()"),
+		ReproReliability: 0.5,
+	}, job)
+
+	// The job is restarted after a reset.
+	again, err := env.api.JobPoll(&dashapi.JobPollReq{Managers: managers})
+	require.NoError(t, err)
+	assert.Empty(t, again.ID)
+	require.NoError(t, env.api.JobReset(&dashapi.JobResetReq{Managers: []string{"ci-upstream"}}))
+	again, err = env.api.JobPoll(&dashapi.JobPollReq{Managers: managers})
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, again.ID)
+
+	require.NoError(t, env.api.JobDone(&dashapi.JobDoneReq{
+		ID:         job.ID,
+		Log:        []byte("bisect log"),
+		Commits:    []dashapi.Commit{{Hash: "3333333333333333333333333333333333333333", Title: "foo: add bar"}},
+		Confidence: 0.9,
+	}))
+	assert.Error(t, env.api.JobDone(&dashapi.JobDoneReq{ID: job.ID}))
+	bugs, err := env.api.BugList()
+	require.NoError(t, err)
+	rep, err := env.api.LoadBug(bugs.List[0])
+	require.NoError(t, err)
+	require.NotNil(t, rep.BisectCause)
+	require.NotNil(t, rep.BisectCause.Commit)
+	assert.Equal(t, "foo: add bar", rep.BisectCause.Commit.Title)
+	assert.Equal(t, 0.9, rep.BisectCause.Confidence)
+
+	// Patch testing requested from the web UI.
+	form := url.Values{
+		"id":     {rep.ID},
+		"action": {"test"},
+		"patch":  {"diff --git a/foo.c b/foo.c"},
+		"csrf":   {env.dash.csrfToken("admin")},
+	}
+	assert.Equal(t, http.StatusFound, env.uiAction("admin", "password", form))
+	job, err = env.api.JobPoll(&dashapi.JobPollReq{Managers: managers})
+	require.NoError(t, err)
+	assert.Equal(t, dashapi.JobTestPatch, job.Type)
+	assert.Equal(t, "master", job.KernelBranch)
+	assert.Equal(t, []byte("diff --git a/foo.c b/foo.c"), job.Patch)
+
+	reportBlob := rep.ReportLink[strings.LastIndex(rep.ReportLink, "=")+1:]
+	for _, page := range []string{"/", "/?status=fixed", "/bug?id=" + rep.ID, "/text?id=" + reportBlob} {
+		req, err := http.NewRequest(http.MethodGet, env.srv.URL+page, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth("admin", "password")
+		httpResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, httpResp.StatusCode, "%v: %s", page, body)
+	}
+}
+
+func TestUIAuth(t *testing.T) {
+	env := newTestEnv(t, &Config{
+		Clients: map[string]string{"ci": "secret"},
+		Users:   map[string]string{"admin": "password"},
+	})
+	require.NoError(t, env.api.UploadBuild(testBuild("build1")))
+	_, err := env.api.ReportCrash(testCrash("build1", "WARNING in foo"))
+	require.NoError(t, err)
+	require.Len(t, env.dash.st.Bugs, 1)
+	bug := env.dash.st.Bugs[0]
+	bugID := bug.ID
+	form := url.Values{
+		"id":     {bugID},
+		"action": {"invalid"},
+		"csrf":   {env.dash.csrfToken("admin")},
+	}
+	assert.Equal(t, http.StatusUnauthorized, env.uiAction("", "", form))
+	assert.Equal(t, http.StatusUnauthorized, env.uiAction("admin", "wrong", form))
+	assert.Equal(t, http.StatusUnauthorized, env.uiAction("other", "password", form))
+
+	noToken := url.Values{"id": {bugID}, "action": {"invalid"}}
+	assert.Equal(t, http.StatusForbidden, env.uiAction("admin", "password", noToken))
+	badToken := url.Values{"id": {bugID}, "action": {"invalid"}, "csrf": {env.dash.csrfToken("other")}}
+	assert.Equal(t, http.StatusForbidden, env.uiAction("admin", "password", badToken))
+	assert.Equal(t, dashapi.BugStatusOpen, bug.Status)
+
+	assert.Equal(t, http.StatusFound, env.uiAction("admin", "password", form))
+	assert.Equal(t, dashapi.BugStatusInvalid, bug.Status)
+}
+
+func TestNoClients(t *testing.T) {
+	_, err := New(&Config{}, t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestUnknownClient(t *testing.T) {
+	env := newTestEnv(t, &Config{Clients: map[string]string{"other": "secret"}})
+	assert.Error(t, env.api.UploadBuild(testBuild("build1")))
+}
+
+// uiAction submits the form to /action and returns the HTTP status code.
+func (env *testEnv) uiAction(user, password string, form url.Values) int {
+	req, err := http.NewRequest(http.MethodPost, env.srv.URL+"/action", strings.NewReader(form.Encode()))
+	require.NoError(env.t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Do(req)
+	require.NoError(env.t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
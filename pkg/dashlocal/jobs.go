@@ -0,0 +1,175 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package dashlocal
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+)
+
+func (dash *Dashboard) createJob(typ dashapi.JobType, bug *Bug, crash *Crash) *Job {
+	dash.st.NextJobID++
+	job := &Job{
+		ID:      fmt.Sprint(dash.st.NextJobID),
+		Type:    typ,
+		BugID:   bug.ID,
+		CrashID: crash.ID,
+		Manager: crash.Manager,
+		Created: dash.now(),
+	}
+	dash.st.Jobs = append(dash.st.Jobs, job)
+	return job
+}
+
+// testPatch creates a patch testing job for the bug, repo and branch default to the tested crash's build.
+func (dash *Dashboard) testPatch(bug *Bug, repo, branch string, patch []byte) (*Job, error) {
+	crash := bug.bestCrash()
+	if crash == nil || crash.ReproLevel == dashapi.ReproLevelNone {
+		return nil, fmt.Errorf("the bug does not have a reproducer")
+	}
+	if len(patch) == 0 {
+		return nil, fmt.Errorf("empty patch")
+	}
+	patchBlob, err := putBlob(dash.dir, patch)
+	if err != nil {
+		return nil, err
+	}
+	job := dash.createJob(dashapi.JobTestPatch, bug, crash)
+	job.KernelRepo = repo
+	job.KernelBranch = branch
+	job.Patch = patchBlob
+	return job, nil
+}
+
+func (dash *Dashboard) apiJobPoll(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.JobPollReq](payload)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range dash.st.Jobs {
+		if !job.Started.IsZero() {
+			continue
+		}
+		mgr, ok := req.Managers[job.Manager]
+		if !ok || job.Type == dashapi.JobTestPatch && !mgr.TestPatches ||
+			job.Type == dashapi.JobBisectCause && !mgr.BisectCause ||
+			job.Type == dashapi.JobBisectFix && !mgr.BisectFix {
+			continue
+		}
+		resp, err := dash.jobPollResp(job)
+		if err != nil {
+			return nil, err
+		}
+		job.Started = dash.now()
+		return resp, nil
+	}
+	return &dashapi.JobPollResp{}, nil
+}
+
+func (dash *Dashboard) jobPollResp(job *Job) (*dashapi.JobPollResp, error) {
+	bug := dash.st.bug(job.BugID)
+	if bug == nil {
+		return nil, fmt.Errorf("job %v: unknown bug %v", job.ID, job.BugID)
+	}
+	crash := bug.crash(job.CrashID)
+	if crash == nil {
+		return nil, fmt.Errorf("job %v: unknown crash %v", job.ID, job.CrashID)
+	}
+	build := dash.st.Builds[crash.BuildID]
+	if build == nil {
+		return nil, fmt.Errorf("job %v: unknown build %v", job.ID, crash.BuildID)
+	}
+	resp := &dashapi.JobPollResp{
+		ID:                job.ID,
+		Type:              job.Type,
+		Manager:           job.Manager,
+		KernelRepo:        build.Build.KernelRepo,
+		KernelBranch:      build.Build.KernelBranch,
+		KernelCommit:      build.Build.KernelCommit,
+		KernelCommitTitle: build.Build.KernelCommitTitle,
+		SyzkallerCommit:   build.Build.SyzkallerCommit,
+		ReproReliability:  crash.ReproReliability,
+	}
+	if job.KernelRepo != "" {
+		resp.KernelRepo = job.KernelRepo
+	}
+	if job.KernelBranch != "" {
+		resp.KernelBranch = job.KernelBranch
+	}
+	blobs := []struct {
+		data *[]byte
+		id   string
+	}{
+		{&resp.KernelConfig, build.KernelConfig},
+		{&resp.Patch, job.Patch},
+		{&resp.ReproOpts, crash.ReproOpts},
+		{&resp.ReproSyz, crash.ReproSyz},
+		{&resp.ReproC, crash.ReproC},
+	}
+	for _, blob := range blobs {
+		var err error
+		if *blob.data, err = getBlob(dash.dir, blob.id); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (dash *Dashboard) apiJobReset(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.JobResetReq](payload)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range dash.st.Jobs {
+		if job.Finished.IsZero() && stringInList(req.Managers, job.Manager) {
+			job.Started = time.Time{}
+		}
+	}
+	return nil, nil
+}
+
+func (dash *Dashboard) apiJobDone(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.JobDoneReq](payload)
+	if err != nil {
+		return nil, err
+	}
+	job := dash.st.job(req.ID)
+	if job == nil {
+		return nil, fmt.Errorf("unknown job %q", req.ID)
+	}
+	if !job.Finished.IsZero() {
+		return nil, fmt.Errorf("job %v is already finished", req.ID)
+	}
+	if req.Build.ID != "" {
+		if err := dash.uploadBuild(&req.Build); err != nil {
+			return nil, err
+		}
+	}
+	job.Finished = dash.now()
+	job.KernelCommit = req.Build.KernelCommit
+	job.KernelCommitTitle = req.Build.KernelCommitTitle
+	job.CrashTitle = req.CrashTitle
+	job.Commits = req.Commits
+	job.Merge = req.Merge
+	job.Flags = req.Flags
+	job.Confidence = req.Confidence
+	blobs := []struct {
+		id   *string
+		data []byte
+	}{
+		{&job.Error, req.Error},
+		{&job.Log, req.Log},
+		{&job.CrashLog, req.CrashLog},
+		{&job.CrashReport, req.CrashReport},
+	}
+	for _, blob := range blobs {
+		if *blob.id, err = putBlob(dash.dir, blob.data); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package dashlocal
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+)
+
+// There is a single reporting stage: every bug is reported once when it's created
+// and once more when it gets a better reproducer. Reporting tools (e.g. a mailing bot)
+// poll reports with reporting_poll_bugs and confirm them with reporting_update.
+// Bugs can also be closed with reporting_update or from the web UI.
+
+func (dash *Dashboard) apiReportingPollBugs(payload io.Reader) (interface{}, error) {
+	resp := new(dashapi.PollBugsResponse)
+	for _, bug := range dash.st.Bugs {
+		if bug.Status != dashapi.BugStatusOpen ||
+			bug.Title == corruptedReportTitle || bug.Title == suppressedReportTitle {
+			continue
+		}
+		typ := dashapi.ReportNew
+		if bug.Reported {
+			if bug.ReproLevel <= bug.ReportedReproLevel {
+				continue
+			}
+			typ = dashapi.ReportRepro
+		}
+		rep, err := dash.bugReport(bug, typ)
+		if err != nil {
+			return nil, err
+		}
+		resp.Reports = append(resp.Reports, rep)
+	}
+	return resp, nil
+}
+
+func (dash *Dashboard) bugReport(bug *Bug, typ dashapi.ReportType) (*dashapi.BugReport, error) {
+	crash := bug.bestCrash()
+	if crash == nil {
+		return nil, fmt.Errorf("bug %v has no crashes", bug.ID)
+	}
+	build := dash.st.Builds[crash.BuildID]
+	if build == nil {
+		return nil, fmt.Errorf("bug %v: unknown build %v", bug.ID, crash.BuildID)
+	}
+	rep := &dashapi.BugReport{
+		Type:              typ,
+		BugStatus:         bug.Status,
+		Namespace:         dash.cfg.Name,
+		ID:                bug.ID,
+		ExtID:             bug.ExtID,
+		First:             typ == dashapi.ReportNew,
+		Title:             bug.Title,
+		Link:              dash.cfg.URL + bugLink(bug),
+		OS:                build.Build.OS,
+		Arch:              build.Build.Arch,
+		VMArch:            build.Build.VMArch,
+		BuildID:           build.Build.ID,
+		BuildTime:         build.Time,
+		CompilerID:        build.Build.CompilerID,
+		KernelRepo:        build.Build.KernelRepo,
+		KernelBranch:      build.Build.KernelBranch,
+		KernelCommit:      build.Build.KernelCommit,
+		KernelCommitTitle: build.Build.KernelCommitTitle,
+		KernelCommitDate:  build.Build.KernelCommitDate,
+		KernelConfigLink:  dash.textLink(build.KernelConfig),
+		SyzkallerCommit:   build.Build.SyzkallerCommit,
+		LogLink:           dash.textLink(crash.Log),
+		ReportLink:        dash.textLink(crash.Report),
+		ReproCLink:        dash.textLink(crash.ReproC),
+		ReproSyzLink:      dash.textLink(crash.ReproSyz),
+		MachineInfoLink:   dash.textLink(crash.MachineInfo),
+		Manager:           crash.Manager,
+		CrashID:           crash.ID,
+		CrashTime:         crash.Time,
+		NumCrashes:        bug.NumCrashes,
+		HappenedOn:        bug.Managers,
+		Assets:            assets(build),
+	}
+	blobs := []struct {
+		data *[]byte
+		id   string
+	}{
+		{&rep.KernelConfig, build.KernelConfig},
+		{&rep.Log, crash.Log},
+		{&rep.Report, crash.Report},
+		{&rep.ReproC, crash.ReproC},
+		{&rep.ReproSyz, crash.ReproSyz},
+		{&rep.ReproOpts, crash.ReproOpts},
+		{&rep.MachineInfo, crash.MachineInfo},
+	}
+	for _, blob := range blobs {
+		var err error
+		if *blob.data, err = getBlob(dash.dir, blob.id); err != nil {
+			return nil, err
+		}
+	}
+	if job := dash.st.job(bug.BisectCauseJob); job != nil && !job.Finished.IsZero() && job.Error == "" {
+		rep.BisectCause = dash.bisectResult(job)
+	}
+	return rep, nil
+}
+
+func (dash *Dashboard) bisectResult(job *Job) *dashapi.BisectResult {
+	res := &dashapi.BisectResult{
+		LogLink:         dash.textLink(job.Log),
+		CrashLogLink:    dash.textLink(job.CrashLog),
+		CrashReportLink: dash.textLink(job.CrashReport),
+		Fix:             job.Type == dashapi.JobBisectFix,
+		Confidence:      job.Confidence,
+		Merge:           job.Merge,
+	}
+	for i := range job.Commits {
+		res.Commits = append(res.Commits, &job.Commits[i])
+	}
+	if len(res.Commits) == 1 {
+		res.Commit = res.Commits[0]
+		res.Commits = nil
+	}
+	return res
+}
+
+func assets(build *Build) []dashapi.Asset {
+	var ret []dashapi.Asset
+	for _, asset := range build.Build.Assets {
+		ret = append(ret, dashapi.Asset{
+			Title:       string(asset.Type),
+			DownloadURL: asset.DownloadURL,
+			Type:        asset.Type,
+			FsIsClean:   asset.FsIsClean,
+		})
+	}
+	return ret
+}
+
+func (dash *Dashboard) apiReportingUpdate(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.BugUpdate](payload)
+	if err != nil {
+		return nil, err
+	}
+	bug := dash.st.bug(req.ID)
+	if bug == nil {
+		return &dashapi.BugUpdateReply{Text: fmt.Sprintf("unknown bug %q", req.ID)}, nil
+	}
+	if req.ExtID != "" {
+		bug.ExtID = req.ExtID
+	}
+	if req.Link != "" {
+		bug.Link = req.Link
+	}
+	if len(req.FixCommits) != 0 {
+		bug.FixCommits = req.FixCommits
+		req.Status = dashapi.BugStatusFixed
+	}
+	switch req.Status {
+	case dashapi.BugStatusOpen, dashapi.BugStatusUpstream:
+		if bug.Status != dashapi.BugStatusOpen {
+			return &dashapi.BugUpdateReply{Text: "the bug is already closed"}, nil
+		}
+		bug.Reported = true
+		bug.ReportedReproLevel = max(bug.ReportedReproLevel, req.ReproLevel)
+	case dashapi.BugStatusInvalid, dashapi.BugStatusFixed:
+		dash.closeBug(bug, req.Status, "")
+	case dashapi.BugStatusDup:
+		dup := dash.st.bug(req.DupOf)
+		if dup == nil || dup == bug {
+			return &dashapi.BugUpdateReply{Text: fmt.Sprintf("can't dup to bug %q", req.DupOf)}, nil
+		}
+		dash.closeBug(bug, req.Status, dup.ID)
+	case dashapi.BugStatusUpdate, dashapi.BugStatusUnCC:
+	default:
+		return &dashapi.BugUpdateReply{Text: fmt.Sprintf("unknown bug status %v", req.Status)}, nil
+	}
+	return &dashapi.BugUpdateReply{OK: true}, nil
+}
+
+func (dash *Dashboard) closeBug(bug *Bug, status dashapi.BugStatus, dupOf string) {
+	bug.Status = status
+	bug.DupOf = dupOf
+	bug.CloseTime = dash.now()
+}
+
+func (dash *Dashboard) apiReportingPollNotifications(payload io.Reader) (interface{}, error) {
+	return &dashapi.PollNotificationsResponse{}, nil
+}
+
+func (dash *Dashboard) apiReportingPollClosed(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.PollClosedRequest](payload)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(dashapi.PollClosedResponse)
+	for _, id := range req.IDs {
+		if bug := dash.st.bug(id); bug != nil && bug.Status != dashapi.BugStatusOpen {
+			resp.IDs = append(resp.IDs, id)
+		}
+	}
+	return resp, nil
+}
+
+func (dash *Dashboard) apiBugList(payload io.Reader) (interface{}, error) {
+	resp := new(dashapi.BugListResp)
+	for _, bug := range dash.st.Bugs {
+		resp.List = append(resp.List, bug.ID)
+	}
+	return resp, nil
+}
+
+func (dash *Dashboard) apiLoadBug(payload io.Reader) (interface{}, error) {
+	req, err := decode[dashapi.LoadBugReq](payload)
+	if err != nil {
+		return nil, err
+	}
+	bug := dash.st.bug(req.ID)
+	if bug == nil {
+		return nil, fmt.Errorf("unknown bug %q", req.ID)
+	}
+	return dash.bugReport(bug, dashapi.ReportNew)
+}
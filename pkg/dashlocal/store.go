@@ -0,0 +1,323 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package dashlocal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/osutil"
+)
+
+// The store keeps all metadata (builds, bugs, crashes, jobs) in a single state.json file
+// that is rewritten on every change, and all large texts (logs, reports, reproducers, configs)
+// in content-addressed files in the blobs dir. This is simple and robust and is good enough
+// for a single on-prem deployment with thousands of bugs.
+
+type state struct {
+	Builds      map[string]*Build
+	Bugs        []*Bug
+	Jobs        []*Job
+	Managers    map[string]*Manager
+	NextCrashID int64
+	NextJobID   int64
+}
+
+type Build struct {
+	Build        *dashapi.Build // KernelConfig is stored separately
+	KernelConfig string         // blob
+	Time         time.Time
+}
+
+type Bug struct {
+	ID         string
+	Title      string
+	Seq        int // number of previous bugs with the same title
+	Status     dashapi.BugStatus
+	DupOf      string
+	FixCommits []string
+	FirstTime  time.Time
+	LastTime   time.Time
+	CloseTime  time.Time
+	NumCrashes int64
+	Managers   []string
+	// The best repro level among all crashes.
+	ReproLevel    dashapi.ReproLevel
+	NumRepro      int
+	LastReproTime time.Time
+	// Up to maxCrashesPerBug crashes, crashes with reproducers are preferred.
+	Crashes []*Crash
+	// Reporting state (see reporting_poll_bugs).
+	Reported           bool
+	ReportedReproLevel dashapi.ReproLevel
+	ExtID              string
+	Link               string
+	BisectCauseJob     string
+}
+
+type Crash struct {
+	ID          int64
+	BuildID     string
+	Manager     string
+	Title       string
+	Time        time.Time
+	Log         string // blob
+	Report      string // blob
+	MachineInfo string // blob
+	ReproLevel  dashapi.ReproLevel
+	ReproOpts   string // blob
+	ReproSyz    string // blob
+	ReproC      string // blob
+	ReproLog    string // blob
+	// The probability that the reproducer crashes the kernel in a single run, 0 if unknown.
+	ReproReliability float64
+}
+
+type Job struct {
+	ID       string
+	Type     dashapi.JobType
+	BugID    string
+	CrashID  int64
+	Manager  string
+	Created  time.Time
+	Started  time.Time
+	Finished time.Time
+	// Patch testing parameters.
+	KernelRepo   string
+	KernelBranch string
+	Patch        string // blob
+	// Results.
+	KernelCommit      string
+	KernelCommitTitle string
+	Error             string // blob
+	Log               string // blob
+	CrashTitle        string
+	CrashLog          string // blob
+	CrashReport       string // blob
+	Commits           []dashapi.Commit
+	Merge             *dashapi.Commit
+	Flags             dashapi.JobDoneFlags
+	Confidence        float64
+}
+
+type Manager struct {
+	Name        string
+	Addr        string
+	LastActive  time.Time
+	UpTime      time.Duration
+	Corpus      uint64
+	PCs         uint64
+	Cover       uint64
+	CrashTypes  uint64
+	FuzzingTime time.Duration
+	Crashes     uint64
+	Execs       uint64
+}
+
+const maxCrashesPerBug = 40
+
+func (st *state) reset() {
+	*st = state{
+		Builds:   make(map[string]*Build),
+		Managers: make(map[string]*Manager),
+	}
+}
+
+func (st *state) load(dir string) error {
+	st.reset()
+	data, err := os.ReadFile(filepath.Join(dir, "state.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return fmt.Errorf("failed to parse state.json: %w", err)
+	}
+	if st.Builds == nil {
+		st.Builds = make(map[string]*Build)
+	}
+	if st.Managers == nil {
+		st.Managers = make(map[string]*Manager)
+	}
+	return nil
+}
+
+func (st *state) save(dir string) error {
+	data, err := json.MarshalIndent(st, "", "\t")
+	if err != nil {
+		return err
+	}
+	return osutil.WriteFileAtomically(filepath.Join(dir, "state.json"), data)
+}
+
+func (st *state) bug(id string) *Bug {
+	for _, bug := range st.Bugs {
+		if bug.ID == id {
+			return bug
+		}
+	}
+	return nil
+}
+
+// findBug returns the open bug for one of the titles (or nil).
+func (st *state) findBug(titles []string) *Bug {
+	for _, title := range titles {
+		for _, bug := range st.Bugs {
+			if bug.Title == title && bug.Status == dashapi.BugStatusOpen {
+				return bug
+			}
+		}
+	}
+	return nil
+}
+
+func (st *state) createBug(title string, now time.Time) *Bug {
+	seq := 0
+	for _, bug := range st.Bugs {
+		if bug.Title == title {
+			seq++
+		}
+	}
+	bug := &Bug{
+		ID:        hash.String([]byte(fmt.Sprintf("%v-%v", title, seq))),
+		Title:     title,
+		Seq:       seq,
+		Status:    dashapi.BugStatusOpen,
+		FirstTime: now,
+	}
+	st.Bugs = append(st.Bugs, bug)
+	return bug
+}
+
+func (st *state) job(id string) *Job {
+	for _, job := range st.Jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+func (bug *Bug) crash(id int64) *Crash {
+	for _, crash := range bug.Crashes {
+		if crash.ID == id {
+			return crash
+		}
+	}
+	return nil
+}
+
+// bestCrash returns the crash with the best reproducer, and the latest one among equal.
+func (bug *Bug) bestCrash() *Crash {
+	var best *Crash
+	for _, crash := range bug.Crashes {
+		if best == nil || crash.ReproLevel > best.ReproLevel ||
+			crash.ReproLevel == best.ReproLevel && crash.Time.After(best.Time) {
+			best = crash
+		}
+	}
+	return best
+}
+
+func (bug *Bug) addCrash(crash *Crash) {
+	bug.Crashes = append(bug.Crashes, crash)
+	if len(bug.Crashes) <= maxCrashesPerBug {
+		return
+	}
+	// Drop the oldest crash with the worst reproducer.
+	worst := 0
+	for i, crash := range bug.Crashes {
+		if crash.ReproLevel < bug.Crashes[worst].ReproLevel {
+			worst = i
+		}
+	}
+	bug.Crashes = append(bug.Crashes[:worst], bug.Crashes[worst+1:]...)
+}
+
+func (st *state) blobs() map[string]bool {
+	used := make(map[string]bool)
+	add := func(blobs ...string) {
+		for _, blob := range blobs {
+			if blob != "" {
+				used[blob] = true
+			}
+		}
+	}
+	for _, build := range st.Builds {
+		add(build.KernelConfig)
+	}
+	for _, bug := range st.Bugs {
+		for _, crash := range bug.Crashes {
+			add(crash.Log, crash.Report, crash.MachineInfo,
+				crash.ReproOpts, crash.ReproSyz, crash.ReproC, crash.ReproLog)
+		}
+	}
+	for _, job := range st.Jobs {
+		add(job.Patch, job.Error, job.Log, job.CrashLog, job.CrashReport)
+	}
+	return used
+}
+
+func blobDir(dir string) string {
+	return filepath.Join(dir, "blobs")
+}
+
+func putBlob(dir string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	id := hash.String(data)
+	file := filepath.Join(blobDir(dir), id)
+	if osutil.IsExist(file) {
+		return id, nil
+	}
+	if err := osutil.WriteFile(file, data); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func getBlob(dir, id string) ([]byte, error) {
+	if id == "" {
+		return nil, nil
+	}
+	if !hashRe.MatchString(id) {
+		return nil, fmt.Errorf("bad blob id %q", id)
+	}
+	return os.ReadFile(filepath.Join(blobDir(dir), id))
+}
+
+// gcBlobs removes blobs that are not referenced from the state anymore
+// (e.g. belonged to crashes that were displaced by newer crashes).
+func gcBlobs(dir string, used map[string]bool) error {
+	files, err := os.ReadDir(blobDir(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	removed := 0
+	for _, file := range files {
+		if used[file.Name()] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(blobDir(dir), file.Name())); err != nil {
+			return err
+		}
+		removed++
+	}
+	if removed != 0 {
+		log.Logf(0, "removed %v unused blobs", removed)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package dashlocal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/html/pages"
+	"github.com/google/syzkaller/pkg/log"
+)
+
+func (dash *Dashboard) initUI(mux *http.ServeMux) {
+	mux.HandleFunc("/", dash.auth(dash.httpMain))
+	mux.HandleFunc("/bug", dash.auth(dash.httpBug))
+	mux.HandleFunc("/text", dash.auth(dash.httpText))
+	mux.HandleFunc("/action", dash.auth(dash.httpAction))
+}
+
+// auth requires HTTP basic auth of one of the configured users.
+func (dash *Dashboard) auth(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		expected, known := dash.cfg.Users[user]
+		if !ok || !known || subtle.ConstantTimeCompare([]byte(expected), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="syz-dashlocal", charset="UTF-8"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// csrfToken returns the token that must accompany the actions submitted by the user.
+func (dash *Dashboard) csrfToken(user string) string {
+	mac := hmac.New(sha256.New, dash.csrfKey)
+	mac.Write([]byte(user))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (dash *Dashboard) checkCSRF(r *http.Request) error {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || u.Host != r.Host {
+			return fmt.Errorf("cross-origin request from %q", origin)
+		}
+	}
+	user, _, _ := r.BasicAuth()
+	if !hmac.Equal([]byte(dash.csrfToken(user)), []byte(r.PostFormValue("csrf"))) {
+		return fmt.Errorf("invalid CSRF token")
+	}
+	return nil
+}
+
+type uiMainPage struct {
+	Name     string
+	Status   string
+	Managers []*Manager
+	Bugs     []*uiBug
+}
+
+type uiBug struct {
+	*Bug
+	Link string
+}
+
+type uiBugPage struct {
+	Name    string
+	Bug     *Bug
+	Status  string
+	DupOf   *uiBug
+	Crashes []*uiCrash
+	Jobs    []*uiJob
+	// Defaults for the patch testing form.
+	KernelRepo   string
+	KernelBranch string
+	CSRFToken    string
+}
+
+type uiCrash struct {
+	*Crash
+	Build            *dashapi.Build
+	LogLink          string
+	ReportLink       string
+	MachineInfoLink  string
+	ReproSyzLink     string
+	ReproCLink       string
+	ReproLogLink     string
+	KernelConfigLink string
+}
+
+type uiJob struct {
+	*Job
+	PatchLink       string
+	LogLink         string
+	ErrorLink       string
+	CrashLogLink    string
+	CrashReportLink string
+}
+
+var uiStatuses = map[string]dashapi.BugStatus{
+	"":        dashapi.BugStatusOpen,
+	"fixed":   dashapi.BugStatusFixed,
+	"invalid": dashapi.BugStatusInvalid,
+	"dup":     dashapi.BugStatusDup,
+}
+
+func (dash *Dashboard) httpMain(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	status := r.FormValue("status")
+	bugStatus, ok := uiStatuses[status]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown status %q", status), http.StatusBadRequest)
+		return
+	}
+	dash.mu.Lock()
+	defer dash.mu.Unlock()
+	data := &uiMainPage{
+		Name:   dash.cfg.Name,
+		Status: status,
+	}
+	for _, mgr := range dash.st.Managers {
+		data.Managers = append(data.Managers, mgr)
+	}
+	sort.Slice(data.Managers, func(i, j int) bool {
+		return data.Managers[i].Name < data.Managers[j].Name
+	})
+	for _, bug := range dash.st.Bugs {
+		if bug.Status == bugStatus {
+			data.Bugs = append(data.Bugs, &uiBug{bug, bugLink(bug)})
+		}
+	}
+	sort.SliceStable(data.Bugs, func(i, j int) bool {
+		return data.Bugs[i].LastTime.After(data.Bugs[j].LastTime)
+	})
+	executeTemplate(w, "main.html", data)
+}
+
+func (dash *Dashboard) httpBug(w http.ResponseWriter, r *http.Request) {
+	dash.mu.Lock()
+	defer dash.mu.Unlock()
+	bug := dash.st.bug(r.FormValue("id"))
+	if bug == nil {
+		http.Error(w, "unknown bug", http.StatusNotFound)
+		return
+	}
+	data := &uiBugPage{
+		Name:   dash.cfg.Name,
+		Bug:    bug,
+		Status: statusName(bug.Status),
+	}
+	if user, _, ok := r.BasicAuth(); ok {
+		data.CSRFToken = dash.csrfToken(user)
+	}
+	if dup := dash.st.bug(bug.DupOf); dup != nil {
+		data.DupOf = &uiBug{dup, bugLink(dup)}
+	}
+	for i := len(bug.Crashes) - 1; i >= 0; i-- {
+		crash := bug.Crashes[i]
+		ui := &uiCrash{
+			Crash:           crash,
+			LogLink:         dash.textLink(crash.Log),
+			ReportLink:      dash.textLink(crash.Report),
+			MachineInfoLink: dash.textLink(crash.MachineInfo),
+			ReproSyzLink:    dash.textLink(crash.ReproSyz),
+			ReproCLink:      dash.textLink(crash.ReproC),
+			ReproLogLink:    dash.textLink(crash.ReproLog),
+		}
+		if build := dash.st.Builds[crash.BuildID]; build != nil {
+			ui.Build = build.Build
+			ui.KernelConfigLink = dash.textLink(build.KernelConfig)
+		}
+		data.Crashes = append(data.Crashes, ui)
+	}
+	if best := bug.bestCrash(); best != nil {
+		if build := dash.st.Builds[best.BuildID]; build != nil {
+			data.KernelRepo = build.Build.KernelRepo
+			data.KernelBranch = build.Build.KernelBranch
+		}
+	}
+	for i := len(dash.st.Jobs) - 1; i >= 0; i-- {
+		job := dash.st.Jobs[i]
+		if job.BugID != bug.ID {
+			continue
+		}
+		data.Jobs = append(data.Jobs, &uiJob{
+			Job:             job,
+			PatchLink:       dash.textLink(job.Patch),
+			LogLink:         dash.textLink(job.Log),
+			ErrorLink:       dash.textLink(job.Error),
+			CrashLogLink:    dash.textLink(job.CrashLog),
+			CrashReportLink: dash.textLink(job.CrashReport),
+		})
+	}
+	executeTemplate(w, "bug.html", data)
+}
+
+func (dash *Dashboard) httpText(w http.ResponseWriter, r *http.Request) {
+	data, err := getBlob(dash.dir, r.FormValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(data)
+}
+
+func (dash *Dashboard) httpAction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST is required", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := dash.checkCSRF(r); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	dash.mu.Lock()
+	defer dash.mu.Unlock()
+	bug := dash.st.bug(r.FormValue("id"))
+	if bug == nil {
+		http.Error(w, "unknown bug", http.StatusNotFound)
+		return
+	}
+	switch action := r.FormValue("action"); action {
+	case "fixed":
+		dash.closeBug(bug, dashapi.BugStatusFixed, "")
+	case "invalid":
+		dash.closeBug(bug, dashapi.BugStatusInvalid, "")
+	case "test":
+		_, err := dash.testPatch(bug, r.FormValue("repo"), r.FormValue("branch"), []byte(r.FormValue("patch")))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, fmt.Sprintf("unknown action %q", action), http.StatusBadRequest)
+		return
+	}
+	if err := dash.st.save(dash.dir); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, bugLink(bug), http.StatusFound)
+}
+
+func (job *uiJob) TypeName() string {
+	switch job.Type {
+	case dashapi.JobTestPatch:
+		return "patch testing"
+	case dashapi.JobBisectCause:
+		return "cause bisection"
+	case dashapi.JobBisectFix:
+		return "fix bisection"
+	}
+	return fmt.Sprint(job.Type)
+}
+
+func (bug *uiBug) StatusName() string {
+	return statusName(bug.Status)
+}
+
+func statusName(status dashapi.BugStatus) string {
+	for name, st := range uiStatuses {
+		if st == status && name != "" {
+			return name
+		}
+	}
+	return "open"
+}
+
+func bugLink(bug *Bug) string {
+	return "/bug?id=" + bug.ID
+}
+
+func (dash *Dashboard) textLink(blob string) string {
+	if blob == "" {
+		return ""
+	}
+	return dash.cfg.URL + "/text?id=" + url.QueryEscape(blob)
+}
+
+func executeTemplate(w http.ResponseWriter, name string, data interface{}) {
+	buf := new(bytes.Buffer)
+	if err := templates.ExecuteTemplate(buf, name, data); err != nil {
+		log.Logf(0, "failed to execute template: %v", err)
+		http.Error(w, fmt.Sprintf("failed to execute template: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Write(buf.Bytes())
+}
+
+//go:embed templates
+var templatesFS embed.FS
+var templates = pages.CreateFromFS(templatesFS, "templates/*.html")
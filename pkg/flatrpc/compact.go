@@ -0,0 +1,112 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package flatrpc
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// The compact encoding of signal and cover (CallInfoRaw.signal_packed/cover_packed) is a sequence
+// of zigzag varints. Each varint is the difference between the element and the element that follows it
+// (the last element is stored as is). The executor writes flatbuffers backwards, so this is the order
+// in which it's the most natural to produce. Since PCs of the same program are close to each other,
+// most elements take 1-3 bytes instead of 8.
+
+// PackCompact encodes the values in the compact form.
+func PackCompact(vals []uint64) []byte {
+	ret := make([]byte, 0, len(vals)*2)
+	for i, val := range vals {
+		next := uint64(0)
+		if i+1 < len(vals) {
+			next = vals[i+1]
+		}
+		ret = binary.AppendVarint(ret, int64(val-next))
+	}
+	return ret
+}
+
+// UnpackCompact decodes the values encoded with PackCompact.
+func UnpackCompact(data []byte) ([]uint64, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	ret := make([]uint64, 0, len(data)/2)
+	for pos := 0; pos < len(data); {
+		delta, n := binary.Varint(data[pos:])
+		if n <= 0 {
+			return nil, fmt.Errorf("bad compact varint at offset %v", pos)
+		}
+		ret = append(ret, uint64(delta))
+		pos += n
+	}
+	for i := len(ret) - 2; i >= 0; i-- {
+		ret[i] += ret[i+1]
+	}
+	return ret, nil
+}
+
+// Unpack decodes compact signal/cover into the Signal/Cover fields.
+func (ci *CallInfo) Unpack() error {
+	if ci == nil {
+		return nil
+	}
+	if ci.SignalPacked != nil {
+		vals, err := UnpackCompact(ci.SignalPacked)
+		if err != nil {
+			return fmt.Errorf("signal: %w", err)
+		}
+		ci.Signal = append(ci.Signal, vals...)
+		ci.SignalPacked = nil
+	}
+	if ci.CoverPacked != nil {
+		vals, err := UnpackCompact(ci.CoverPacked)
+		if err != nil {
+			return fmt.Errorf("cover: %w", err)
+		}
+		ci.Cover = append(ci.Cover, vals...)
+		ci.CoverPacked = nil
+	}
+	return nil
+}
+
+// With the compact encoding the executor filters signal against a bloom filter of the max signal
+// instead of the exact set. On connect the manager sends the filter bits (see MaxSignalBloom),
+// which take MaxSignalBloomSize bytes regardless of the amount of signal, and then new max signal
+// values as usual. A false positive makes the executor drop a new signal value,
+// for 4M max signal values the false positive rate is ~0.5%.
+
+// MaxSignalBloomSize is the size of the max signal bloom filter in bytes
+// (must match BloomFilter::kSize in executor/bloom_filter.h).
+const MaxSignalBloomSize = 8 << 20
+
+const maxSignalBloomHashes = 3
+
+// MaxSignalBloom returns the bits of the max signal bloom filter that contains the signal values.
+func MaxSignalBloom(signal []uint64) []byte {
+	bits := make([]byte, MaxSignalBloomSize)
+	for _, sig := range signal {
+		for _, bit := range bloomBits(sig) {
+			bits[bit/8] |= 1 << (bit % 8)
+		}
+	}
+	return bits
+}
+
+// bloomBits returns the bits that represent the value in the bloom filter
+// (must match BloomFilter::Bit in executor/bloom_filter.h).
+func bloomBits(sig uint64) [maxSignalBloomHashes]uint64 {
+	// This is the 64-bit finalizer of MurmurHash3.
+	h := sig
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	var ret [maxSignalBloomHashes]uint64
+	for i := range ret {
+		ret[i] = (h&0xffffffff + uint64(i)*(h>>32)) % (MaxSignalBloomSize * 8)
+	}
+	return ret
+}
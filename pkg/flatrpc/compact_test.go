@@ -0,0 +1,88 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package flatrpc
+
+import (
+	"math/rand"
+	"testing"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompactEncoding(t *testing.T) {
+	// Must match the expectations in executor/test.h:test_compact_cover.
+	vals := []uint64{3, 1, 2, 300}
+	data := PackCompact(vals)
+	assert.Equal(t, []byte{0x04, 0x01, 0xd3, 0x04, 0xd8, 0x04}, data)
+	got, err := UnpackCompact(data)
+	assert.NoError(t, err)
+	assert.Equal(t, vals, got)
+}
+
+func TestCompactRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		var vals []uint64
+		base := uint64(0xffffffff81000000)
+		for n := rnd.Intn(100); n > 0; n-- {
+			switch rnd.Intn(3) {
+			case 0:
+				vals = append(vals, rnd.Uint64())
+			default:
+				vals = append(vals, base+uint64(rnd.Intn(1<<20)))
+			}
+		}
+		got, err := UnpackCompact(PackCompact(vals))
+		assert.NoError(t, err)
+		assert.Equal(t, vals, got)
+	}
+}
+
+func TestCompactCallInfo(t *testing.T) {
+	pcs := []uint64{0xffffffff81000010, 0xffffffff81000000, 0xffffffff81000008}
+	info := &CallInfo{
+		SignalPacked: PackCompact(pcs),
+		CoverPacked:  PackCompact(pcs[:2]),
+	}
+	builder := flatbuffers.NewBuilder(0)
+	builder.Finish(info.Pack(builder))
+	info = GetRootAsCallInfoRaw(builder.FinishedBytes(), 0).UnPack()
+	assert.NoError(t, info.Unpack())
+	assert.Equal(t, pcs, info.Signal)
+	assert.Equal(t, pcs[:2], info.Cover)
+	assert.Nil(t, info.SignalPacked)
+	assert.Nil(t, info.CoverPacked)
+
+	info = &CallInfo{CoverPacked: []byte{0x80}}
+	assert.Error(t, info.Unpack())
+}
+
+func TestMaxSignalBloom(t *testing.T) {
+	// Must match the expectations in executor/test.h:test_max_signal_bloom.
+	assert.Equal(t, [maxSignalBloomHashes]uint64{46605348, 62131722, 10549232}, bloomBits(0xffffffff81000010))
+	bits := MaxSignalBloom([]uint64{0xffffffff81000010})
+	assert.Len(t, bits, MaxSignalBloomSize)
+	var set []uint64
+	for i, b := range bits {
+		for j := 0; j < 8; j++ {
+			if b&(1<<j) != 0 {
+				set = append(set, uint64(i*8+j))
+			}
+		}
+	}
+	assert.Equal(t, []uint64{10549232, 46605348, 62131722}, set)
+
+	rnd := rand.New(rand.NewSource(0))
+	var signal []uint64
+	for i := 0; i < 1000; i++ {
+		signal = append(signal, rnd.Uint64())
+	}
+	bits = MaxSignalBloom(signal)
+	for _, sig := range signal {
+		for _, bit := range bloomBits(sig) {
+			assert.NotZero(t, bits[bit/8]&(1<<(bit%8)), "0x%x", sig)
+		}
+	}
+}
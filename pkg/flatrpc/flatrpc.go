@@ -711,6 +711,7 @@ type ConnectReplyRawT struct {
 	RaceFrames       []string `json:"race_frames"`
 	Features         Feature  `json:"features"`
 	Files            []string `json:"files"`
+	CompactCover     bool     `json:"compact_cover"`
 }
 
 func (t *ConnectReplyRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
@@ -769,6 +770,7 @@ func (t *ConnectReplyRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffse
 	ConnectReplyRawAddRaceFrames(builder, raceFramesOffset)
 	ConnectReplyRawAddFeatures(builder, t.Features)
 	ConnectReplyRawAddFiles(builder, filesOffset)
+	ConnectReplyRawAddCompactCover(builder, t.CompactCover)
 	return ConnectReplyRawEnd(builder)
 }
 
@@ -797,6 +799,7 @@ func (rcv *ConnectReplyRaw) UnPackTo(t *ConnectReplyRawT) {
 	for j := 0; j < filesLength; j++ {
 		t.Files[j] = string(rcv.Files(j))
 	}
+	t.CompactCover = rcv.CompactCover()
 }
 
 func (rcv *ConnectReplyRaw) UnPack() *ConnectReplyRawT {
@@ -994,8 +997,20 @@ func (rcv *ConnectReplyRaw) FilesLength() int {
 	return 0
 }
 
+func (rcv *ConnectReplyRaw) CompactCover() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(28))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *ConnectReplyRaw) MutateCompactCover(n bool) bool {
+	return rcv._tab.MutateBoolSlot(28, n)
+}
+
 func ConnectReplyRawStart(builder *flatbuffers.Builder) {
-	builder.StartObject(12)
+	builder.StartObject(13)
 }
 func ConnectReplyRawAddDebug(builder *flatbuffers.Builder, debug bool) {
 	builder.PrependBoolSlot(0, debug, false)
@@ -1042,6 +1057,9 @@ func ConnectReplyRawAddFiles(builder *flatbuffers.Builder, files flatbuffers.UOf
 func ConnectReplyRawStartFilesVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
 	return builder.StartVector(4, numElems, 4)
 }
+func ConnectReplyRawAddCompactCover(builder *flatbuffers.Builder, compactCover bool) {
+	builder.PrependBoolSlot(12, compactCover, false)
+}
 func ConnectReplyRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -2198,7 +2216,9 @@ func ExecRequestRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 }
 
 type SignalUpdateRawT struct {
-	NewMax []uint64 `json:"new_max"`
+	NewMax      []uint64 `json:"new_max"`
+	Bloom       []byte   `json:"bloom"`
+	BloomOffset uint32   `json:"bloom_offset"`
 }
 
 func (t *SignalUpdateRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
@@ -2214,8 +2234,14 @@ func (t *SignalUpdateRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffse
 		}
 		newMaxOffset = builder.EndVector(newMaxLength)
 	}
+	bloomOffset := flatbuffers.UOffsetT(0)
+	if t.Bloom != nil {
+		bloomOffset = builder.CreateByteString(t.Bloom)
+	}
 	SignalUpdateRawStart(builder)
 	SignalUpdateRawAddNewMax(builder, newMaxOffset)
+	SignalUpdateRawAddBloom(builder, bloomOffset)
+	SignalUpdateRawAddBloomOffset(builder, t.BloomOffset)
 	return SignalUpdateRawEnd(builder)
 }
 
@@ -2225,6 +2251,8 @@ func (rcv *SignalUpdateRaw) UnPackTo(t *SignalUpdateRawT) {
 	for j := 0; j < newMaxLength; j++ {
 		t.NewMax[j] = rcv.NewMax(j)
 	}
+	t.Bloom = rcv.BloomBytes()
+	t.BloomOffset = rcv.BloomOffset()
 }
 
 func (rcv *SignalUpdateRaw) UnPack() *SignalUpdateRawT {
@@ -2289,8 +2317,54 @@ func (rcv *SignalUpdateRaw) MutateNewMax(j int, n uint64) bool {
 	return false
 }
 
+func (rcv *SignalUpdateRaw) Bloom(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *SignalUpdateRaw) BloomLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *SignalUpdateRaw) BloomBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *SignalUpdateRaw) MutateBloom(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
+func (rcv *SignalUpdateRaw) BloomOffset() uint32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.GetUint32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *SignalUpdateRaw) MutateBloomOffset(n uint32) bool {
+	return rcv._tab.MutateUint32Slot(8, n)
+}
+
 func SignalUpdateRawStart(builder *flatbuffers.Builder) {
-	builder.StartObject(1)
+	builder.StartObject(3)
 }
 func SignalUpdateRawAddNewMax(builder *flatbuffers.Builder, newMax flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(newMax), 0)
@@ -2298,6 +2372,15 @@ func SignalUpdateRawAddNewMax(builder *flatbuffers.Builder, newMax flatbuffers.U
 func SignalUpdateRawStartNewMaxVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
 	return builder.StartVector(8, numElems, 8)
 }
+func SignalUpdateRawAddBloom(builder *flatbuffers.Builder, bloom flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(bloom), 0)
+}
+func SignalUpdateRawStartBloomVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
+func SignalUpdateRawAddBloomOffset(builder *flatbuffers.Builder, bloomOffset uint32) {
+	builder.PrependUint32Slot(2, bloomOffset, 0)
+}
 func SignalUpdateRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -2546,11 +2629,13 @@ func ExecutingMessageRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 }
 
 type CallInfoRawT struct {
-	Flags  CallFlag          `json:"flags"`
-	Error  int32             `json:"error"`
-	Signal []uint64          `json:"signal"`
-	Cover  []uint64          `json:"cover"`
-	Comps  []*ComparisonRawT `json:"comps"`
+	Flags        CallFlag          `json:"flags"`
+	Error        int32             `json:"error"`
+	Signal       []uint64          `json:"signal"`
+	Cover        []uint64          `json:"cover"`
+	Comps        []*ComparisonRawT `json:"comps"`
+	SignalPacked []byte            `json:"signal_packed"`
+	CoverPacked  []byte            `json:"cover_packed"`
 }
 
 func (t *CallInfoRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
@@ -2584,12 +2669,22 @@ func (t *CallInfoRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 		}
 		compsOffset = builder.EndVector(compsLength)
 	}
+	signalPackedOffset := flatbuffers.UOffsetT(0)
+	if t.SignalPacked != nil {
+		signalPackedOffset = builder.CreateByteString(t.SignalPacked)
+	}
+	coverPackedOffset := flatbuffers.UOffsetT(0)
+	if t.CoverPacked != nil {
+		coverPackedOffset = builder.CreateByteString(t.CoverPacked)
+	}
 	CallInfoRawStart(builder)
 	CallInfoRawAddFlags(builder, t.Flags)
 	CallInfoRawAddError(builder, t.Error)
 	CallInfoRawAddSignal(builder, signalOffset)
 	CallInfoRawAddCover(builder, coverOffset)
 	CallInfoRawAddComps(builder, compsOffset)
+	CallInfoRawAddSignalPacked(builder, signalPackedOffset)
+	CallInfoRawAddCoverPacked(builder, coverPackedOffset)
 	return CallInfoRawEnd(builder)
 }
 
@@ -2613,6 +2708,8 @@ func (rcv *CallInfoRaw) UnPackTo(t *CallInfoRawT) {
 		rcv.Comps(&x, j)
 		t.Comps[j] = x.UnPack()
 	}
+	t.SignalPacked = rcv.SignalPackedBytes()
+	t.CoverPacked = rcv.CoverPackedBytes()
 }
 
 func (rcv *CallInfoRaw) UnPack() *CallInfoRawT {
@@ -2746,8 +2843,76 @@ func (rcv *CallInfoRaw) CompsLength() int {
 	return 0
 }
 
+func (rcv *CallInfoRaw) SignalPacked(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *CallInfoRaw) SignalPackedLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *CallInfoRaw) SignalPackedBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CallInfoRaw) MutateSignalPacked(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
+func (rcv *CallInfoRaw) CoverPacked(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *CallInfoRaw) CoverPackedLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *CallInfoRaw) CoverPackedBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CallInfoRaw) MutateCoverPacked(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
 func CallInfoRawStart(builder *flatbuffers.Builder) {
-	builder.StartObject(5)
+	builder.StartObject(7)
 }
 func CallInfoRawAddFlags(builder *flatbuffers.Builder, flags CallFlag) {
 	builder.PrependByteSlot(0, byte(flags), 0)
@@ -2773,6 +2938,18 @@ func CallInfoRawAddComps(builder *flatbuffers.Builder, comps flatbuffers.UOffset
 func CallInfoRawStartCompsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
 	return builder.StartVector(32, numElems, 8)
 }
+func CallInfoRawAddSignalPacked(builder *flatbuffers.Builder, signalPacked flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(5, flatbuffers.UOffsetT(signalPacked), 0)
+}
+func CallInfoRawStartSignalPackedVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
+func CallInfoRawAddCoverPacked(builder *flatbuffers.Builder, coverPacked flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(6, flatbuffers.UOffsetT(coverPacked), 0)
+}
+func CallInfoRawStartCoverPackedVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
 func CallInfoRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -1034,6 +1034,7 @@ struct ConnectReplyRawT : public flatbuffers::NativeTable {
   std::vector<std::string> race_frames{};
   rpc::Feature features = static_cast<rpc::Feature>(0);
   std::vector<std::string> files{};
+  bool compact_cover = false;
 };
 
 struct ConnectReplyRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
@@ -1051,7 +1052,8 @@ struct ConnectReplyRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
     VT_LEAK_FRAMES = 20,
     VT_RACE_FRAMES = 22,
     VT_FEATURES = 24,
-    VT_FILES = 26
+    VT_FILES = 26,
+    VT_COMPACT_COVER = 28
   };
   bool debug() const {
     return GetField<uint8_t>(VT_DEBUG, 0) != 0;
@@ -1089,6 +1091,9 @@ struct ConnectReplyRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>> *files() const {
     return GetPointer<const flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>> *>(VT_FILES);
   }
+  bool compact_cover() const {
+    return GetField<uint8_t>(VT_COMPACT_COVER, 0) != 0;
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<uint8_t>(verifier, VT_DEBUG, 1) &&
@@ -1109,6 +1114,7 @@ struct ConnectReplyRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
            VerifyOffset(verifier, VT_FILES) &&
            verifier.VerifyVector(files()) &&
            verifier.VerifyVectorOfStrings(files()) &&
+           VerifyField<uint8_t>(verifier, VT_COMPACT_COVER, 1) &&
            verifier.EndTable();
   }
   ConnectReplyRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
@@ -1156,6 +1162,9 @@ struct ConnectReplyRawBuilder {
   void add_files(flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> files) {
     fbb_.AddOffset(ConnectReplyRaw::VT_FILES, files);
   }
+  void add_compact_cover(bool compact_cover) {
+    fbb_.AddElement<uint8_t>(ConnectReplyRaw::VT_COMPACT_COVER, static_cast<uint8_t>(compact_cover), 0);
+  }
   explicit ConnectReplyRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -1180,7 +1189,8 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(
     flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> leak_frames = 0,
     flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> race_frames = 0,
     rpc::Feature features = static_cast<rpc::Feature>(0),
-    flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> files = 0) {
+    flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> files = 0,
+    bool compact_cover = false) {
   ConnectReplyRawBuilder builder_(_fbb);
   builder_.add_features(features);
   builder_.add_files(files);
@@ -1190,6 +1200,7 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(
   builder_.add_syscall_timeout_ms(syscall_timeout_ms);
   builder_.add_slowdown(slowdown);
   builder_.add_procs(procs);
+  builder_.add_compact_cover(compact_cover);
   builder_.add_kernel_64_bit(kernel_64_bit);
   builder_.add_cover_edges(cover_edges);
   builder_.add_cover(cover);
@@ -1210,7 +1221,8 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRawDirect(
     const std::vector<flatbuffers::Offset<flatbuffers::String>> *leak_frames = nullptr,
     const std::vector<flatbuffers::Offset<flatbuffers::String>> *race_frames = nullptr,
     rpc::Feature features = static_cast<rpc::Feature>(0),
-    const std::vector<flatbuffers::Offset<flatbuffers::String>> *files = nullptr) {
+    const std::vector<flatbuffers::Offset<flatbuffers::String>> *files = nullptr,
+    bool compact_cover = false) {
   auto leak_frames__ = leak_frames ? _fbb.CreateVector<flatbuffers::Offset<flatbuffers::String>>(*leak_frames) : 0;
   auto race_frames__ = race_frames ? _fbb.CreateVector<flatbuffers::Offset<flatbuffers::String>>(*race_frames) : 0;
   auto files__ = files ? _fbb.CreateVector<flatbuffers::Offset<flatbuffers::String>>(*files) : 0;
@@ -1227,7 +1239,8 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRawDirect(
       leak_frames__,
       race_frames__,
       features,
-      files__);
+      files__,
+      compact_cover);
 }
 
 flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(flatbuffers::FlatBufferBuilder &_fbb, const ConnectReplyRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
@@ -1989,21 +2002,34 @@ flatbuffers::Offset<ExecRequestRaw> CreateExecRequestRaw(flatbuffers::FlatBuffer
 struct SignalUpdateRawT : public flatbuffers::NativeTable {
   typedef SignalUpdateRaw TableType;
   std::vector<uint64_t> new_max{};
+  std::vector<uint8_t> bloom{};
+  uint32_t bloom_offset = 0;
 };
 
 struct SignalUpdateRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   typedef SignalUpdateRawT NativeTableType;
   typedef SignalUpdateRawBuilder Builder;
   enum FlatBuffersVTableOffset FLATBUFFERS_VTABLE_UNDERLYING_TYPE {
-    VT_NEW_MAX = 4
+    VT_NEW_MAX = 4,
+    VT_BLOOM = 6,
+    VT_BLOOM_OFFSET = 8
   };
   const flatbuffers::Vector<uint64_t> *new_max() const {
     return GetPointer<const flatbuffers::Vector<uint64_t> *>(VT_NEW_MAX);
   }
+  const flatbuffers::Vector<uint8_t> *bloom() const {
+    return GetPointer<const flatbuffers::Vector<uint8_t> *>(VT_BLOOM);
+  }
+  uint32_t bloom_offset() const {
+    return GetField<uint32_t>(VT_BLOOM_OFFSET, 0);
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyOffset(verifier, VT_NEW_MAX) &&
            verifier.VerifyVector(new_max()) &&
+           VerifyOffset(verifier, VT_BLOOM) &&
+           verifier.VerifyVector(bloom()) &&
+           VerifyField<uint32_t>(verifier, VT_BLOOM_OFFSET, 4) &&
            verifier.EndTable();
   }
   SignalUpdateRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
@@ -2018,6 +2044,12 @@ struct SignalUpdateRawBuilder {
   void add_new_max(flatbuffers::Offset<flatbuffers::Vector<uint64_t>> new_max) {
     fbb_.AddOffset(SignalUpdateRaw::VT_NEW_MAX, new_max);
   }
+  void add_bloom(flatbuffers::Offset<flatbuffers::Vector<uint8_t>> bloom) {
+    fbb_.AddOffset(SignalUpdateRaw::VT_BLOOM, bloom);
+  }
+  void add_bloom_offset(uint32_t bloom_offset) {
+    fbb_.AddElement<uint32_t>(SignalUpdateRaw::VT_BLOOM_OFFSET, bloom_offset, 0);
+  }
   explicit SignalUpdateRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -2031,19 +2063,28 @@ struct SignalUpdateRawBuilder {
 
 inline flatbuffers::Offset<SignalUpdateRaw> CreateSignalUpdateRaw(
     flatbuffers::FlatBufferBuilder &_fbb,
-    flatbuffers::Offset<flatbuffers::Vector<uint64_t>> new_max = 0) {
+    flatbuffers::Offset<flatbuffers::Vector<uint64_t>> new_max = 0,
+    flatbuffers::Offset<flatbuffers::Vector<uint8_t>> bloom = 0,
+    uint32_t bloom_offset = 0) {
   SignalUpdateRawBuilder builder_(_fbb);
+  builder_.add_bloom_offset(bloom_offset);
+  builder_.add_bloom(bloom);
   builder_.add_new_max(new_max);
   return builder_.Finish();
 }
 
 inline flatbuffers::Offset<SignalUpdateRaw> CreateSignalUpdateRawDirect(
     flatbuffers::FlatBufferBuilder &_fbb,
-    const std::vector<uint64_t> *new_max = nullptr) {
+    const std::vector<uint64_t> *new_max = nullptr,
+    const std::vector<uint8_t> *bloom = nullptr,
+    uint32_t bloom_offset = 0) {
   auto new_max__ = new_max ? _fbb.CreateVector<uint64_t>(*new_max) : 0;
+  auto bloom__ = bloom ? _fbb.CreateVector<uint8_t>(*bloom) : 0;
   return rpc::CreateSignalUpdateRaw(
       _fbb,
-      new_max__);
+      new_max__,
+      bloom__,
+      bloom_offset);
 }
 
 flatbuffers::Offset<SignalUpdateRaw> CreateSignalUpdateRaw(flatbuffers::FlatBufferBuilder &_fbb, const SignalUpdateRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
@@ -2218,6 +2259,8 @@ struct CallInfoRawT : public flatbuffers::NativeTable {
   std::vector<uint64_t> signal{};
   std::vector<uint64_t> cover{};
   std::vector<rpc::ComparisonRaw> comps{};
+  std::vector<uint8_t> signal_packed{};
+  std::vector<uint8_t> cover_packed{};
 };
 
 struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
@@ -2228,7 +2271,9 @@ struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
     VT_ERROR = 6,
     VT_SIGNAL = 8,
     VT_COVER = 10,
-    VT_COMPS = 12
+    VT_COMPS = 12,
+    VT_SIGNAL_PACKED = 14,
+    VT_COVER_PACKED = 16
   };
   rpc::CallFlag flags() const {
     return static_cast<rpc::CallFlag>(GetField<uint8_t>(VT_FLAGS, 0));
@@ -2245,6 +2290,12 @@ struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const flatbuffers::Vector<const rpc::ComparisonRaw *> *comps() const {
     return GetPointer<const flatbuffers::Vector<const rpc::ComparisonRaw *> *>(VT_COMPS);
   }
+  const flatbuffers::Vector<uint8_t> *signal_packed() const {
+    return GetPointer<const flatbuffers::Vector<uint8_t> *>(VT_SIGNAL_PACKED);
+  }
+  const flatbuffers::Vector<uint8_t> *cover_packed() const {
+    return GetPointer<const flatbuffers::Vector<uint8_t> *>(VT_COVER_PACKED);
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<uint8_t>(verifier, VT_FLAGS, 1) &&
@@ -2255,6 +2306,10 @@ struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
            verifier.VerifyVector(cover()) &&
            VerifyOffset(verifier, VT_COMPS) &&
            verifier.VerifyVector(comps()) &&
+           VerifyOffset(verifier, VT_SIGNAL_PACKED) &&
+           verifier.VerifyVector(signal_packed()) &&
+           VerifyOffset(verifier, VT_COVER_PACKED) &&
+           verifier.VerifyVector(cover_packed()) &&
            verifier.EndTable();
   }
   CallInfoRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
@@ -2281,6 +2336,12 @@ struct CallInfoRawBuilder {
   void add_comps(flatbuffers::Offset<flatbuffers::Vector<const rpc::ComparisonRaw *>> comps) {
     fbb_.AddOffset(CallInfoRaw::VT_COMPS, comps);
   }
+  void add_signal_packed(flatbuffers::Offset<flatbuffers::Vector<uint8_t>> signal_packed) {
+    fbb_.AddOffset(CallInfoRaw::VT_SIGNAL_PACKED, signal_packed);
+  }
+  void add_cover_packed(flatbuffers::Offset<flatbuffers::Vector<uint8_t>> cover_packed) {
+    fbb_.AddOffset(CallInfoRaw::VT_COVER_PACKED, cover_packed);
+  }
   explicit CallInfoRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -2298,8 +2359,12 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRaw(
     int32_t error = 0,
     flatbuffers::Offset<flatbuffers::Vector<uint64_t>> signal = 0,
     flatbuffers::Offset<flatbuffers::Vector<uint64_t>> cover = 0,
-    flatbuffers::Offset<flatbuffers::Vector<const rpc::ComparisonRaw *>> comps = 0) {
+    flatbuffers::Offset<flatbuffers::Vector<const rpc::ComparisonRaw *>> comps = 0,
+    flatbuffers::Offset<flatbuffers::Vector<uint8_t>> signal_packed = 0,
+    flatbuffers::Offset<flatbuffers::Vector<uint8_t>> cover_packed = 0) {
   CallInfoRawBuilder builder_(_fbb);
+  builder_.add_cover_packed(cover_packed);
+  builder_.add_signal_packed(signal_packed);
   builder_.add_comps(comps);
   builder_.add_cover(cover);
   builder_.add_signal(signal);
@@ -2314,17 +2379,23 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRawDirect(
     int32_t error = 0,
     const std::vector<uint64_t> *signal = nullptr,
     const std::vector<uint64_t> *cover = nullptr,
-    const std::vector<rpc::ComparisonRaw> *comps = nullptr) {
+    const std::vector<rpc::ComparisonRaw> *comps = nullptr,
+    const std::vector<uint8_t> *signal_packed = nullptr,
+    const std::vector<uint8_t> *cover_packed = nullptr) {
   auto signal__ = signal ? _fbb.CreateVector<uint64_t>(*signal) : 0;
   auto cover__ = cover ? _fbb.CreateVector<uint64_t>(*cover) : 0;
   auto comps__ = comps ? _fbb.CreateVectorOfStructs<rpc::ComparisonRaw>(*comps) : 0;
+  auto signal_packed__ = signal_packed ? _fbb.CreateVector<uint8_t>(*signal_packed) : 0;
+  auto cover_packed__ = cover_packed ? _fbb.CreateVector<uint8_t>(*cover_packed) : 0;
   return rpc::CreateCallInfoRaw(
       _fbb,
       flags,
       error,
       signal__,
       cover__,
-      comps__);
+      comps__,
+      signal_packed__,
+      cover_packed__);
 }
 
 flatbuffers::Offset<CallInfoRaw> CreateCallInfoRaw(flatbuffers::FlatBufferBuilder &_fbb, const CallInfoRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
@@ -3050,6 +3121,7 @@ inline void ConnectReplyRaw::UnPackTo(ConnectReplyRawT *_o, const flatbuffers::r
   { auto _e = race_frames(); if (_e) { _o->race_frames.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->race_frames[_i] = _e->Get(_i)->str(); } } }
   { auto _e = features(); _o->features = _e; }
   { auto _e = files(); if (_e) { _o->files.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->files[_i] = _e->Get(_i)->str(); } } }
+  { auto _e = compact_cover(); _o->compact_cover = _e; }
 }
 
 inline flatbuffers::Offset<ConnectReplyRaw> ConnectReplyRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const ConnectReplyRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
@@ -3072,6 +3144,7 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(flatbuffers::F
   auto _race_frames = _o->race_frames.size() ? _fbb.CreateVectorOfStrings(_o->race_frames) : 0;
   auto _features = _o->features;
   auto _files = _o->files.size() ? _fbb.CreateVectorOfStrings(_o->files) : 0;
+  auto _compact_cover = _o->compact_cover;
   return rpc::CreateConnectReplyRaw(
       _fbb,
       _debug,
@@ -3085,7 +3158,8 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(flatbuffers::F
       _leak_frames,
       _race_frames,
       _features,
-      _files);
+      _files,
+      _compact_cover);
 }
 
 inline InfoRequestRawT::InfoRequestRawT(const InfoRequestRawT &o)
@@ -3390,6 +3464,8 @@ inline void SignalUpdateRaw::UnPackTo(SignalUpdateRawT *_o, const flatbuffers::r
   (void)_o;
   (void)_resolver;
   { auto _e = new_max(); if (_e) { _o->new_max.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->new_max[_i] = _e->Get(_i); } } }
+  { auto _e = bloom(); if (_e) { _o->bloom.resize(_e->size()); std::copy(_e->begin(), _e->end(), _o->bloom.begin()); } }
+  { auto _e = bloom_offset(); _o->bloom_offset = _e; }
 }
 
 inline flatbuffers::Offset<SignalUpdateRaw> SignalUpdateRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const SignalUpdateRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
@@ -3401,9 +3477,13 @@ inline flatbuffers::Offset<SignalUpdateRaw> CreateSignalUpdateRaw(flatbuffers::F
   (void)_o;
   struct _VectorArgs { flatbuffers::FlatBufferBuilder *__fbb; const SignalUpdateRawT* __o; const flatbuffers::rehasher_function_t *__rehasher; } _va = { &_fbb, _o, _rehasher}; (void)_va;
   auto _new_max = _o->new_max.size() ? _fbb.CreateVector(_o->new_max) : 0;
+  auto _bloom = _o->bloom.size() ? _fbb.CreateVector(_o->bloom) : 0;
+  auto _bloom_offset = _o->bloom_offset;
   return rpc::CreateSignalUpdateRaw(
       _fbb,
-      _new_max);
+      _new_max,
+      _bloom,
+      _bloom_offset);
 }
 
 inline CorpusTriagedRawT *CorpusTriagedRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
@@ -3501,6 +3581,8 @@ inline void CallInfoRaw::UnPackTo(CallInfoRawT *_o, const flatbuffers::resolver_
   { auto _e = signal(); if (_e) { _o->signal.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->signal[_i] = _e->Get(_i); } } }
   { auto _e = cover(); if (_e) { _o->cover.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->cover[_i] = _e->Get(_i); } } }
   { auto _e = comps(); if (_e) { _o->comps.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->comps[_i] = *_e->Get(_i); } } }
+  { auto _e = signal_packed(); if (_e) { _o->signal_packed.resize(_e->size()); std::copy(_e->begin(), _e->end(), _o->signal_packed.begin()); } }
+  { auto _e = cover_packed(); if (_e) { _o->cover_packed.resize(_e->size()); std::copy(_e->begin(), _e->end(), _o->cover_packed.begin()); } }
 }
 
 inline flatbuffers::Offset<CallInfoRaw> CallInfoRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const CallInfoRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
@@ -3516,13 +3598,17 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRaw(flatbuffers::FlatBuffe
   auto _signal = _o->signal.size() ? _fbb.CreateVector(_o->signal) : 0;
   auto _cover = _o->cover.size() ? _fbb.CreateVector(_o->cover) : 0;
   auto _comps = _o->comps.size() ? _fbb.CreateVectorOfStructs(_o->comps) : 0;
+  auto _signal_packed = _o->signal_packed.size() ? _fbb.CreateVector(_o->signal_packed) : 0;
+  auto _cover_packed = _o->cover_packed.size() ? _fbb.CreateVector(_o->cover_packed) : 0;
   return rpc::CreateCallInfoRaw(
       _fbb,
       _flags,
       _error,
       _signal,
       _cover,
-      _comps);
+      _comps,
+      _signal_packed,
+      _cover_packed);
 }
 
 inline ProgInfoRawT::ProgInfoRawT(const ProgInfoRawT &o)
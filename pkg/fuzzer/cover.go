@@ -36,6 +36,17 @@ func (cover *Cover) addRawMaxSignal(signal []uint64, prio uint8) signal.Signal {
 	return diff
 }
 
+// AddMaxSignal merges the max signal obtained elsewhere (e.g. from other managers)
+// and returns the part that was not known before.
+// Unlike the signal found by the fuzzer itself, it's not returned from GrabSignalDelta.
+func (cover *Cover) AddMaxSignal(raw []uint64) signal.Signal {
+	cover.mu.Lock()
+	defer cover.mu.Unlock()
+	diff := cover.maxSignal.DiffRaw(raw, 0)
+	cover.maxSignal.Merge(diff)
+	return diff
+}
+
 func (cover *Cover) CopyMaxSignal() signal.Signal {
 	cover.mu.RLock()
 	defer cover.mu.RUnlock()
@@ -63,6 +63,7 @@ var Funcs = template.FuncMap{
 	"formatLateness":         formatLateness,
 	"formatReproLevel":       formatReproLevel,
 	"formatStat":             formatStat,
+	"formatPercent":          formatPercent,
 	"formatShortHash":        formatShortHash,
 	"formatTagHash":          formatTagHash,
 	"formatCommitTableTitle": formatCommitTableTitle,
@@ -173,6 +174,13 @@ func formatStat(v int64) string {
 	return fmt.Sprint(v)
 }
 
+func formatPercent(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.0f%%", v*100)
+}
+
 func formatShortHash(v string) string {
 	const hashLen = 8
 	if len(v) <= hashLen {
@@ -4,6 +4,7 @@
 package manager
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"embed"
@@ -56,6 +57,8 @@ type HTTPServer struct {
 	Pool        *vm.Dispatcher
 	Pools       map[string]*vm.Dispatcher
 	TogglePause func(paused bool)
+	// Times when PCs were covered for the first time, nil if coverage is disabled.
+	CoverTimeline *cover.Timeline
 
 	// Can be set dynamically after calling Serve.
 	Corpus          atomic.Pointer[corpus.Corpus]
@@ -86,7 +89,9 @@ func (serv *HTTPServer) Serve(ctx context.Context) error {
 	handle("/corpus", serv.httpCorpus)
 	handle("/corpus.db", serv.httpDownloadCorpus)
 	handle("/cover", serv.httpCover)
+	handle("/coverdiff", serv.httpCoverDiff)
 	handle("/coverprogs", serv.httpPrograms)
+	handle("/covertimeline", serv.httpCoverTimeline)
 	handle("/debuginput", serv.httpDebugInput)
 	handle("/file", serv.httpFile)
 	handle("/filecover", serv.httpFileCover)
@@ -282,14 +287,33 @@ func (serv *HTTPServer) httpVMs(w http.ResponseWriter, r *http.Request) {
 	data := &UIVMData{
 		UIPageHeader: serv.pageHeader(r, "VMs"),
 	}
+	if decision := pool.LastScaleDecision(); decision != nil {
+		data.Autoscale = &UIAutoscale{
+			Active:        decision.Active,
+			Min:           decision.Min,
+			Max:           decision.Max,
+			Reason:        decision.Reason,
+			Since:         time.Since(decision.Time),
+			HostLoad:      fmt.Sprintf("%.2f", decision.Signals.HostLoad),
+			ReproVMs:      decision.Signals.ReproVMs,
+			TriageBacklog: decision.Signals.TriageBacklog,
+		}
+	}
 	// TODO: we could also query vmLoop for VMs that are idle (waiting to start reproducing),
 	// and query the exact bug that is being reproduced by a VM.
 	for id, state := range pool.State() {
 		name := fmt.Sprintf("#%d", id)
 		info := UIVMInfo{
-			Name:  name,
-			State: "unknown",
-			Since: time.Since(state.LastUpdate),
+			Name:   name,
+			State:  "unknown",
+			Since:  time.Since(state.LastUpdate),
+			Health: state.Health.String(),
+		}
+		for ev, count := range state.Health.Events {
+			if count != 0 && dispatcher.HealthEvent(ev) != dispatcher.HealthOK {
+				info.HealthEvents = append(info.HealthEvents,
+					fmt.Sprintf("%v: %v", dispatcher.HealthEvent(ev), count))
+			}
 		}
 		switch state.State {
 		case dispatcher.StateOffline:
@@ -304,6 +328,9 @@ func (serv *HTTPServer) httpVMs(w http.ResponseWriter, r *http.Request) {
 		if state.Reserved {
 			info.State = "[reserved] " + info.State
 		}
+		if state.Parked {
+			info.State = "[parked] " + info.State
+		}
 		if state.MachineInfo != nil {
 			info.MachineInfo = fmt.Sprintf("/vm?type=machine-info&id=%d", id)
 		}
@@ -446,6 +473,11 @@ const (
 	DoFilterPCs
 	DoCoverJSONL
 	DoCoverPrograms
+	DoLCOV
+	DoCobertura
+	DoLLVMCovJSON
+	DoCoverDiff
+	DoLinePrograms
 )
 
 func (serv *HTTPServer) httpCover(w http.ResponseWriter, r *http.Request) {
@@ -453,11 +485,20 @@ func (serv *HTTPServer) httpCover(w http.ResponseWriter, r *http.Request) {
 		serv.httpCoverFallback(w, r)
 		return
 	}
-	if r.FormValue("jsonl") == "1" {
+	switch {
+	case r.FormValue("jsonl") == "1":
 		serv.httpCoverCover(w, r, DoCoverJSONL)
-		return
+	case r.FormValue("lcov") == "1":
+		serv.httpCoverCover(w, r, DoLCOV)
+	case r.FormValue("cobertura") == "1":
+		serv.httpCoverCover(w, r, DoCobertura)
+	case r.FormValue("llvmjson") == "1":
+		serv.httpCoverCover(w, r, DoLLVMCovJSON)
+	case r.FormValue("lineprogs") == "1":
+		serv.httpCoverCover(w, r, DoLinePrograms)
+	default:
+		serv.httpCoverCover(w, r, DoHTML)
 	}
-	serv.httpCoverCover(w, r, DoHTML)
 }
 
 func (serv *HTTPServer) httpPrograms(w http.ResponseWriter, r *http.Request) {
@@ -490,6 +531,7 @@ func (serv *HTTPServer) httpModuleCover(w http.ResponseWriter, r *http.Request)
 
 const ctTextPlain = "text/plain; charset=utf-8"
 const ctApplicationJSON = "application/json"
+const ctApplicationXML = "application/xml"
 
 func (serv *HTTPServer) httpCoverCover(w http.ResponseWriter, r *http.Request, funcFlag int) {
 	if !serv.Cfg.Cover {
@@ -571,10 +613,26 @@ func (serv *HTTPServer) httpCoverCover(w http.ResponseWriter, r *http.Request, f
 	}
 
 	params := cover.HandlerParams{
-		Progs:  progs,
-		Filter: coverFilter,
-		Debug:  r.FormValue("debug") != "",
-		Force:  r.FormValue("force") != "",
+		Progs:    progs,
+		Filter:   coverFilter,
+		Debug:    r.FormValue("debug") != "",
+		Force:    r.FormValue("force") != "",
+		Branches: r.FormValue("branches") != "",
+		Callers:  r.FormValue("callers") != "",
+	}
+
+	var baseParams cover.HandlerParams
+	if funcFlag == DoCoverDiff {
+		basePCs, err := readRawCoverForm(r, "base")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		baseParams = cover.HandlerParams{
+			Progs:  []cover.Prog{{PCs: basePCs}},
+			Filter: coverFilter,
+			Force:  params.Force,
+		}
 	}
 
 	type handlerFuncType func(w io.Writer, params cover.HandlerParams) error
@@ -592,6 +650,13 @@ func (serv *HTTPServer) httpCoverCover(w http.ResponseWriter, r *http.Request, f
 		DoFilterPCs:      {rg.DoFilterPCs, ctTextPlain},
 		DoCoverJSONL:     {rg.DoCoverJSONL, ctApplicationJSON},
 		DoCoverPrograms:  {rg.DoCoverPrograms, ctApplicationJSON},
+		DoLCOV:           {rg.DoLCOV, ctTextPlain},
+		DoCobertura:      {rg.DoCobertura, ctApplicationXML},
+		DoLLVMCovJSON:    {rg.DoLLVMCovJSON, ctApplicationJSON},
+		DoLinePrograms:   {rg.DoLinePrograms, ctApplicationJSON},
+		DoCoverDiff: {func(w io.Writer, params cover.HandlerParams) error {
+			return rg.DoCoverDiff(w, rg, baseParams, params)
+		}, ctTextPlain},
 	}
 
 	if ct := flagToFunc[funcFlag].contentType; ct != "" {
@@ -852,6 +917,93 @@ func (serv *HTTPServer) httpRawCoverFiles(w http.ResponseWriter, r *http.Request
 	serv.httpCoverCover(w, r, DoRawCoverFiles)
 }
 
+func (serv *HTTPServer) httpCoverDiff(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		serv.httpCoverCover(w, r, DoCoverDiff)
+		return
+	}
+	data := &UITextPage{
+		UIPageHeader: serv.pageHeader(r, "coverage diff"),
+		HTML: `<form method="post" enctype="multipart/form-data">
+Compare the current coverage with an earlier <a href="/rawcover">/rawcover</a> snapshot:
+<input type="file" name="base">
+<input type="submit" value="Compare">
+</form>`,
+	}
+	executeTemplate(w, textTemplate, data)
+}
+
+// readRawCoverForm reads PCs from an uploaded file in the /rawcover format (one hex PC per line).
+func readRawCoverForm(r *http.Request, name string) ([]uint64, error) {
+	file, _, err := r.FormFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the uploaded %v file: %w", name, err)
+	}
+	defer file.Close()
+	var pcs []uint64
+	s := bufio.NewScanner(file)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		pc, err := strconv.ParseUint(line, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad PC %q in the uploaded %v file: %w", line, name, err)
+		}
+		pcs = append(pcs, pc)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return pcs, nil
+}
+
+func (serv *HTTPServer) httpCoverTimeline(w http.ResponseWriter, r *http.Request) {
+	if serv.CoverTimeline == nil {
+		http.Error(w, "coverage is not enabled", http.StatusInternalServerError)
+		return
+	}
+	coverInfo := serv.Cover.Load()
+	if coverInfo == nil {
+		http.Error(w, "coverage is not ready, please try again later after fuzzer started", http.StatusInternalServerError)
+		return
+	}
+	rg, err := coverInfo.ReportGenerator.Get()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to generate coverage profile: %v", err), http.StatusInternalServerError)
+		return
+	}
+	funcs := rg.FunctionTimeline(serv.CoverTimeline)
+	if r.FormValue("json") == "1" {
+		w.Header().Set("Content-Type", ctApplicationJSON)
+		if err := json.NewEncoder(w).Encode(funcs); err != nil {
+			http.Error(w, fmt.Sprintf("failed to encode the timeline: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+	hours := 24
+	if val := r.FormValue("hours"); val != "" {
+		if hours, err = strconv.Atoi(val); err != nil || hours <= 0 {
+			http.Error(w, "bad hours", http.StatusBadRequest)
+			return
+		}
+	}
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+	data := &UICoverTimeline{
+		UIPageHeader: serv.pageHeader(r, "coverage timeline"),
+		Days:         serv.CoverTimeline.Buckets(24 * time.Hour),
+		Hours:        hours,
+	}
+	for _, fn := range funcs {
+		if fn.FirstCovered.Before(since) {
+			break
+		}
+		data.Functions = append(data.Functions, fn)
+	}
+	executeTemplate(w, coverTimelineTemplate, data)
+}
+
 func (serv *HTTPServer) httpFilterPCs(w http.ResponseWriter, r *http.Request) {
 	serv.httpCoverCover(w, r, DoFilterPCs)
 }
@@ -998,13 +1150,27 @@ type UIDiffTable struct {
 
 type UIVMData struct {
 	UIPageHeader
-	VMs []UIVMInfo
+	Autoscale *UIAutoscale
+	VMs       []UIVMInfo
+}
+
+type UIAutoscale struct {
+	Active        int
+	Min           int
+	Max           int
+	Reason        string
+	Since         time.Duration
+	HostLoad      string
+	ReproVMs      int
+	TriageBacklog int
 }
 
 type UIVMInfo struct {
 	Name           string
 	State          string
 	Since          time.Duration
+	Health         string
+	HealthEvents   []string
 	MachineInfo    string
 	DetailedStatus string
 }
@@ -1167,6 +1333,13 @@ type UIJobInfo struct {
 	Execs int32
 }
 
+type UICoverTimeline struct {
+	UIPageHeader
+	Days      []cover.TimelineBucket
+	Hours     int
+	Functions []cover.FunctionCover
+}
+
 type UITextPage struct {
 	UIPageHeader
 	Text []byte
@@ -1184,6 +1357,7 @@ var (
 	rawCoverTemplate      = createPage("raw_cover", UIRawCoverPage{})
 	jobListTemplate       = createPage("job_list", UIJobList{})
 	textTemplate          = createPage("text", UITextPage{})
+	coverTimelineTemplate = createPage("cover_timeline", UICoverTimeline{})
 )
 
 //go:embed html/*.html
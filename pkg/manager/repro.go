@@ -111,6 +111,13 @@ func (r *ReproLoop) Empty() bool {
 	return len(r.reproducing) == 0 && len(r.queue) == 0
 }
 
+// NeededVMs returns the number of VMs that would be needed to process all pending reproductions.
+func (r *ReproLoop) NeededVMs() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.neededVMsLocked()
+}
+
 func (r *ReproLoop) Enqueue(crash *Crash) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -270,6 +277,10 @@ func (r *ReproLoop) handle(ctx context.Context, crash *Crash) {
 }
 
 func (r *ReproLoop) adjustPoolSizeLocked() {
+	r.mgr.ResizeReproPool(r.neededVMsLocked())
+}
+
+func (r *ReproLoop) neededVMsLocked() int {
 	// Avoid the +-1 jitter by considering the repro queue size as well.
 	// We process same-titled crashes sequentially, so only count unique ones.
 	uniqueTitles := maps.Clone(r.reproducing)
@@ -278,6 +289,5 @@ func (r *ReproLoop) adjustPoolSizeLocked() {
 	}
 
 	needRepros := len(uniqueTitles)
-	VMs := min(r.reproVMs, r.calculateReproVMs(needRepros))
-	r.mgr.ResizeReproPool(VMs)
+	return min(r.reproVMs, r.calculateReproVMs(needRepros))
 }
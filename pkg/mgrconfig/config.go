@@ -92,6 +92,12 @@ type Config struct {
 	//  - "4.19/kasan"
 	HubDomain string `json:"hub_domain,omitempty"`
 
+	// Address of a syz-coordinator instance shared by several managers fuzzing the same kernel (optional).
+	// The managers exchange new max signal, corpus inputs and titles of crashes being reproduced
+	// through the coordinator, so they behave like one large fuzzer and don't triage and
+	// reproduce the same things several times. Requires a unique manager name.
+	CoordinatorAddr string `json:"coordinator_addr,omitempty"`
+
 	// List of email addresses to receive notifications when bugs are encountered for the first time (optional).
 	// Mailx is the only supported mailer. Please set it up prior to using this function.
 	EmailAddrs []string `json:"email_addrs,omitempty"`
@@ -163,6 +169,18 @@ type Config struct {
 	// By default the value is 0, i.e. all VMs can be used for all purposes.
 	FuzzingVMs int `json:"fuzzing_vms,omitempty"`
 
+	// Dynamically adjust the number of running VMs within the given bounds (optional).
+	// The decisions are based on the host load, the number of pending reproductions
+	// and the triage backlog. Can be helpful on shared hosts to use the idle CPUs
+	// without overcommitting the busy ones.
+	// The bounds cannot exceed the VM count. A sample config:
+	// {
+	//    "min_vms": 2,
+	//    "max_vms": 16,
+	//    "max_host_load": 0.9
+	// }
+	Autoscale *AutoscaleConfig `json:"autoscale,omitempty"`
+
 	// Keep existing programs in the corpus even if they no longer pass syscall filters.
 	// By default it is true, as this is the desired behavior when executing syzkaller
 	// locally.
@@ -245,6 +263,10 @@ type Experimental struct {
 	// Hash adjacent PCs to form fuzzing feedback signal, otherwise use PCs as signal (default: true).
 	CoverEdges bool `json:"cover_edges"`
 
+	// Make syz-executor send signal and cover in a delta-encoded varint form.
+	// Reduces the manager CPU and network load for large numbers of VMs (default: false).
+	CompactCover bool `json:"compact_cover"`
+
 	// Use automatically (auto) generated or manually (manual) written descriptions or any (any) (default: manual)
 	DescriptionsMode string `json:"descriptions_mode"`
 
@@ -257,6 +279,21 @@ type Experimental struct {
 	FocusAreas []FocusArea `json:"focus_areas,omitempty"`
 }
 
+type AutoscaleConfig struct {
+	// The minimum number of running VMs (default: 1).
+	MinVMs int `json:"min_vms"`
+	// The maximum number of running VMs (default: the VM count).
+	MaxVMs int `json:"max_vms"`
+	// The host is considered overloaded when the 1-minute load average
+	// per CPU exceeds this value (default: 0.9).
+	MaxHostLoad float64 `json:"max_host_load"`
+	// The number of inputs waiting for triage that makes the manager
+	// start more VMs (default: 1000).
+	TriageBacklog int `json:"triage_backlog"`
+	// How often the decision is re-evaluated, in seconds (default: 60).
+	Interval int `json:"interval"`
+}
+
 type FocusArea struct {
 	// Name allows to display detailed statistics for every focus area.
 	Name string `json:"name"`
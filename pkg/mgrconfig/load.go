@@ -193,6 +193,9 @@ func Complete(cfg *Config) error {
 	if cfg.FuzzingVMs < 0 {
 		return fmt.Errorf("fuzzing_vms cannot be less than 0")
 	}
+	if err := cfg.completeAutoscale(); err != nil {
+		return err
+	}
 
 	var err error
 	cfg.Syscalls, err = ParseEnabledSyscalls(cfg.Target, cfg.EnabledSyscalls, cfg.DisabledSyscalls,
@@ -221,6 +224,11 @@ func (cfg *Config) completeServices() error {
 			return err
 		}
 	}
+	if cfg.CoordinatorAddr != "" {
+		if err := checkNonEmpty(cfg.Name, "name"); err != nil {
+			return err
+		}
+	}
 	if cfg.HubDomain != "" &&
 		!regexp.MustCompile(`^[a-zA-Z0-9-_.]{2,50}(/[a-zA-Z0-9-_.]{2,50})?$`).MatchString(cfg.HubDomain) {
 		return fmt.Errorf("bad value for hub_domain")
@@ -361,6 +369,32 @@ func (cfg *Config) completeBinaries() error {
 	return nil
 }
 
+func (cfg *Config) completeAutoscale() error {
+	as := cfg.Autoscale
+	if as == nil {
+		return nil
+	}
+	if as.MinVMs == 0 {
+		as.MinVMs = 1
+	}
+	if as.MaxHostLoad == 0 {
+		as.MaxHostLoad = 0.9
+	}
+	if as.TriageBacklog == 0 {
+		as.TriageBacklog = 1000
+	}
+	if as.Interval == 0 {
+		as.Interval = 60
+	}
+	if as.MinVMs < 1 || as.MaxVMs < 0 || as.MaxVMs != 0 && as.MaxVMs < as.MinVMs {
+		return fmt.Errorf("bad autoscale bounds: min_vms=%v max_vms=%v", as.MinVMs, as.MaxVMs)
+	}
+	if as.MaxHostLoad < 0 || as.TriageBacklog < 0 || as.Interval < 0 {
+		return fmt.Errorf("autoscale parameters cannot be negative")
+	}
+	return nil
+}
+
 func (cfg *Config) completeFocusAreas() error {
 	names := map[string]bool{}
 	seenEmptyFilter := false
@@ -26,6 +26,10 @@ func SystemMemorySize() uint64 {
 	return 0
 }
 
+func SystemLoad() float64 {
+	return 0
+}
+
 func ProcessExitStatus(ps *os.ProcessState) int {
 	// TODO: can be extracted from ExitStatus string.
 	return 0
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -45,6 +46,13 @@ func SystemMemorySize() uint64 {
 	return uint64(info.Totalram) // nolint:unconvert
 }
 
+// SystemLoad returns the 1-minute load average of the host divided by the number of CPUs.
+func SystemLoad() float64 {
+	var info syscall.Sysinfo_t
+	syscall.Sysinfo(&info)
+	return float64(info.Loads[0]) / (1 << unix.SI_LOAD_SHIFT) / float64(runtime.NumCPU())
+}
+
 func removeImmutable(fname string) error {
 	// Reset FS_XFLAG_IMMUTABLE/FS_XFLAG_APPEND.
 	fd, err := syscall.Open(fname, syscall.O_RDONLY, 0)
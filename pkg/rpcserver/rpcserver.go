@@ -42,6 +42,8 @@ type Config struct {
 	VMLess bool
 	// Hash adjacent PCs to form fuzzing feedback signal (otherwise just use coverage PCs as signal).
 	UseCoverEdges bool
+	// Ask executor to send signal and cover in the compact encoding.
+	CompactCover bool
 	// Filter signal/comparisons against target kernel text/data ranges.
 	// Disabled for gVisor/Starnix which are not Linux.
 	FilterSignal      bool
@@ -176,6 +178,7 @@ func New(cfg *RemoteConfig) (Server, error) {
 		VMLess: cfg.VMLess,
 		// gVisor coverage is not a trace, so producing edges won't work.
 		UseCoverEdges: cfg.Experimental.CoverEdges && cfg.Type != targets.GVisor,
+		CompactCover:  cfg.Experimental.CompactCover,
 		// gVisor/Starnix are not Linux, so filtering against Linux ranges won't work.
 		FilterSignal:      cfg.Type != targets.GVisor && cfg.Type != targets.Starnix,
 		PrintMachineCheck: true,
@@ -418,16 +421,8 @@ func (serv *server) connectionLoop(baseCtx context.Context, runner *Runner) erro
 	}()
 
 	if serv.cfg.Cover {
-		maxSignal := serv.mgr.MaxSignal().ToRaw()
-		for len(maxSignal) != 0 {
-			// Split coverage into batches to not grow the connection serialization
-			// buffer too much (we don't want to grow it larger than what will be needed
-			// to send programs).
-			n := min(len(maxSignal), 50000)
-			if err := runner.SendSignalUpdate(maxSignal[:n]); err != nil {
-				return err
-			}
-			maxSignal = maxSignal[n:]
+		if err := runner.SendMaxSignal(serv.mgr.MaxSignal().ToRaw()); err != nil {
+			return err
 		}
 	}
 
@@ -538,6 +533,7 @@ func (serv *server) CreateInstance(id int, injectExec chan<- bool, updInfo dispa
 		source:        serv.execSource,
 		cover:         serv.cfg.Cover,
 		coverEdges:    serv.cfg.UseCoverEdges,
+		compactCover:  serv.cfg.CompactCover,
 		filterSignal:  serv.cfg.FilterSignal,
 		debug:         serv.cfg.Debug,
 		debugTimeouts: serv.cfg.DebugTimeouts,
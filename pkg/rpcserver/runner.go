@@ -30,6 +30,7 @@ type Runner struct {
 	procs         int
 	cover         bool
 	coverEdges    bool
+	compactCover  bool
 	filterSignal  bool
 	debug         bool
 	debugTimeouts bool
@@ -95,6 +96,7 @@ func (runner *Runner) Handshake(conn *flatrpc.Conn, cfg *handshakeConfig) (hands
 		Debug:            runner.debug,
 		Cover:            runner.cover,
 		CoverEdges:       runner.coverEdges,
+		CompactCover:     runner.compactCover,
 		Kernel64Bit:      runner.sysTarget.PtrSize == 8,
 		Procs:            int32(runner.procs),
 		Slowdown:         int32(cfg.Timeouts.Slowdown),
@@ -415,6 +417,9 @@ func (runner *Runner) handleExecResult(msg *flatrpc.ExecResult) error {
 		if msg.Info.Freshness == 0 {
 			runner.stats.statExecutorRestarts.Add(1)
 		}
+		if err := unpackProgInfo(msg.Info); err != nil {
+			return fmt.Errorf("failed to unpack result of request %v: %w", msg.Id, err)
+		}
 		for _, call := range msg.Info.Calls {
 			runner.convertCallInfo(call)
 		}
@@ -462,6 +467,21 @@ func (runner *Runner) handleExecResult(msg *flatrpc.ExecResult) error {
 	return nil
 }
 
+// unpackProgInfo decodes signal and cover sent in the compact form (see ConnectReply.CompactCover).
+func unpackProgInfo(info *flatrpc.ProgInfo) error {
+	for _, call := range info.Calls {
+		if err := call.Unpack(); err != nil {
+			return err
+		}
+	}
+	for _, call := range info.ExtraRaw {
+		if err := call.Unpack(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (runner *Runner) convertCallInfo(call *flatrpc.CallInfo) {
 	call.Cover = runner.canonicalizer.Canonicalize(call.Cover)
 	call.Signal = runner.canonicalizer.Canonicalize(call.Signal)
@@ -526,6 +546,43 @@ func (runner *Runner) SendSignalUpdate(plus []uint64) error {
 	return flatrpc.Send(runner.conn, msg)
 }
 
+// SendMaxSignal sends the whole max signal to a newly connected executor.
+// With the compact cover encoding a large max signal is sent as a bloom filter (see flatrpc.MaxSignalBloom),
+// which has fixed size regardless of the amount of signal.
+func (runner *Runner) SendMaxSignal(signal []uint64) error {
+	// Split coverage into batches to not grow the connection serialization
+	// buffer too much (we don't want to grow it larger than what will be needed
+	// to send programs).
+	const batch = 50000
+	if runner.compactCover && len(signal)*8 > flatrpc.MaxSignalBloomSize {
+		bloom := flatrpc.MaxSignalBloom(runner.canonicalizer.Decanonicalize(signal))
+		for off := 0; off < len(bloom); off += batch * 8 {
+			end := min(len(bloom), off+batch*8)
+			msg := &flatrpc.HostMessage{
+				Msg: &flatrpc.HostMessages{
+					Type: flatrpc.HostMessagesRawSignalUpdate,
+					Value: &flatrpc.SignalUpdate{
+						Bloom:       bloom[off:end],
+						BloomOffset: uint32(off),
+					},
+				},
+			}
+			if err := flatrpc.Send(runner.conn, msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for len(signal) != 0 {
+		n := min(len(signal), batch)
+		if err := runner.SendSignalUpdate(signal[:n]); err != nil {
+			return err
+		}
+		signal = signal[n:]
+	}
+	return nil
+}
+
 func (runner *Runner) SendCorpusTriaged() error {
 	msg := &flatrpc.HostMessage{
 		Msg: &flatrpc.HostMessages{
@@ -13,6 +13,7 @@ import (
 	"os/exec"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -382,6 +383,40 @@ func (git *gitRepo) LatestCommits(afterCommit string, afterDate time.Time) ([]Co
 	return ret, nil
 }
 
+func (git *gitRepo) ListCommits(base, head string, limit int) ([]*Commit, error) {
+	output, err := git.Run("log", "--no-merges", "--name-only", "--format=%x00%H %at %s",
+		fmt.Sprintf("-n%v", limit), base+".."+head)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*Commit
+	for _, entry := range strings.Split(string(output), "\x00")[1:] {
+		lines := strings.Split(strings.TrimSpace(entry), "\n")
+		parts := strings.SplitN(lines[0], " ", 3)
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("unexpected git log output: %q", lines[0])
+		}
+		date, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date in %q: %w", lines[0], err)
+		}
+		com := &Commit{
+			Hash: parts[0],
+			Date: time.Unix(date, 0),
+		}
+		if len(parts) == 3 {
+			com.Title = parts[2]
+		}
+		for _, file := range lines[1:] {
+			if file != "" {
+				com.Files = append(com.Files, file)
+			}
+		}
+		ret = append(ret, com)
+	}
+	return ret, nil
+}
+
 func (git *gitRepo) ExtractFixTagsFromCommits(baseCommit, email string) ([]*Commit, error) {
 	user, domain, err := splitEmail(email)
 	if err != nil {
@@ -411,17 +446,44 @@ func splitEmail(email string) (user, domain string, err error) {
 
 func (git *gitRepo) Bisect(bad, good string, dt debugtracer.DebugTracer, pred func() (BisectResult,
 	error)) ([]*Commit, error) {
+	return git.bisect(bad, good, false, dt, pred)
+}
+
+func (git *gitRepo) BisectFirstParent(bad, good string, dt debugtracer.DebugTracer,
+	pred func() (BisectResult, error)) (*Commit, []*Commit, error) {
+	commits, err := git.bisect(bad, good, true, dt, pred)
+	if err != nil || len(commits) != 1 || len(commits[0].Parents) < 2 {
+		return nil, commits, err
+	}
+	merge := commits[0]
+	// The merge is bad and its first parent is good, so the culprit is among the merged commits
+	// (or it's the merge itself if the merged commits are fine on their own).
+	dt.Log("# descending into merge %v %v", merge.Hash, merge.Title)
+	commits, err = git.bisect(merge.Hash, merge.Parents[0], false, dt, pred)
+	if err != nil {
+		return nil, nil, err
+	}
+	return merge, commits, nil
+}
+
+func (git *gitRepo) bisect(bad, good string, firstParent bool, dt debugtracer.DebugTracer,
+	pred func() (BisectResult, error)) ([]*Commit, error) {
 	git.Reset()
 	firstBad, err := git.Commit(bad)
 	if err != nil {
 		return nil, err
 	}
-	output, err := git.Run("bisect", "start", bad, good)
+	args := []string{"bisect", "start"}
+	if firstParent {
+		args = append(args, "--first-parent")
+	}
+	args = append(args, bad, good)
+	output, err := git.Run(args...)
 	if err != nil {
 		return nil, err
 	}
 	defer git.Reset()
-	dt.Log("# git bisect start %v %v\n%s", bad, good, output)
+	dt.Log("# git %v\n%s", strings.Join(args, " "), output)
 	current, err := git.Commit(HEAD)
 	if err != nil {
 		return nil, err
@@ -460,6 +522,21 @@ func (git *gitRepo) Bisect(bad, good string, dt debugtracer.DebugTracer, pred fu
 	}
 }
 
+func (git *gitRepo) BisectRange(bad string, good []string, paths ...string) ([]string, error) {
+	args := []string{"rev-list", "--topo-order", "--reverse", bad}
+	for _, com := range good {
+		args = append(args, "^"+com)
+	}
+	if len(paths) != 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	output, err := git.Run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
 var gitFullHashRe = regexp.MustCompile("[a-f0-9]{40}")
 
 func (git *gitRepo) bisectInconclusive(output []byte) ([]*Commit, error) {
@@ -401,3 +401,146 @@ func TestBisect(t *testing.T) {
 		}
 	}
 }
+
+func TestBisectFirstParent(t *testing.T) {
+	t.Parallel()
+	repoDir := t.TempDir()
+	repo := MakeTestRepo(t, repoDir)
+	repo.Git("checkout", "-b", "master")
+	good := repo.CommitChange("good")
+	repo.Git("checkout", "-b", "series")
+	var series []*Commit
+	for i := 0; i < 4; i++ {
+		series = append(series, repo.CommitChange(fmt.Sprintf("series %v", i)))
+	}
+	repo.Git("checkout", "master")
+	direct := repo.CommitChange("direct")
+	repo.Git("merge", "--no-ff", "-m", "merge series", "series")
+	merge, err := repo.repo.Commit(HEAD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad := repo.CommitChange("bad")
+	tests := []struct {
+		culprit *Commit
+		merge   *Commit
+		result  []*Commit
+	}{
+		{series[0], merge, []*Commit{series[0]}},
+		// The skipped commit may also be the culprit.
+		{series[2], merge, []*Commit{series[1], series[2]}},
+		{series[3], merge, []*Commit{series[3]}},
+		{direct, nil, []*Commit{direct}},
+		{bad, nil, []*Commit{bad}},
+	}
+	for _, test := range tests {
+		pred := func() (BisectResult, error) {
+			current, err := repo.repo.Commit(HEAD)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if current.Hash == series[1].Hash {
+				// Unbuildable commit in the middle of the series.
+				return BisectSkip, nil
+			}
+			hasBug, err := repo.repo.Contains(test.culprit.Hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hasBug {
+				return BisectBad, nil
+			}
+			return BisectGood, nil
+		}
+		gotMerge, commits, err := repo.repo.BisectFirstParent(bad.Hash, good.Hash,
+			&debugtracer.TestTracer{T: t}, pred)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (gotMerge == nil) != (test.merge == nil) || gotMerge != nil && gotMerge.Hash != test.merge.Hash {
+			t.Fatalf("culprit %q: got merge %+v, want %+v", test.culprit.Title, gotMerge, test.merge)
+		}
+		var got, want []string
+		for _, com := range commits {
+			got = append(got, com.Title)
+		}
+		for _, com := range test.result {
+			want = append(want, com.Title)
+		}
+		sort.Strings(got)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("culprit %q: %v", test.culprit.Title, diff)
+		}
+	}
+}
+
+func TestBisectRange(t *testing.T) {
+	t.Parallel()
+	repoDir := t.TempDir()
+	repo := MakeTestRepo(t, repoDir)
+	var commits []string
+	for i := 0; i < 5; i++ {
+		com := repo.CommitChange(fmt.Sprintf("commit %v", i))
+		commits = append(commits, com.Hash)
+	}
+	got, err := repo.repo.BisectRange(commits[4], []string{commits[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(commits[2:], got); diff != "" {
+		t.Fatal(diff)
+	}
+	got, err = repo.repo.BisectRange(commits[3], []string{commits[1], commits[2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(commits[3:4], got); diff != "" {
+		t.Fatal(diff)
+	}
+	repo.CommitFileChange("master", "touch")
+	touch := repo.Commits["master"]["touch"].Hash
+	repo.CommitChange("commit 5")
+	head := repo.CommitChange("commit 6").Hash
+	got, err = repo.repo.BisectRange(head, []string{commits[1]}, "file", "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{touch}, got); diff != "" {
+		t.Fatal(diff)
+	}
+	got, err = repo.repo.BisectRange(head, []string{commits[1]}, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no commits, got %v", got)
+	}
+}
+
+func TestListCommits(t *testing.T) {
+	t.Parallel()
+	repoDir := t.TempDir()
+	repo := MakeTestRepo(t, repoDir)
+	base := repo.CommitChange("base")
+	repo.CommitFileChange("master", "touch")
+	head := repo.CommitChange("empty")
+	got, err := repo.repo.ListCommits(base.Hash, head.Hash, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	touch := repo.Commits["master"]["touch"]
+	want := []*Commit{
+		{Hash: head.Hash, Title: "empty", Date: head.Date},
+		{Hash: touch.Hash, Title: touch.Title, Date: touch.Date, Files: []string{"file"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+	got, err = repo.repo.ListCommits(base.Hash, head.Hash, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want[:1], got); diff != "" {
+		t.Fatal(diff)
+	}
+}
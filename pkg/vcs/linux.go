@@ -213,6 +213,15 @@ func (ctx *linux) Bisect(bad, good string, dt debugtracer.DebugTracer, pred func
 	return commits, err
 }
 
+func (ctx *linux) BisectFirstParent(bad, good string, dt debugtracer.DebugTracer,
+	pred func() (BisectResult, error)) (*Commit, []*Commit, error) {
+	merge, commits, err := ctx.gitRepo.BisectFirstParent(bad, good, dt, pred)
+	if len(commits) == 1 {
+		ctx.addMaintainers(commits[0])
+	}
+	return merge, commits, err
+}
+
 func (ctx *linux) addMaintainers(com *Commit) {
 	if len(com.Recipients) > 2 {
 		return
@@ -94,6 +94,19 @@ type Bisecter interface {
 	// or multiple commits if bisection is inconclusive due to BisectSkip.
 	Bisect(bad, good string, dt debugtracer.DebugTracer, pred func() (BisectResult, error)) ([]*Commit, error)
 
+	// BisectFirstParent is like Bisect, but it first bisects only the first-parent chain of bad
+	// (i.e. the merges into the bad branch, which are usually buildable), and then descends
+	// into the commits brought in by the culprit merge. It returns the culprit merge
+	// (nil if the culprit was committed directly to the branch) and the Bisect result.
+	BisectFirstParent(bad, good string, dt debugtracer.DebugTracer,
+		pred func() (BisectResult, error)) (*Commit, []*Commit, error)
+
+	// BisectRange returns hashes of commits that are reachable from bad, but not from any of good
+	// (i.e. the candidates for the first bad commit). The commits are in the reverse
+	// topological order (parents go before children).
+	// If paths are specified, only commits that touch any of the paths are returned.
+	BisectRange(bad string, good []string, paths ...string) ([]string, error)
+
 	// PreviousReleaseTags returns list of preceding release tags that are reachable from the given commit.
 	// If the commit itself has a release tag, this tag is not included.
 	PreviousReleaseTags(commit, compilerType string) ([]string, error)
@@ -104,6 +117,13 @@ type Bisecter interface {
 		kernelConfig []byte, backports []BackportCommit) (*BisectEnv, error)
 }
 
+// CommitLister may be optionally implemented by Repo.
+type CommitLister interface {
+	// ListCommits returns up to limit non-merge commits that are reachable from head, but not from base,
+	// the newest commits go first. Only Hash, Title, Date and Files are filled in.
+	ListCommits(base, head string, limit int) ([]*Commit, error)
+}
+
 type ConfigMinimizer interface {
 	Minimize(target *targets.Target, original, baseline []byte, types []crash.Type,
 		dt debugtracer.DebugTracer, pred func(test []byte) (BisectResult, error)) ([]byte, error)
@@ -120,6 +140,7 @@ type Commit struct {
 	Date       time.Time
 	CommitDate time.Time
 	Patch      []byte
+	Files      []string // files touched by the commit, filled in only by CommitLister
 }
 
 type CommitShort struct {
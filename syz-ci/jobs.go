@@ -504,11 +504,15 @@ func (jp *JobProcessor) bisect(job *Job, mgrcfg *mgrconfig.Config) error {
 			Commit: req.SyzkallerCommit,
 		},
 		Repro: bisect.ReproConfig{
-			Opts: req.ReproOpts,
-			Syz:  req.ReproSyz,
-			C:    req.ReproC,
+			Opts:        req.ReproOpts,
+			Syz:         req.ReproSyz,
+			C:           req.ReproC,
+			Reliability: req.ReproReliability,
 		},
 		CrossTree:      req.MergeBaseRepo != "",
+		Parallel:       jp.cfg.BisectParallel,
+		FirstParent:    mgr.mgrcfg.BisectFirstParent,
+		Paths:          req.BisectPaths,
 		Manager:        mgrcfg,
 		BuildSemaphore: buildSem,
 		TestSemaphore:  testSem,
@@ -523,6 +527,7 @@ func (jp *JobProcessor) bisect(job *Job, mgrcfg *mgrconfig.Config) error {
 		}
 		return err
 	}
+	resp.Confidence = res.Confidence
 	for _, com := range res.Commits {
 		resp.Commits = append(resp.Commits, dashapi.Commit{
 			Hash:       com.Hash,
@@ -534,6 +539,15 @@ func (jp *JobProcessor) bisect(job *Job, mgrcfg *mgrconfig.Config) error {
 		})
 	}
 	if len(res.Commits) == 1 {
+		if com := res.Merge; com != nil {
+			resp.Merge = &dashapi.Commit{
+				Hash:       com.Hash,
+				Title:      com.Title,
+				Author:     com.Author,
+				AuthorName: com.AuthorName,
+				Date:       com.Date,
+			}
+		}
 		if len(res.Commits[0].Parents) > 1 {
 			resp.Flags |= dashapi.BisectResultMerge
 		}
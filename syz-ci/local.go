@@ -0,0 +1,213 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/config"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/prog"
+)
+
+// LocalJob describes a patch testing or bisection job that is executed without a dashboard
+// (see -local_job flag). All file names are relative to the job file.
+type LocalJob struct {
+	// One of "test_patch" (default), "bisect_cause", "bisect_fix".
+	Type string `json:"type"`
+	// Name of the syz-ci manager whose config is used (optional if there is only one manager).
+	Manager string `json:"manager"`
+	// Kernel repo and branch or commit hash (for patch testing defaults to the manager's repo/branch).
+	// Bisection starts from KernelCommit.
+	KernelRepo   string `json:"kernel_repo"`
+	KernelBranch string `json:"kernel_branch"`
+	KernelCommit string `json:"kernel_commit"`
+	// Files with the kernel config (defaults to the manager's kernel config) and the patch to test.
+	KernelConfig string `json:"kernel_config"`
+	Patch        string `json:"patch"`
+	// Files with the reproducer (repro.opts, repro.prog and repro.cprog in the dashboard terms).
+	ReproOpts string `json:"repro_opts"`
+	ReproSyz  string `json:"repro_syz"`
+	ReproC    string `json:"repro_c"`
+	// Defaults to the revision of the syz-ci binary.
+	SyzkallerCommit string `json:"syzkaller_commit"`
+}
+
+// LocalJobResult is saved as result.json in the output dir.
+// Large outputs are saved as separate files next to it.
+type LocalJobResult struct {
+	Type              string
+	KernelRepo        string
+	KernelBranch      string
+	KernelCommit      string
+	KernelCommitTitle string
+	CompilerID        string
+	// Empty if the kernel did not crash.
+	CrashTitle string
+	// Set if the job has failed (see error.txt).
+	Failed bool
+	// Bisection results.
+	Commits    []dashapi.Commit
+	Merge      *dashapi.Commit
+	Flags      string
+	Confidence float64
+}
+
+var localJobTypes = map[string]dashapi.JobType{
+	"":             dashapi.JobTestPatch,
+	"test_patch":   dashapi.JobTestPatch,
+	"bisect_cause": dashapi.JobBisectCause,
+	"bisect_fix":   dashapi.JobBisectFix,
+}
+
+// runLocalJob runs the job described in jobFile exactly as if it was received from the dashboard
+// and saves the results into outDir.
+func runLocalJob(cfg *Config, jobFile, outDir string, shutdownPending chan struct{}) error {
+	job := new(LocalJob)
+	if err := config.LoadFile(jobFile, job); err != nil {
+		return err
+	}
+	var mgrcfg *ManagerConfig
+	for _, mc := range cfg.Managers {
+		if mc.Name == job.Manager || job.Manager == "" && len(cfg.Managers) == 1 {
+			mgrcfg = mc
+		}
+	}
+	if mgrcfg == nil {
+		return fmt.Errorf("unknown manager %q", job.Manager)
+	}
+	req, err := job.pollResp(filepath.Dir(jobFile), mgrcfg)
+	if err != nil {
+		return err
+	}
+	// The job may run next to a live syz-ci instance in the same dir,
+	// so don't touch the state of its managers.
+	baseDir := osutil.Abs("jobs-local")
+	mgr, err := newManager(cfg, mgrcfg, filepath.Join(baseDir, "managers", mgrcfg.Name), false)
+	if err != nil {
+		return err
+	}
+	jp := &JobProcessor{
+		JobManager: &JobManager{
+			cfg:             cfg,
+			managers:        []*Manager{mgr},
+			shutdownPending: shutdownPending,
+		},
+		name:           cfg.Name + "-local",
+		instanceSuffix: "-local",
+		baseDir:        baseDir,
+	}
+	jp.Logf(0, "starting local job type %v for manager %v on %v/%v",
+		req.Type, mgr.name, req.KernelRepo, req.KernelBranch)
+	resp := jp.process(&Job{req: req, mgr: mgr})
+	jp.Logf(0, "done local job: commit %v, crash %q, error: %s",
+		resp.Build.KernelCommit, resp.CrashTitle, resp.Error)
+	return saveLocalJobResult(outDir, job.Type, resp)
+}
+
+func (job *LocalJob) pollResp(dir string, mgrcfg *ManagerConfig) (*dashapi.JobPollResp, error) {
+	typ, ok := localJobTypes[job.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown job type %q", job.Type)
+	}
+	req := &dashapi.JobPollResp{
+		ID:              "local",
+		Type:            typ,
+		Manager:         mgrcfg.Name,
+		KernelRepo:      job.KernelRepo,
+		KernelBranch:    job.KernelBranch,
+		KernelCommit:    job.KernelCommit,
+		SyzkallerCommit: job.SyzkallerCommit,
+	}
+	if req.SyzkallerCommit == "" {
+		req.SyzkallerCommit = prog.GitRevisionBase
+	}
+	if typ != dashapi.JobTestPatch && req.KernelCommit == "" {
+		return nil, fmt.Errorf("kernel_commit is required for bisection")
+	}
+	kernelConfig := job.KernelConfig
+	if kernelConfig == "" {
+		kernelConfig = mgrcfg.KernelConfig
+	} else {
+		kernelConfig = localJobFile(dir, kernelConfig)
+	}
+	files := []struct {
+		name string
+		data *[]byte
+	}{
+		{kernelConfig, &req.KernelConfig},
+		{localJobFile(dir, job.Patch), &req.Patch},
+		{localJobFile(dir, job.ReproOpts), &req.ReproOpts},
+		{localJobFile(dir, job.ReproSyz), &req.ReproSyz},
+		{localJobFile(dir, job.ReproC), &req.ReproC},
+	}
+	for _, file := range files {
+		if file.name == "" {
+			continue
+		}
+		data, err := os.ReadFile(file.name)
+		if err != nil {
+			return nil, err
+		}
+		*file.data = data
+	}
+	return req, nil
+}
+
+func localJobFile(dir, file string) string {
+	if file == "" || filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(dir, file)
+}
+
+func saveLocalJobResult(dir, typ string, resp *dashapi.JobDoneReq) error {
+	if err := osutil.MkdirAll(dir); err != nil {
+		return err
+	}
+	res := &LocalJobResult{
+		Type:              typ,
+		KernelRepo:        resp.Build.KernelRepo,
+		KernelBranch:      resp.Build.KernelBranch,
+		KernelCommit:      resp.Build.KernelCommit,
+		KernelCommitTitle: resp.Build.KernelCommitTitle,
+		CompilerID:        resp.Build.CompilerID,
+		CrashTitle:        resp.CrashTitle,
+		Failed:            len(resp.Error) != 0,
+		Commits:           resp.Commits,
+		Merge:             resp.Merge,
+		Flags:             resp.Flags.String(),
+		Confidence:        resp.Confidence,
+	}
+	if res.Type == "" {
+		res.Type = "test_patch"
+	}
+	data, err := json.MarshalIndent(res, "", "\t")
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		"result.json":      data,
+		"error.txt":        resp.Error,
+		"bisect.log":       resp.Log,
+		"crash_log.txt":    resp.CrashLog,
+		"crash_report.txt": resp.CrashReport,
+		"kernel.config":    resp.Build.KernelConfig,
+	}
+	for name, data := range files {
+		if len(data) == 0 {
+			continue
+		}
+		if err := osutil.WriteFile(filepath.Join(dir, name), data); err != nil {
+			return err
+		}
+	}
+	log.Logf(0, "saved job results to %v", dir)
+	return nil
+}
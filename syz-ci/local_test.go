@@ -0,0 +1,98 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalJobPollResp(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"kernel.config":  "CONFIG_KASAN=y",
+		"fix.patch":      "diff --git a/a b/a",
+		"repro.opts":     "{}",
+		"repro.prog":     "getpid()",
+		"manager.config": "CONFIG_KCOV=y",
+	}
+	for name, data := range files {
+		require.NoError(t, osutil.WriteFile(filepath.Join(dir, name), []byte(data)))
+	}
+	mgrcfg := &ManagerConfig{
+		Name:         "ci-upstream",
+		KernelConfig: filepath.Join(dir, "manager.config"),
+	}
+
+	job := &LocalJob{
+		KernelRepo:      "git://repo",
+		KernelBranch:    "master",
+		KernelConfig:    "kernel.config",
+		Patch:           "fix.patch",
+		ReproOpts:       "repro.opts",
+		ReproSyz:        filepath.Join(dir, "repro.prog"),
+		SyzkallerCommit: "abcd",
+	}
+	req, err := job.pollResp(dir, mgrcfg)
+	require.NoError(t, err)
+	assert.Equal(t, &dashapi.JobPollResp{
+		ID:              "local",
+		Type:            dashapi.JobTestPatch,
+		Manager:         "ci-upstream",
+		KernelRepo:      "git://repo",
+		KernelBranch:    "master",
+		KernelConfig:    []byte("CONFIG_KASAN=y"),
+		SyzkallerCommit: "abcd",
+		Patch:           []byte("diff --git a/a b/a"),
+		ReproOpts:       []byte("{}"),
+		ReproSyz:        []byte("getpid()"),
+	}, req)
+
+	job = &LocalJob{
+		Type:         "bisect_fix",
+		KernelCommit: "1234",
+	}
+	req, err = job.pollResp(dir, mgrcfg)
+	require.NoError(t, err)
+	assert.Equal(t, dashapi.JobBisectFix, req.Type)
+	assert.Equal(t, []byte("CONFIG_KCOV=y"), req.KernelConfig)
+
+	_, err = (&LocalJob{Type: "bisect_cause"}).pollResp(dir, mgrcfg)
+	assert.Error(t, err)
+	_, err = (&LocalJob{Type: "fuzz"}).pollResp(dir, mgrcfg)
+	assert.Error(t, err)
+	_, err = (&LocalJob{Patch: "no-such-file"}).pollResp(dir, mgrcfg)
+	assert.Error(t, err)
+}
+
+func TestSaveLocalJobResult(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	resp := &dashapi.JobDoneReq{
+		Build: dashapi.Build{
+			KernelCommit: "1234",
+		},
+		CrashTitle:  "KASAN: use-after-free in foo",
+		CrashReport: []byte("report"),
+		CrashLog:    []byte("log"),
+	}
+	require.NoError(t, saveLocalJobResult(dir, "", resp))
+	data, err := os.ReadFile(filepath.Join(dir, "result.json"))
+	require.NoError(t, err)
+	res := new(LocalJobResult)
+	require.NoError(t, json.Unmarshal(data, res))
+	assert.Equal(t, "test_patch", res.Type)
+	assert.Equal(t, "1234", res.KernelCommit)
+	assert.Equal(t, "KASAN: use-after-free in foo", res.CrashTitle)
+	assert.False(t, res.Failed)
+	assert.FileExists(t, filepath.Join(dir, "crash_report.txt"))
+	assert.FileExists(t, filepath.Join(dir, "crash_log.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "error.txt"))
+}
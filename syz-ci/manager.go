@@ -105,7 +105,16 @@ type ManagerDashapi interface {
 }
 
 func createManager(cfg *Config, mgrcfg *ManagerConfig, debug bool) (*Manager, error) {
-	dir := osutil.Abs(filepath.Join("managers", mgrcfg.Name))
+	mgr, err := newManager(cfg, mgrcfg, osutil.Abs(filepath.Join("managers", mgrcfg.Name)), debug)
+	if err != nil {
+		return nil, err
+	}
+	os.RemoveAll(mgr.currentDir)
+	return mgr, nil
+}
+
+// newManager creates a manager with all its state in dir.
+func newManager(cfg *Config, mgrcfg *ManagerConfig, dir string, debug bool) (*Manager, error) {
 	err := osutil.MkdirAll(dir)
 	if err != nil {
 		log.Fatal(err)
@@ -161,8 +170,6 @@ func createManager(cfg *Config, mgrcfg *ManagerConfig, debug bool) (*Manager, er
 	if dash != nil {
 		mgr.dash = dash
 	}
-
-	os.RemoveAll(mgr.currentDir)
 	return mgr, nil
 }
 
@@ -628,6 +635,11 @@ func (mgr *Manager) uploadBuild(info *BuildInfo, imageDir string) (string, error
 	if err != nil {
 		return "", err
 	}
+	if mgr.lastBuild != nil {
+		build.KernelCommitsBase = mgr.lastBuild.KernelCommit
+		build.KernelCommits, build.KernelCommitsTruncated = mgr.listKernelCommits(
+			build.KernelCommitsBase, info.KernelCommit)
+	}
 	mgr.lastBuild = build
 	commitTitles, fixCommits, err := mgr.pollCommits(info.KernelCommit)
 	if err != nil {
@@ -745,6 +757,38 @@ func (mgr *Manager) pollCommits(buildCommit string) ([]string, []dashapi.Commit,
 	return present, fixCommits, nil
 }
 
+// listKernelCommits returns kernel commits between the previous and the new build
+// for dashboard to attribute new bugs to commit ranges.
+// The returned bool is set if there are more commits than we upload.
+func (mgr *Manager) listKernelCommits(base, head string) ([]dashapi.BuildCommit, bool) {
+	const (
+		maxCommits = 1000
+		maxFiles   = 20
+	)
+	lister, ok := mgr.repo.(vcs.CommitLister)
+	if !ok || base == "" || base == head || brokenRepo(mgr.mgrcfg.Repo) {
+		return nil, false
+	}
+	// Ask for one more commit to find out whether the list is truncated.
+	commits, err := lister.ListCommits(base, head, maxCommits+1)
+	if err != nil {
+		// This is not critical for operation.
+		mgr.Errorf("failed to list kernel commits: %v", err)
+		return nil, false
+	}
+	truncated := len(commits) > maxCommits
+	commits = commits[:min(len(commits), maxCommits)]
+	var ret []dashapi.BuildCommit
+	for _, com := range commits {
+		ret = append(ret, dashapi.BuildCommit{
+			Hash:  com.Hash,
+			Title: com.Title,
+			Files: com.Files[:min(len(com.Files), maxFiles)],
+		})
+	}
+	return ret, truncated
+}
+
 func (mgr *Manager) backportCommits() []vcs.BackportCommit {
 	return append(
 		append([]vcs.BackportCommit{}, mgr.cfg.BisectBackports...),
@@ -81,6 +81,8 @@ var (
 	flagDebug      = flag.Bool("debug", false, "debug mode (for testing)")
 	// nolint: lll
 	flagExitOnUpgrade = flag.Bool("exit-on-upgrade", false, "exit after a syz-ci upgrade is applied; otherwise syz-ci restarts")
+	flagLocalJob      = flag.String("local_job", "", "run a single job described in the file without dashboard and exit")
+	flagLocalJobOut   = flag.String("local_job_out", "job-result", "output dir for -local_job results")
 )
 
 type Config struct {
@@ -131,7 +133,11 @@ type Config struct {
 	// Extra commits to cherry-pick to older kernel revisions.
 	// The list is concatenated with the similar parameter from ManagerConfig.
 	BisectBackports []vcs.BackportCommit `json:"bisect_backports"`
-	Ccache          string               `json:"ccache"`
+	// BisectParallel is the number of commits built and tested concurrently during cause bisection
+	// (see pkg/bisect.Config.Parallel). Each extra commit needs its own kernel checkout
+	// next to the manager's one, so it multiplies the disk usage of bisection.
+	BisectParallel int    `json:"bisect_parallel"`
+	Ccache         string `json:"ccache"`
 	// BuildCPUs defines the maximum number of parallel kernel build threads.
 	BuildCPUs int              `json:"build_cpus"`
 	Managers  []*ManagerConfig `json:"managers"`
@@ -216,6 +222,9 @@ type ManagerConfig struct {
 	Jobs         ManagerJobs `json:"jobs"`
 	// Extra commits to cherry pick to older kernel revisions.
 	BisectBackports []vcs.BackportCommit `json:"bisect_backports"`
+	// Bisect merges into the branch first and then descend into the culprit merge
+	// (see pkg/bisect.Config.FirstParent). Useful for trees like linux-next.
+	BisectFirstParent bool `json:"bisect_first_parent"`
 	// Base syz-manager config for the instance.
 	ManagerConfig json.RawMessage `json:"manager_config"`
 	// By default we want to archive git commits.
@@ -263,8 +272,6 @@ func main() {
 	shutdownPending := make(chan struct{})
 	osutil.HandleInterrupts(shutdownPending)
 
-	serveHTTP(cfg)
-
 	os.Unsetenv("GOPATH")
 	if cfg.Goroot != "" {
 		os.Setenv("GOROOT", cfg.Goroot)
@@ -272,6 +279,15 @@ func main() {
 			string(filepath.ListSeparator)+os.Getenv("PATH"))
 	}
 
+	if *flagLocalJob != "" {
+		if err := runLocalJob(cfg, *flagLocalJob, *flagLocalJobOut, shutdownPending); err != nil {
+			log.Fatalf("local job failed: %v", err)
+		}
+		return
+	}
+
+	serveHTTP(cfg)
+
 	updatePending := make(chan struct{})
 	updater := NewSyzUpdater(cfg)
 	updater.UpdateOnStart(*flagAutoUpdate, shutdownPending)
@@ -0,0 +1,200 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/syz-cluster/pkg/api"
+	"github.com/google/syzkaller/syz-cluster/pkg/app"
+	"github.com/google/syzkaller/syz-cluster/pkg/controller"
+	"github.com/google/syzkaller/syz-cluster/pkg/db"
+	"github.com/google/syzkaller/syz-cluster/pkg/localseries"
+	"github.com/google/syzkaller/syz-cluster/pkg/workflow"
+)
+
+// The local mode runs the controller, the workflow steps and the database on the local machine.
+// Instead of polling the mailing lists, it tests a single patch series taken from the local sources.
+var (
+	flagMbox          = flag.String("mbox", "", "local: the patch series in the mbox format")
+	flagPatches       = flag.String("patches", "", "local: the folder with the git format-patch output")
+	flagRange         = flag.String("range", "", "local: the commit range in --repo (e.g. origin/master..my-branch)")
+	flagRepo          = flag.String("repo", "", "local: the kernel checkout")
+	flagWorkdir       = flag.String("workdir", "syz-cluster-local", "local: the working folder")
+	flagSteps         = flag.String("steps", "", "local: the step binaries folder (default: next to this binary)")
+	flagSyzkaller     = flag.String("syzkaller", "", "local: the syzkaller checkout with the built binaries")
+	flagConfigs       = flag.String("configs", "", "local: the syzkaller configs (syz-cluster/workflow/configs)")
+	flagKernelConfigs = flag.String("kernel_configs", "", "local: the folder with the kernel configs")
+	flagUserspace     = flag.String("userspace", "", "local: the userspace image for the kernel builds")
+	flagFuzzTime      = flag.Duration("fuzz_time", time.Hour, "local: how long to fuzz the patched kernel")
+	flagEmulator      = flag.String("spanner_emulator", "", "local: the Spanner emulator binary")
+)
+
+func runLocal(ctx context.Context) error {
+	sources := 0
+	for _, val := range []string{*flagMbox, *flagPatches, *flagRange} {
+		if val != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of --mbox, --patches and --range must be set")
+	}
+	if *flagRepo == "" || *flagSyzkaller == "" || *flagConfigs == "" ||
+		*flagKernelConfigs == "" || *flagUserspace == "" {
+		return fmt.Errorf("--repo, --syzkaller, --configs, --kernel_configs and --userspace must be set")
+	}
+	workdir, err := filepath.Abs(*flagWorkdir)
+	if err != nil {
+		return err
+	}
+	if err := osutil.MkdirAll(workdir); err != nil {
+		return err
+	}
+	series, err := readLocalSeries()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := setUpLocalDB(ctx); err != nil {
+		return fmt.Errorf("failed to set up the database: %w", err)
+	}
+	// The step subprocesses inherit the environment variables, so they also use the local services.
+	os.Setenv("LOCAL_BLOB_STORAGE_PATH", filepath.Join(workdir, "blobs"))
+	env, err := app.Environment(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set up environment: %w", err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	os.Setenv("CONTROLLER_API_URL", "http://"+ln.Addr().String())
+	go func() {
+		err := http.Serve(ln, controller.NewAPIServer(env).Mux())
+		app.Fatalf("listen failed: %v", err)
+	}()
+
+	steps := *flagSteps
+	if steps == "" {
+		bin, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		steps = filepath.Dir(bin)
+	}
+	workflows := workflow.NewLocalService(workflow.LocalConfig{
+		BinDir:        steps,
+		Repository:    *flagRepo,
+		Workdir:       workdir,
+		Configs:       *flagConfigs,
+		KernelConfigs: *flagKernelConfigs,
+		Userspace:     *flagUserspace,
+		Syzkaller:     *flagSyzkaller,
+		FuzzTime:      *flagFuzzTime,
+	})
+	sp := NewSeriesProcessor(env, &app.AppConfig{ParallelWorkflows: 1}, workflows)
+	sp.dbPollInterval = time.Second
+	go func() {
+		if err := sp.Loop(ctx); err != nil {
+			app.Errorf("processor loop failed: %v", err)
+		}
+	}()
+
+	sessionID, err := localseries.Upload(ctx, app.DefaultClient(), series)
+	if err != nil {
+		return err
+	}
+	log.Printf("testing %q in session %s, see %s for the logs",
+		series.Title, sessionID, filepath.Join(workdir, sessionID))
+	return waitLocalSession(ctx, env, sessionID)
+}
+
+func setUpLocalDB(ctx context.Context) error {
+	if os.Getenv("SPANNER_EMULATOR_HOST") == "" {
+		if *flagEmulator == "" {
+			return fmt.Errorf("either SPANNER_EMULATOR_HOST or --spanner_emulator must be set")
+		}
+		cmd, host, err := db.RunEmulator(*flagEmulator)
+		if err != nil {
+			return err
+		}
+		go func() {
+			<-ctx.Done()
+			cmd.Process.Kill()
+			cmd.Wait()
+		}()
+		os.Setenv("SPANNER_EMULATOR_HOST", host)
+	}
+	uri, err := db.ParseURI(fmt.Sprintf("projects/local/instances/local/databases/db%v", time.Now().Unix()))
+	if err != nil {
+		return err
+	}
+	if err := db.CreateSpannerInstance(ctx, uri); err != nil {
+		return err
+	}
+	if err := db.CreateSpannerDB(ctx, uri); err != nil {
+		return err
+	}
+	if err := db.RunMigrations(uri.Full); err != nil {
+		return err
+	}
+	os.Setenv("SPANNER_DATABASE_URI", uri.Full)
+	return nil
+}
+
+func waitLocalSession(ctx context.Context, env *app.AppEnvironment, sessionID string) error {
+	sessionRepo := db.NewSessionRepository(env.Spanner)
+	for {
+		session, err := sessionRepo.GetByID(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		if session.Status() == db.SessionStatusSkipped {
+			log.Printf("the series was skipped: %s", session.SkipReason.StringVal)
+			return nil
+		}
+		if session.Status() == db.SessionStatusFinished {
+			break
+		}
+		time.Sleep(10 * time.Second)
+	}
+	tests, err := db.NewSessionTestRepository(env.Spanner).BySessionRaw(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	for _, test := range tests {
+		log.Printf("test %q: %s", test.TestName, test.Result)
+	}
+	findings, err := db.NewFindingRepository(env.Spanner).ListForSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	for _, finding := range findings {
+		log.Printf("finding in %q: %s", finding.TestName, finding.Title)
+	}
+	log.Printf("found %d issues", len(findings))
+	return nil
+}
+
+func readLocalSeries() (*api.Series, error) {
+	switch {
+	case *flagMbox != "":
+		return localseries.ReadMbox(*flagMbox)
+	case *flagPatches != "":
+		return localseries.ReadPatchDir(*flagPatches)
+	default:
+		return localseries.ReadGitRange(*flagRepo, *flagRange)
+	}
+}
@@ -7,15 +7,26 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"net/http"
 
 	"github.com/google/syzkaller/syz-cluster/pkg/app"
 	"github.com/google/syzkaller/syz-cluster/pkg/controller"
+	"github.com/google/syzkaller/syz-cluster/pkg/workflow"
 )
 
+var flagLocal = flag.Bool("local", false, "test a single patch series on the local machine (see README.md)")
+
 func main() {
+	flag.Parse()
 	ctx := context.Background()
+	if *flagLocal {
+		if err := runLocal(ctx); err != nil {
+			app.Fatalf("%v", err)
+		}
+		return
+	}
 	env, err := app.Environment(ctx)
 	if err != nil {
 		app.Fatalf("failed to set up environment: %v", err)
@@ -24,7 +35,11 @@ func main() {
 	if err != nil {
 		app.Fatalf("failed to fetch the config: %v", err)
 	}
-	sp := NewSeriesProcessor(env, cfg)
+	workflows, err := workflow.NewArgoService()
+	if err != nil {
+		app.Fatalf("failed to initialize workflows: %v", err)
+	}
+	sp := NewSeriesProcessor(env, cfg, workflows)
 	go func() {
 		err := sp.Loop(ctx)
 		app.Fatalf("processor loop failed: %v", err)
@@ -29,11 +29,7 @@ type SeriesProcessor struct {
 	parallelWorkflows int
 }
 
-func NewSeriesProcessor(env *app.AppEnvironment, cfg *app.AppConfig) *SeriesProcessor {
-	workflows, err := workflow.NewArgoService()
-	if err != nil {
-		app.Fatalf("failed to initialize workflows: %v", err)
-	}
+func NewSeriesProcessor(env *app.AppEnvironment, cfg *app.AppConfig, workflows workflow.Service) *SeriesProcessor {
 	return &SeriesProcessor{
 		blobStorage:       env.BlobStorage,
 		seriesRepo:        db.NewSeriesRepository(env.Spanner),
@@ -23,6 +23,31 @@ type FuzzConfig struct {
 	Patched   BuildRequest `json:"patched"`
 	Config    string       `json:"config"` // Refers to workflow/configs/{}.
 	CorpusURL string       `json:"corpus_url"`
+	// The known reproducers to run on both kernels before fuzzing.
+	Repros []*ReproTest `json:"repros"`
+}
+
+// ReproTest is an existing reproducer that is relevant to the changes of the patch series.
+type ReproTest struct {
+	Title       string `json:"title"`
+	BugURL      string `json:"bug_url"`
+	SyzReproURL string `json:"syz_repro_url"`
+	Reason      string `json:"reason"` // Why the reproducer was selected.
+	// If set, the reproducer is passed inline (e.g. it comes from a finding of the previous series version).
+	SyzRepro     []byte `json:"syz_repro,omitempty"`
+	SyzReproOpts []byte `json:"syz_repro_opts,omitempty"`
+	// If set, the result of the reproducer is also saved as PrevFindingTestName(PrevFindingID).
+	PrevFindingID string `json:"prev_finding_id,omitempty"`
+}
+
+// ReproducersTestName is the name of the test that runs the ReproTest reproducers before fuzzing.
+const ReproducersTestName = "Reproducers"
+
+// PrevFindingTestName is the name of the test that runs the reproducer of a finding
+// of the previous series version on the patched kernel. The test passes if the reproducer
+// did not crash the kernel.
+func PrevFindingTestName(findingID string) string {
+	return "Reproducer of " + findingID
 }
 
 // The triage step of the workflow will request these from controller.
@@ -133,6 +158,30 @@ type SessionReport struct {
 	Series     *Series    `json:"series"`
 	Findings   []*Finding `json:"findings"`
 	Link       string     `json:"link"` // URL to the web dashboard.
+	// The findings of the previous version of the series (if it was tested) and whether
+	// they are still present in this version.
+	PrevVersion  int              `json:"prev_version"`
+	PrevFindings []*FindingStatus `json:"prev_findings"`
+}
+
+type FindingStatus struct {
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+const (
+	FindingFixed   string = "fixed"
+	FindingPresent string = "still present"
+	// The finding was not hit again during fuzzing and its reproducer (if any) could not be run.
+	FindingNotObserved string = "not observed"
+)
+
+// PrevFinding is a finding of the previous version of the series.
+type PrevFinding struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	SyzRepro     []byte `json:"syz_repro"`
+	SyzReproOpts []byte `json:"syz_repro_opts"`
 }
 
 type Finding struct {
@@ -35,6 +35,15 @@ func (client Client) SkipSession(ctx context.Context, sessionID string, req *Ski
 	return err
 }
 
+type PrevFindingsResp struct {
+	Findings []*PrevFinding `json:"findings"`
+}
+
+// GetPrevFindings returns the findings of the previous version of the session's series.
+func (client Client) GetPrevFindings(ctx context.Context, sessionID string) (*PrevFindingsResp, error) {
+	return getJSON[PrevFindingsResp](ctx, client.baseURL+"/sessions/"+sessionID+"/prev_findings")
+}
+
 type TreesResp struct {
 	Trees []*Tree `json:"trees"`
 }
@@ -39,19 +39,24 @@ type EmailConfig struct {
 }
 
 // The project configuration is expected to be mounted at /config/config.yaml.
+// APP_CONFIG_PATH overrides the location, it's used by the local mode.
 
 func Config() (*AppConfig, error) {
 	configLoadedOnce.Do(loadConfig)
 	return config, configErr
 }
 
-const configPath = `/config/config.yaml`
+const defaultConfigPath = `/config/config.yaml`
 
 var configLoadedOnce sync.Once
 var configErr error
 var config *AppConfig
 
 func loadConfig() {
+	configPath := defaultConfigPath
+	if path := os.Getenv("APP_CONFIG_PATH"); path != "" {
+		configPath = path
+	}
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		configErr = fmt.Errorf("failed to read %q: %w", configPath, err)
@@ -61,7 +61,10 @@ func DefaultSpanner(ctx context.Context) (*spanner.Client, error) {
 }
 
 func DefaultStorage(ctx context.Context) (blob.Storage, error) {
-	// BLOB_STORAGE_GCS_BUCKET is the only supported option.
+	// LOCAL_BLOB_STORAGE_PATH is only meant for the local setups.
+	if path := os.Getenv("LOCAL_BLOB_STORAGE_PATH"); path != "" {
+		return blob.NewLocalStorage(path), nil
+	}
 	bucket := os.Getenv("BLOB_STORAGE_GCS_BUCKET")
 	if bucket == "" {
 		return nil, fmt.Errorf("empty BLOB0_STORAGE_GCS_BUCKET")
@@ -69,7 +72,12 @@ func DefaultStorage(ctx context.Context) (blob.Storage, error) {
 	return blob.NewGCSClient(ctx, bucket)
 }
 
+// DefaultClient returns the controller API client.
+// CONTROLLER_API_URL overrides the controller address, it's used by the local mode.
 func DefaultClient() *api.Client {
+	if url := os.Getenv("CONTROLLER_API_URL"); url != "" {
+		return api.NewClient(url)
+	}
 	return api.NewClient(`http://controller-service:8080`)
 }
 
@@ -41,6 +41,7 @@ func (c APIServer) Mux() *http.ServeMux {
 	mux.HandleFunc("/series/upload", c.uploadSeries)
 	mux.HandleFunc("/series/{series_id}", c.getSeries)
 	mux.HandleFunc("/sessions/upload", c.uploadSession)
+	mux.HandleFunc("/sessions/{session_id}/prev_findings", c.getPrevFindings)
 	mux.HandleFunc("/sessions/{session_id}/series", c.getSessionSeries)
 	mux.HandleFunc("/sessions/{session_id}/skip", c.skipSession)
 	mux.HandleFunc("/tests/upload_artifacts", c.uploadTestArtifact)
@@ -61,6 +62,18 @@ func (c APIServer) getSessionSeries(w http.ResponseWriter, r *http.Request) {
 	api.ReplyJSON(w, resp)
 }
 
+func (c APIServer) getPrevFindings(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.findingService.PrevFindings(r.Context(), r.PathValue("session_id"))
+	if errors.Is(err, service.ErrSessionNotFound) {
+		http.Error(w, fmt.Sprint(err), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprint(err), http.StatusInternalServerError)
+		return
+	}
+	api.ReplyJSON(w, resp)
+}
+
 func (c APIServer) skipSession(w http.ResponseWriter, r *http.Request) {
 	req := api.ParseJSON[api.SkipRequest](w, r)
 	if req == nil {
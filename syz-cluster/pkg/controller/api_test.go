@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/syzkaller/syz-cluster/pkg/api"
 	"github.com/google/syzkaller/syz-cluster/pkg/app"
+	"github.com/google/syzkaller/syz-cluster/pkg/db"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -84,6 +85,55 @@ func TestAPISaveFinding(t *testing.T) {
 	})
 }
 
+func TestAPIPrevFindings(t *testing.T) {
+	env, ctx := app.TestEnvironment(t)
+	client := TestServer(t, env)
+
+	v1 := DummySeries()
+	v1.Version = 1
+	_, sessionID := UploadTestSeries(t, ctx, client, v1)
+	assert.NoError(t, db.NewSessionRepository(env.Spanner).Start(ctx, sessionID))
+	buildResp := UploadTestBuild(t, ctx, client, testBuild)
+	err := client.UploadTestResult(ctx, &api.TestResult{
+		SessionID:   sessionID,
+		BaseBuildID: buildResp.ID,
+		TestName:    "test",
+		Result:      api.TestPassed,
+	})
+	assert.NoError(t, err)
+	err = client.UploadFinding(ctx, &api.NewFinding{
+		SessionID:    sessionID,
+		TestName:     "test",
+		Title:        "finding",
+		SyzRepro:     []byte("syz repro"),
+		SyzReproOpts: []byte("syz repro opts"),
+	})
+	assert.NoError(t, err)
+
+	// The first version has nothing to compare against.
+	resp, err := client.GetPrevFindings(ctx, sessionID)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Findings)
+
+	v2 := DummySeries()
+	v2.ExtID = "ext-id-v2"
+	v2.Version = 2
+	_, sessionID2 := UploadTestSeries(t, ctx, client, v2)
+	resp, err = client.GetPrevFindings(ctx, sessionID2)
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Findings, 1) {
+		assert.NotEmpty(t, resp.Findings[0].ID)
+		resp.Findings[0].ID = ""
+	}
+	assert.Equal(t, []*api.PrevFinding{
+		{
+			Title:        "finding",
+			SyzRepro:     []byte("syz repro"),
+			SyzReproOpts: []byte("syz repro opts"),
+		},
+	}, resp.Findings)
+}
+
 func TestAPIUploadTestArtifacts(t *testing.T) {
 	env, ctx := app.TestEnvironment(t)
 	client := TestServer(t, env)
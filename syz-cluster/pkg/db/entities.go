@@ -21,12 +21,18 @@ type Series struct {
 	// TODO: we could ger rid of the field by using slightly more complicated SQL queries.
 	LatestSessionID spanner.NullString `spanner:"LatestSessionID"`
 	Cc              []string           `spanner:"Cc"`
+	// The previous version of the same series (e.g. v1 for v2), if it's known.
+	PrevSeriesID spanner.NullString `spanner:"PrevSeriesID"`
 }
 
 func (s *Series) SetLatestSession(session *Session) {
 	s.LatestSessionID = spanner.NullString{StringVal: session.ID, Valid: true}
 }
 
+func (s *Series) SetPrevSeries(series *Series) {
+	s.PrevSeriesID = spanner.NullString{StringVal: series.ID, Valid: true}
+}
+
 type Patch struct {
 	ID       string `spanner:"ID"`
 	Seq      int64  `spanner:"Seq"`
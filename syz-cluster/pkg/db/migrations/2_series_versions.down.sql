@@ -0,0 +1,3 @@
+ALTER TABLE Series DROP CONSTRAINT FK_PrevSeries;
+DROP INDEX SeriesByTitle;
+ALTER TABLE Series DROP COLUMN PrevSeriesID;
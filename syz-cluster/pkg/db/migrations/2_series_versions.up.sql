@@ -0,0 +1,6 @@
+-- Different versions (v1, v2, ...) of the same patch series are linked together,
+-- so that the findings of the previous version can be re-checked on the next one.
+ALTER TABLE Series ADD COLUMN PrevSeriesID STRING(36);
+ALTER TABLE Series ADD CONSTRAINT FK_PrevSeries FOREIGN KEY (PrevSeriesID) REFERENCES Series (ID);
+
+CREATE INDEX SeriesByTitle ON Series (Title, AuthorEmail, Version);
@@ -56,6 +56,24 @@ func (repo *SeriesRepository) GetByExtID(ctx context.Context, extID string) (*Se
 	return readOne[Series](iter)
 }
 
+// PrevVersion returns the most recent earlier version of the series, if there is one.
+// The versions are matched by the title and the author, since the Message-IDs of different versions
+// are not related to each other.
+func (repo *SeriesRepository) PrevVersion(ctx context.Context, series *Series) (*Series, error) {
+	stmt := spanner.Statement{
+		SQL: "SELECT * FROM `Series` WHERE `Title` = @title AND `AuthorEmail` = @author " +
+			"AND `Version` < @version ORDER BY `Version` DESC, `PublishedAt` DESC LIMIT 1",
+		Params: map[string]interface{}{
+			"title":   series.Title,
+			"author":  series.AuthorEmail,
+			"version": series.Version,
+		},
+	}
+	iter := repo.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+	return readOne[Series](iter)
+}
+
 var ErrSeriesExists = errors.New("the series already exists")
 
 // Insert() checks whether there already exists a series with the same ExtID.
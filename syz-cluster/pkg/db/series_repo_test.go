@@ -189,3 +189,28 @@ func TestSeriesRepositoryUpdate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "updated title", series2.Title)
 }
+
+func TestSeriesRepositoryPrevVersion(t *testing.T) {
+	client, ctx := NewTransientDB(t)
+	repo := NewSeriesRepository(client)
+	var list []*Series
+	for _, series := range []*Series{
+		{ExtID: "v1", Title: "Series", AuthorEmail: "a@a.com", Version: 1},
+		{ExtID: "v2", Title: "Series", AuthorEmail: "a@a.com", Version: 2},
+		{ExtID: "v2-other", Title: "Series", AuthorEmail: "b@b.com", Version: 2},
+		{ExtID: "v3", Title: "Series", AuthorEmail: "a@a.com", Version: 3},
+	} {
+		err := repo.Insert(ctx, series, nil)
+		assert.NoError(t, err)
+		list = append(list, series)
+	}
+	prev, err := repo.PrevVersion(ctx, list[3])
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", prev.ExtID)
+	prev, err = repo.PrevVersion(ctx, list[2])
+	assert.NoError(t, err)
+	assert.Nil(t, prev)
+	prev, err = repo.PrevVersion(ctx, list[0])
+	assert.NoError(t, err)
+	assert.Nil(t, prev)
+}
@@ -125,7 +125,7 @@ func (repo *SessionRepository) ListForSeries(ctx context.Context, series *Series
 }
 
 // MissingReportList lists the session objects that are missing any SessionReport objects,
-// but do have Findings.
+// but do have Findings (or the latest session of the previous series version had them).
 // Once the conditions for creating a SessionRepor object become more complex, it will
 // likely be not enough to have this simple method, but for now it should be fine.
 func (repo *SessionRepository) MissingReportList(ctx context.Context, from time.Time, limit int) ([]*Session, error) {
@@ -133,8 +133,12 @@ func (repo *SessionRepository) MissingReportList(ctx context.Context, from time.
 		SQL: "SELECT * FROM Sessions WHERE FinishedAt IS NOT NULL " +
 			" AND NOT EXISTS (" +
 			"SELECT 1 FROM SessionReports WHERE SessionReports.SessionID = Sessions.ID" +
-			") AND EXISTS (" +
-			"SELECT 1 FROM Findings WHERE Findings.SessionID = Sessions.ID)",
+			") AND (EXISTS (" +
+			"SELECT 1 FROM Findings WHERE Findings.SessionID = Sessions.ID" +
+			") OR EXISTS (" +
+			"SELECT 1 FROM Series JOIN Series AS PrevSeries ON Series.PrevSeriesID = PrevSeries.ID " +
+			"JOIN Findings ON Findings.SessionID = PrevSeries.LatestSessionID " +
+			"WHERE Series.ID = Sessions.SeriesID))",
 		Params: map[string]interface{}{},
 	}
 	if !from.IsZero() {
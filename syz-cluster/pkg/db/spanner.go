@@ -185,7 +185,7 @@ var spannerHost string
 func spannerTestWrapper(t *testing.T, bin string) string {
 	setupSpannerOnce.Do(func() {
 		t.Logf("this could be the first test requiring a Spanner emulator, starting %s", bin)
-		cmd, host, err := runSpanner(bin)
+		cmd, host, err := RunEmulator(bin)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -200,7 +200,8 @@ func spannerTestWrapper(t *testing.T, bin string) string {
 
 var portRe = regexp.MustCompile(`Server address: ([\w:]+)`)
 
-func runSpanner(bin string) (*exec.Cmd, string, error) {
+// RunEmulator starts the Spanner emulator binary and returns the host it listens on.
+func RunEmulator(bin string) (*exec.Cmd, string, error) {
 	cmd := exec.Command(bin, "--override_max_databases_per_instance=1000",
 		"--grpc_port=0", "--http_port=0")
 	stdout, err := cmd.StdoutPipe()
@@ -0,0 +1,180 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package localseries extracts patch series from the local sources (mbox files, `git format-patch`
+// output folders and git commit ranges) and submits them for testing via the controller API.
+package localseries
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/pkg/email"
+	"github.com/google/syzkaller/pkg/email/lore"
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/syz-cluster/pkg/api"
+)
+
+// ReadMbox extracts the patch series from an mbox file.
+func ReadMbox(path string) (*api.Series, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// ReadPatchDir extracts the patch series from the *.patch files generated by `git format-patch -o dir`.
+// The files are taken in the alphabetical order, which is the order of the patches in the series.
+func ReadPatchDir(dir string) (*api.Series, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.patch"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no *.patch files in %v", dir)
+	}
+	sort.Strings(files)
+	var data []byte
+	for _, file := range files {
+		patch, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != 0 && data[len(data)-1] != '\n' {
+			data = append(data, '\n')
+		}
+		if !bytes.HasPrefix(patch, []byte("From ")) {
+			// Each file must start a new message in the combined mbox.
+			data = append(data, "From local\n"...)
+		}
+		data = append(data, patch...)
+	}
+	return Parse(data)
+}
+
+// ReadGitRange extracts the patch series from the commits of the range (e.g. "origin/master..my-branch").
+func ReadGitRange(repo, revRange string) (*api.Series, error) {
+	data, err := osutil.RunCmd(time.Hour, repo, "git", "format-patch", "--stdout", "--thread=shallow", revRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the patches: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse extracts a single patch series from the mbox data.
+// The messages don't need to be threaded: if none of them has the In-Reply-To header
+// (e.g. `git format-patch` was run without --thread), they are assumed to form a single thread
+// in the order of appearance.
+func Parse(data []byte) (*api.Series, error) {
+	var emails []*email.Email
+	for _, raw := range splitMbox(data) {
+		msg, err := email.Parse(bytes.NewReader(raw), nil, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the email: %w", err)
+		}
+		emails = append(emails, msg)
+	}
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("no emails found")
+	}
+	threaded := false
+	for _, msg := range emails {
+		threaded = threaded || msg.InReplyTo != ""
+	}
+	// The IDs must be stable, so that the same series is not uploaded twice.
+	dataHash := hash.String(data)
+	for i, msg := range emails {
+		if msg.MessageID == "" {
+			msg.MessageID = fmt.Sprintf("<%v-%v@local>", dataHash, i)
+		}
+		if !threaded && i > 0 {
+			msg.InReplyTo = emails[0].MessageID
+		}
+	}
+	list := lore.PatchSeries(emails)
+	if len(list) != 1 {
+		return nil, fmt.Errorf("expected exactly one patch series, found %d", len(list))
+	}
+	series := list[0]
+	if series.Corrupted != "" {
+		return nil, fmt.Errorf("the patch series is corrupted: %s", series.Corrupted)
+	}
+	first := series.Patches[0]
+	ret := &api.Series{
+		ExtID:       series.MessageID,
+		AuthorEmail: first.Author,
+		Title:       series.Subject,
+		Version:     series.Version,
+		PublishedAt: time.Now(),
+	}
+	cc := map[string]bool{}
+	for _, patch := range series.Patches {
+		ret.Patches = append(ret.Patches, api.SeriesPatch{
+			Seq:   patch.Seq,
+			Title: patch.Subject,
+			Body:  []byte(patch.Body),
+		})
+		for _, email := range patch.Cc {
+			cc[email] = true
+		}
+	}
+	for email := range cc {
+		ret.Cc = append(ret.Cc, email)
+	}
+	sort.Strings(ret.Cc)
+	return ret, nil
+}
+
+// Upload saves the series and requests a new testing session for it.
+// Unlike series-tracker, it requests the session even if the series was already uploaded before,
+// so that the same series can be re-tested.
+func Upload(ctx context.Context, client *api.Client, series *api.Series) (string, error) {
+	ret, err := client.UploadSeries(ctx, series)
+	if err != nil {
+		return "", fmt.Errorf("failed to save series: %w", err)
+	} else if !ret.Saved {
+		log.Printf("series %s already exists in the DB", series.ExtID)
+	}
+	session, err := client.UploadSession(ctx, &api.NewSession{
+		ExtID: series.ExtID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to request a fuzzing session: %w", err)
+	}
+	return session.ID, nil
+}
+
+// splitMbox splits the mbox data into separate messages.
+// Each message starts with a "From " line (e.g. "From <hash> Mon Sep 17 00:00:00 2001" in git format-patch).
+func splitMbox(data []byte) [][]byte {
+	var ret [][]byte
+	var cur []byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "From ") {
+			if len(cur) != 0 {
+				ret = append(ret, cur)
+			}
+			cur = nil
+			continue
+		}
+		cur = append(cur, line...)
+		cur = append(cur, '\n')
+	}
+	if len(cur) != 0 {
+		ret = append(ret, cur)
+	}
+	return ret
+}
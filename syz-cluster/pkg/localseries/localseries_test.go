@@ -0,0 +1,146 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package localseries
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/pkg/osutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	series, err := Parse([]byte(`From 0123456789abcdef0123456789abcdef01234567 Mon Sep 17 00:00:00 2001
+Message-ID: <first@example.com>
+From: Author <author@example.com>
+Date: Tue, 1 Apr 2025 10:00:00 +0000
+Subject: [PATCH v2 1/2] net: first change
+Cc: netdev@vger.kernel.org
+
+First description.
+---
+ a.c | 1 +
+diff --git a/a.c b/a.c
+--- a/a.c
++++ b/a.c
+@@ -1 +1,2 @@
+ a
++b
+--
+2.49.0
+
+From fedcba9876543210fedcba9876543210fedcba98 Mon Sep 17 00:00:00 2001
+Message-ID: <second@example.com>
+In-Reply-To: <first@example.com>
+References: <first@example.com>
+From: Author <author@example.com>
+Date: Tue, 1 Apr 2025 10:00:01 +0000
+Subject: [PATCH v2 2/2] net: second change
+Cc: other@example.com
+
+Second description.
+---
+diff --git a/b.c b/b.c
+--- a/b.c
++++ b/b.c
+@@ -1 +1,2 @@
+ a
++c
+--
+2.49.0
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "<first@example.com>", series.ExtID)
+	assert.Equal(t, "author@example.com", series.AuthorEmail)
+	assert.Equal(t, "net: first change", series.Title)
+	assert.Equal(t, 2, series.Version)
+	assert.Equal(t, []string{"author@example.com", "netdev@vger.kernel.org", "other@example.com"}, series.Cc)
+	require.Len(t, series.Patches, 2)
+	assert.Equal(t, 1, series.Patches[0].Seq)
+	assert.Contains(t, string(series.Patches[0].Body), "+b")
+	assert.Equal(t, 2, series.Patches[1].Seq)
+	assert.Contains(t, string(series.Patches[1].Body), "+c")
+}
+
+func TestParseMultiple(t *testing.T) {
+	_, err := Parse([]byte(`From 0123456789abcdef0123456789abcdef01234567 Mon Sep 17 00:00:00 2001
+Message-ID: <first@example.com>
+From: Author <author@example.com>
+Subject: [PATCH 1/2] first
+
+Body.
+
+From 0123456789abcdef0123456789abcdef01234567 Mon Sep 17 00:00:00 2001
+Message-ID: <first-2@example.com>
+In-Reply-To: <first@example.com>
+From: Author <author@example.com>
+Subject: [PATCH 2/2] first
+
+Body.
+
+From fedcba9876543210fedcba9876543210fedcba98 Mon Sep 17 00:00:00 2001
+Message-ID: <second@example.com>
+From: Author <author@example.com>
+Subject: [PATCH] second
+
+Body.
+`))
+	assert.ErrorContains(t, err, "expected exactly one patch series, found 2")
+}
+
+func TestReadPatchDir(t *testing.T) {
+	// git format-patch does not add Message-ID and In-Reply-To without --thread.
+	dir := t.TempDir()
+	for name, subject := range map[string]string{
+		"0000-cover-letter.patch":    "[PATCH 0/2] the series",
+		"0001-first-change.patch":    "[PATCH 1/2] first change",
+		"0002-second-change.patch":   "[PATCH 2/2] second change",
+		"series-description.txt":     "[PATCH 3/2] must be ignored",
+		"0003-another-change.patch~": "[PATCH 3/2] must be ignored",
+	} {
+		data := fmt.Sprintf("From: Author <author@example.com>\nSubject: %s\n\nBody of %s", subject, name)
+		require.NoError(t, osutil.WriteFile(filepath.Join(dir, name), []byte(data)))
+	}
+	series, err := ReadPatchDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "the series", series.Title)
+	require.Len(t, series.Patches, 2)
+	assert.Equal(t, "[PATCH 1/2] first change", series.Patches[0].Title)
+	assert.Equal(t, "[PATCH 2/2] second change", series.Patches[1].Title)
+
+	// The ID must not change between the runs.
+	series2, err := ReadPatchDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, series.ExtID, series2.ExtID)
+}
+
+func TestReadGitRange(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) {
+		_, err := osutil.RunCmd(time.Minute, repo, "git", args...)
+		require.NoError(t, err)
+	}
+	git("init")
+	git("config", "user.name", "Author")
+	git("config", "user.email", "author@example.com")
+	git("commit", "--allow-empty", "-m", "base")
+	git("tag", "base")
+	for i, name := range []string{"a.c", "b.c"} {
+		require.NoError(t, osutil.WriteFile(filepath.Join(repo, name), []byte("content\n")))
+		git("add", name)
+		git("commit", "-m", fmt.Sprintf("change %d", i+1))
+	}
+	series, err := ReadGitRange(repo, "base..HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "change 1", series.Title)
+	assert.Equal(t, "author@example.com", series.AuthorEmail)
+	require.Len(t, series.Patches, 2)
+	assert.Contains(t, string(series.Patches[0].Body), "+++ b/a.c")
+	assert.Contains(t, string(series.Patches[1].Body), "+++ b/b.c")
+}
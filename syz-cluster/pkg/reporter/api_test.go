@@ -10,6 +10,7 @@ import (
 	"github.com/google/syzkaller/syz-cluster/pkg/api"
 	"github.com/google/syzkaller/syz-cluster/pkg/app"
 	"github.com/google/syzkaller/syz-cluster/pkg/controller"
+	"github.com/google/syzkaller/syz-cluster/pkg/db"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -162,3 +163,102 @@ func TestReplyReporting(t *testing.T) {
 		}, resp)
 	})
 }
+
+func TestAPIReportPrevVersion(t *testing.T) {
+	env, ctx := app.TestEnvironment(t)
+	client := controller.TestServer(t, env)
+	sessionRepo := db.NewSessionRepository(env.Spanner)
+
+	v1 := controller.DummySeries()
+	v1.Version = 1
+	_, sessionID := controller.UploadTestSeries(t, ctx, client, v1)
+	assert.NoError(t, sessionRepo.Start(ctx, sessionID))
+	buildResp := controller.UploadTestBuild(t, ctx, client, controller.DummyBuild())
+	err := client.UploadTestResult(ctx, &api.TestResult{
+		SessionID:   sessionID,
+		BaseBuildID: buildResp.ID,
+		TestName:    "test",
+		Result:      api.TestPassed,
+	})
+	assert.NoError(t, err)
+	for _, finding := range []*api.NewFinding{
+		{Title: "with repro", SyzRepro: []byte("repro")},
+		{Title: "without repro"},
+		{Title: "still there", SyzRepro: []byte("repro")},
+		{Title: "other crash", SyzRepro: []byte("repro")},
+		{Title: "repro errored", SyzRepro: []byte("repro")},
+		{Title: "repro not run", SyzRepro: []byte("repro")},
+	} {
+		finding.SessionID = sessionID
+		finding.TestName = "test"
+		assert.NoError(t, client.UploadFinding(ctx, finding))
+	}
+	controller.MarkSessionFinished(t, env, sessionID)
+
+	v2 := controller.DummySeries()
+	v2.ExtID = "ext-id-v2"
+	v2.Version = 2
+	_, sessionID2 := controller.UploadTestSeries(t, ctx, client, v2)
+	assert.NoError(t, sessionRepo.Start(ctx, sessionID2))
+	err = client.UploadTestResult(ctx, &api.TestResult{
+		SessionID:      sessionID2,
+		PatchedBuildID: buildResp.ID,
+		TestName:       api.ReproducersTestName,
+		Result:         api.TestFailed,
+	})
+	assert.NoError(t, err)
+	prevFindings, err := client.GetPrevFindings(ctx, sessionID2)
+	assert.NoError(t, err)
+	reproResults := map[string]string{
+		"with repro":    api.TestPassed,
+		"still there":   api.TestFailed,
+		"other crash":   api.TestFailed,
+		"repro errored": api.TestError,
+	}
+	for _, finding := range prevFindings.Findings {
+		result, ok := reproResults[finding.Title]
+		if !ok {
+			continue
+		}
+		err = client.UploadTestResult(ctx, &api.TestResult{
+			SessionID:      sessionID2,
+			PatchedBuildID: buildResp.ID,
+			TestName:       api.PrevFindingTestName(finding.ID),
+			Result:         result,
+		})
+		assert.NoError(t, err)
+	}
+	err = client.UploadFinding(ctx, &api.NewFinding{
+		SessionID: sessionID2,
+		TestName:  api.ReproducersTestName,
+		Title:     "still there",
+	})
+	assert.NoError(t, err)
+	controller.MarkSessionFinished(t, env, sessionID2)
+
+	generator := NewGenerator(env)
+	assert.NoError(t, generator.Process(ctx, 10))
+	reportClient := TestServer(t, env)
+	for {
+		nextResp, err := reportClient.GetNextReport(ctx, api.LKMLReporter)
+		assert.NoError(t, err)
+		if !assert.NotNil(t, nextResp.Report) {
+			return
+		}
+		if nextResp.Report.Series.Version == 1 {
+			assert.Empty(t, nextResp.Report.PrevFindings)
+			assert.NoError(t, reportClient.ConfirmReport(ctx, nextResp.Report.ID))
+			continue
+		}
+		assert.Equal(t, 1, nextResp.Report.PrevVersion)
+		assert.Equal(t, []*api.FindingStatus{
+			{Title: "other crash", Status: api.FindingPresent},
+			{Title: "repro errored", Status: api.FindingNotObserved},
+			{Title: "repro not run", Status: api.FindingNotObserved},
+			{Title: "still there", Status: api.FindingPresent},
+			{Title: "with repro", Status: api.FindingFixed},
+			{Title: "without repro", Status: api.FindingNotObserved},
+		}, nextResp.Report.PrevFindings)
+		break
+	}
+}
@@ -16,14 +16,20 @@ import (
 )
 
 type FindingService struct {
-	findingRepo *db.FindingRepository
-	blobStorage blob.Storage
+	findingRepo     *db.FindingRepository
+	sessionRepo     *db.SessionRepository
+	seriesRepo      *db.SeriesRepository
+	sessionTestRepo *db.SessionTestRepository
+	blobStorage     blob.Storage
 }
 
 func NewFindingService(env *app.AppEnvironment) *FindingService {
 	return &FindingService{
-		findingRepo: db.NewFindingRepository(env.Spanner),
-		blobStorage: env.BlobStorage,
+		findingRepo:     db.NewFindingRepository(env.Spanner),
+		sessionRepo:     db.NewSessionRepository(env.Spanner),
+		seriesRepo:      db.NewSeriesRepository(env.Spanner),
+		sessionTestRepo: db.NewSessionTestRepository(env.Spanner),
+		blobStorage:     env.BlobStorage,
 	}
 }
 
@@ -87,3 +93,110 @@ func (s *FindingService) List(ctx context.Context, sessionID string) ([]*api.Fin
 	}
 	return ret, nil
 }
+
+// PrevFindings returns the findings of the latest session of the previous series version.
+func (s *FindingService) PrevFindings(ctx context.Context, sessionID string) (*api.PrevFindingsResp, error) {
+	prev, err := s.prevVersion(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	ret := &api.PrevFindingsResp{}
+	if prev == nil {
+		return ret, nil
+	}
+	list, err := s.findingRepo.ListForSession(ctx, prev.LatestSessionID.StringVal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the list: %w", err)
+	}
+	for _, item := range list {
+		finding := &api.PrevFinding{ID: item.ID, Title: item.Title}
+		for _, asset := range []struct {
+			uri    string
+			readTo *[]byte
+		}{
+			{item.SyzReproURI, &finding.SyzRepro},
+			{item.SyzReproOptsURI, &finding.SyzReproOpts},
+		} {
+			if asset.uri == "" {
+				continue
+			}
+			*asset.readTo, err = blob.ReadAllBytes(s.blobStorage, asset.uri)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %q: %w", asset.uri, err)
+			}
+		}
+		ret.Findings = append(ret.Findings, finding)
+	}
+	return ret, nil
+}
+
+// PrevStatus checks which findings of the previous series version are still present in the session.
+// It returns the previous version number and the list of statuses (or nil if nothing was found before).
+func (s *FindingService) PrevStatus(ctx context.Context, sessionID string) (int, []*api.FindingStatus, error) {
+	prev, err := s.prevVersion(ctx, sessionID)
+	if err != nil || prev == nil {
+		return 0, nil, err
+	}
+	prevList, err := s.findingRepo.ListForSession(ctx, prev.LatestSessionID.StringVal)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to query the previous findings: %w", err)
+	}
+	list, err := s.findingRepo.ListForSession(ctx, sessionID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to query the findings: %w", err)
+	}
+	present := map[string]bool{}
+	for _, item := range list {
+		present[item.Title] = true
+	}
+	var ret []*api.FindingStatus
+	for _, item := range prevList {
+		status := api.FindingNotObserved
+		if present[item.Title] {
+			status = api.FindingPresent
+		} else if item.SyzReproURI != "" {
+			// The reproducers of the previous findings are run before fuzzing.
+			reproTest, err := s.sessionTestRepo.Get(ctx, sessionID, api.PrevFindingTestName(item.ID))
+			if err != nil {
+				return 0, nil, fmt.Errorf("failed to query the reproducer test: %w", err)
+			}
+			if reproTest != nil {
+				switch reproTest.Result {
+				case api.TestPassed:
+					status = api.FindingFixed
+				case api.TestFailed:
+					// The crash title may differ, but the reproducer still crashes the kernel.
+					status = api.FindingPresent
+				}
+			}
+		}
+		ret = append(ret, &api.FindingStatus{
+			Title:  item.Title,
+			Status: status,
+		})
+	}
+	return int(prev.Version), ret, nil
+}
+
+// prevVersion returns the previous version of the session's series, if it was tested.
+func (s *FindingService) prevVersion(ctx context.Context, sessionID string) (*db.Series, error) {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the session: %w", err)
+	} else if session == nil {
+		return nil, fmt.Errorf("%w: %q", ErrSessionNotFound, sessionID)
+	}
+	series, err := s.seriesRepo.GetByID(ctx, session.SeriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the series: %w", err)
+	} else if series == nil || series.PrevSeriesID.IsNull() {
+		return nil, nil
+	}
+	prev, err := s.seriesRepo.GetByID(ctx, series.PrevSeriesID.StringVal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the previous series: %w", err)
+	} else if prev == nil || prev.LatestSessionID.IsNull() {
+		return nil, nil
+	}
+	return prev, nil
+}
@@ -93,12 +93,18 @@ func (rs *ReportService) Next(ctx context.Context, reporter string) (*api.NextRe
 	if err != nil {
 		return nil, fmt.Errorf("failed to query findings: %w", err)
 	}
+	prevVersion, prevFindings, err := rs.findingService.PrevStatus(ctx, report.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the previous findings: %w", err)
+	}
 	return &api.NextReportResp{
 		Report: &api.SessionReport{
-			ID:         report.ID,
-			Moderation: report.Moderation,
-			Series:     series,
-			Findings:   findings,
+			ID:           report.ID,
+			Moderation:   report.Moderation,
+			Series:       series,
+			Findings:     findings,
+			PrevVersion:  prevVersion,
+			PrevFindings: prevFindings,
 		},
 	}, nil
 }
@@ -63,7 +63,13 @@ func (s *SeriesService) UploadSeries(ctx context.Context, series *api.Series) (*
 		PublishedAt: series.PublishedAt,
 		Cc:          series.Cc,
 	}
-	err := s.seriesRepo.Insert(ctx, seriesObj, func() ([]*db.Patch, error) {
+	prev, err := s.seriesRepo.PrevVersion(ctx, seriesObj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the previous version: %w", err)
+	} else if prev != nil {
+		seriesObj.SetPrevSeries(prev)
+	}
+	err = s.seriesRepo.Insert(ctx, seriesObj, func() ([]*db.Patch, error) {
 		var ret []*db.Patch
 		for _, patch := range series.Patches {
 			// In case of errors, we will waste some space, but let's ignore it for simplicity.
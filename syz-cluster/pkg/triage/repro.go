@@ -0,0 +1,279 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package triage
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	dashboard "github.com/google/syzkaller/dashboard/api"
+	"github.com/google/syzkaller/pkg/debugtracer"
+	"github.com/google/syzkaller/pkg/subsystem"
+	"github.com/google/syzkaller/pkg/vcs"
+	"github.com/google/syzkaller/syz-cluster/pkg/api"
+)
+
+// ReproSource gives access to the bugs known to the dashboard (see dashboard/api.Client).
+type ReproSource interface {
+	ExportSubsystem(ns, subsystem string) (*dashboard.Export, error)
+	Text(query string) ([]byte, error)
+}
+
+// ReproSelector picks the existing reproducers whose crashes are related to the code
+// modified by the patch series. Running them is a fast and deterministic way to detect regressions.
+type ReproSelector struct {
+	source       ReproSource
+	dashboardURL string
+	ns           string
+	matcher      *subsystem.PathMatcher
+	tracer       debugtracer.DebugTracer
+}
+
+func NewReproSelector(source ReproSource, dashboardURL, ns string, matcher *subsystem.PathMatcher,
+	tracer debugtracer.DebugTracer) *ReproSelector {
+	return &ReproSelector{
+		source:       source,
+		dashboardURL: strings.TrimSuffix(dashboardURL, "/"),
+		ns:           ns,
+		matcher:      matcher,
+		tracer:       tracer,
+	}
+}
+
+const (
+	// Downloading crash reports is throttled by the dashboard, so only inspect the most promising bugs.
+	maxReproCandidates = 30
+	// Each reproducer takes several minutes to run on both kernels.
+	maxRepros = 10
+)
+
+const (
+	scoreTitleFunction = 4
+	scoreStackFunction = 2
+	scoreStackFile     = 1
+)
+
+type reproCandidate struct {
+	bug    *dashboard.ExportBug
+	crash  *dashboard.ExportCrash
+	score  int
+	reason string
+}
+
+// Select returns up to maxRepros reproducers, the most relevant ones first.
+// Only the bugs of the subsystems affected by the series are considered. A bug is relevant
+// if its title or the stack of its crash mentions the modified functions or files.
+func (rs *ReproSelector) Select(series *api.Series) ([]*api.ReproTest, error) {
+	changes := parseChanges(series.PatchBodies())
+	if len(changes.files) == 0 {
+		rs.tracer.Log("the series modifies no files")
+		return nil, nil
+	}
+	rs.tracer.Log("modified files: %q", sortedKeys(changes.files))
+	rs.tracer.Log("modified functions: %q", sortedKeys(changes.functions))
+	subsystems := map[string]bool{}
+	if rs.matcher != nil {
+		for file := range changes.files {
+			for _, item := range rs.matcher.Match(file) {
+				subsystems[item.Name] = true
+			}
+		}
+	}
+	if len(subsystems) == 0 {
+		rs.tracer.Log("the series does not affect any known subsystem")
+		return nil, nil
+	}
+	rs.tracer.Log("affected subsystems: %q", sortedKeys(subsystems))
+	bugs, err := rs.subsystemBugs(sortedKeys(subsystems))
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*reproCandidate
+	for _, bug := range bugs {
+		if bug.Status != dashboard.ExportStatusOpen && bug.Status != dashboard.ExportStatusFixed {
+			continue
+		}
+		crash := reproCrash(bug)
+		if crash == nil {
+			continue
+		}
+		candidate := &reproCandidate{bug: bug, crash: crash}
+		if fn := changes.titleFunction(bug.Title); fn != "" {
+			candidate.score = scoreTitleFunction
+			candidate.reason = fmt.Sprintf("the title mentions %s()", fn)
+		}
+		candidates = append(candidates, candidate)
+	}
+	rs.tracer.Log("%d bugs with reproducers are potentially relevant", len(candidates))
+	sortCandidates(candidates)
+	if len(candidates) > maxReproCandidates {
+		candidates = candidates[:maxReproCandidates]
+	}
+	var relevant []*reproCandidate
+	for _, candidate := range candidates {
+		if link := candidate.crash.CrashReportLink; link != "" {
+			report, err := rs.source.Text(link)
+			if err != nil {
+				rs.tracer.Log("failed to download the report for %q: %v", candidate.bug.Title, err)
+			} else if score, reason := changes.matchReport(report); score > 0 {
+				candidate.score += score
+				if candidate.reason == "" {
+					candidate.reason = reason
+				}
+			}
+		}
+		if candidate.score == 0 {
+			continue
+		}
+		rs.tracer.Log("selected %q: %s", candidate.bug.Title, candidate.reason)
+		relevant = append(relevant, candidate)
+	}
+	sortCandidates(relevant)
+	if len(relevant) > maxRepros {
+		relevant = relevant[:maxRepros]
+	}
+	var ret []*api.ReproTest
+	for _, candidate := range relevant {
+		ret = append(ret, &api.ReproTest{
+			Title:       candidate.bug.Title,
+			BugURL:      rs.url(candidate.bug.Link),
+			SyzReproURL: rs.url(candidate.crash.SyzReproducerLink),
+			Reason:      candidate.reason,
+		})
+	}
+	return ret, nil
+}
+
+// subsystemBugs queries only the bugs of the affected subsystems, the full export is too big
+// to be downloaded for every series. A bug may belong to several of the subsystems.
+func (rs *ReproSelector) subsystemBugs(subsystems []string) ([]*dashboard.ExportBug, error) {
+	var ret []*dashboard.ExportBug
+	seen := map[string]bool{}
+	for _, name := range subsystems {
+		export, err := rs.source.ExportSubsystem(rs.ns, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the bugs of %q: %w", name, err)
+		}
+		for i := range export.Bugs {
+			bug := &export.Bugs[i]
+			if seen[bug.ID] {
+				continue
+			}
+			seen[bug.ID] = true
+			ret = append(ret, bug)
+		}
+	}
+	return ret, nil
+}
+
+// The dashboard returns html-escaped links relative to the dashboard URL.
+func (rs *ReproSelector) url(link string) string {
+	return rs.dashboardURL + html.UnescapeString(link)
+}
+
+// reproCrash returns the first crash that has a syz reproducer.
+func reproCrash(bug *dashboard.ExportBug) *dashboard.ExportCrash {
+	if bug.ReproLevel == "" {
+		return nil
+	}
+	for i := range bug.Crashes {
+		if bug.Crashes[i].SyzReproducerLink != "" {
+			return &bug.Crashes[i]
+		}
+	}
+	return nil
+}
+
+// sortCandidates puts the highest scores first and prefers the bugs that happened recently.
+func sortCandidates(list []*reproCandidate) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].score != list[j].score {
+			return list[i].score > list[j].score
+		}
+		return list[i].bug.LastCrash.After(list[j].bug.LastCrash)
+	})
+}
+
+type seriesChanges struct {
+	files     map[string]bool
+	functions map[string]bool
+}
+
+var (
+	// The git diff hunk headers contain the declaration of the enclosing function.
+	hunkHeaderRe = regexp.MustCompile(`(?m)^@@ -\S+ \+\S+ @@ (.*)$`)
+	// Function definitions start at the beginning of the line, e.g. "+static int foo(struct bar *bar)".
+	funcDefRe  = regexp.MustCompile(`(?m)^[+-][a-zA-Z_][^;\n]*?\b(\w+)\(`)
+	funcNameRe = regexp.MustCompile(`(\w+)\s*\(`)
+)
+
+func parseChanges(patches [][]byte) *seriesChanges {
+	ret := &seriesChanges{
+		files:     map[string]bool{},
+		functions: map[string]bool{},
+	}
+	for _, patch := range patches {
+		for _, file := range vcs.ParseGitDiff(patch) {
+			ret.files[file] = true
+		}
+		for _, match := range hunkHeaderRe.FindAllSubmatch(patch, -1) {
+			if name := funcNameRe.FindSubmatch(match[1]); name != nil {
+				ret.addFunction(string(name[1]))
+			}
+		}
+		for _, match := range funcDefRe.FindAllSubmatch(patch, -1) {
+			ret.addFunction(string(match[1]))
+		}
+	}
+	return ret
+}
+
+func (sc *seriesChanges) addFunction(name string) {
+	// Skip macros like EXPORT_SYMBOL(), they never appear in the crash stacks.
+	if strings.ToUpper(name) == name {
+		return
+	}
+	sc.functions[name] = true
+}
+
+// titleFunction returns the modified function mentioned in the bug title, if any.
+func (sc *seriesChanges) titleFunction(title string) string {
+	for _, word := range strings.FieldsFunc(title, func(r rune) bool {
+		return r != '_' && !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		if sc.functions[word] {
+			return word
+		}
+	}
+	return ""
+}
+
+// matchReport inspects the stack frames of a crash report, e.g.
+// " foo+0x12/0x30 net/core/dev.c:123" or " bar net/core/dev.c:45 [inline]".
+func (sc *seriesChanges) matchReport(report []byte) (int, string) {
+	score, reason := 0, ""
+	for _, line := range strings.Split(string(report), "\n") {
+		for _, field := range strings.Fields(line) {
+			if fn, _, _ := strings.Cut(field, "+0x"); sc.functions[fn] && score < scoreStackFunction {
+				score, reason = scoreStackFunction, fmt.Sprintf("the crash stack includes %s()", fn)
+			}
+			if file, _, _ := strings.Cut(field, ":"); sc.files[file] && score < scoreStackFile {
+				score, reason = scoreStackFile, fmt.Sprintf("the crash stack includes %s", file)
+			}
+		}
+	}
+	return score, reason
+}
+
+func sortedKeys(set map[string]bool) []string {
+	var ret []string
+	for key := range set {
+		ret = append(ret, key)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package triage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	dashboard "github.com/google/syzkaller/dashboard/api"
+	"github.com/google/syzkaller/pkg/debugtracer"
+	"github.com/google/syzkaller/pkg/subsystem"
+	"github.com/google/syzkaller/syz-cluster/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testReproPatch = `net: fix the refcount
+
+diff --git a/net/core/dev.c b/net/core/dev.c
+index 1111111..2222222 100644
+--- a/net/core/dev.c
++++ b/net/core/dev.c
+@@ -10,6 +10,7 @@ static int dev_open_locked(struct net_device *dev)
+ 	int ret;
++	dev_hold(dev);
+ 	ret = __dev_open(dev);
+@@ -50,3 +51,8 @@ EXPORT_SYMBOL(dev_open);
++static void dev_cleanup(struct net_device *dev)
++{
++	dev_put(dev);
++}
+diff --git a/include/linux/netdevice.h b/include/linux/netdevice.h
+index 3333333..4444444 100644
+--- a/include/linux/netdevice.h
++++ b/include/linux/netdevice.h
+@@ -1,2 +1,3 @@
++#define NETDEV_FLAG(x) (x)
+`
+
+func TestParseChanges(t *testing.T) {
+	changes := parseChanges([][]byte{[]byte(testReproPatch)})
+	assert.Equal(t, []string{"include/linux/netdevice.h", "net/core/dev.c"}, sortedKeys(changes.files))
+	assert.Equal(t, []string{"dev_cleanup", "dev_open_locked"}, sortedKeys(changes.functions))
+}
+
+func TestReproSelector(t *testing.T) {
+	source := &testReproSource{
+		bugs: []dashboard.ExportBug{
+			testReproBug("title", "KASAN: use-after-free Read in dev_open_locked", dashboard.ExportStatusFixed, "syz"),
+			testReproBug("stack-func", "WARNING in refcount_warn", dashboard.ExportStatusOpen, "c"),
+			testReproBug("stack-file", "general protection fault in xyz", dashboard.ExportStatusFixed, "syz"),
+			testReproBug("unrelated-stack", "BUG in something_else", dashboard.ExportStatusOpen, "syz"),
+			testReproBug("no-repro", "KASAN: use-after-free in dev_cleanup", dashboard.ExportStatusOpen, ""),
+			testReproBug("invalid", "WARNING in dev_cleanup", dashboard.ExportStatusInvalid, "syz"),
+			testReproBug("other-subsystem", "BUG in fs_code", dashboard.ExportStatusOpen, "syz"),
+		},
+		reports: map[string]string{
+			"/report?id=title": `KASAN: use-after-free
+Call Trace:
+ dev_open_locked+0x12/0x30 net/core/dev.c:12`,
+			"/report?id=stack-func": `WARNING: refcount bug
+Call Trace:
+ refcount_warn+0x10/0x20 lib/refcount.c:25
+ dev_cleanup net/core/dev.c:53 [inline]
+ dev_close+0x44/0x50 net/core/dev.c:70`,
+			"/report?id=stack-file": `general protection fault
+Call Trace:
+ dev_close+0x44/0x50 net/core/dev.c:70`,
+			"/report?id=unrelated-stack": `BUG: something
+Call Trace:
+ tcp_sendmsg+0x44/0x50 net/ipv4/tcp.c:70`,
+			"/report?id=other-subsystem": `BUG: fs
+Call Trace:
+ dev_cleanup+0x1/0x2 net/core/dev.c:53`,
+		},
+	}
+	matcher := subsystem.MakePathMatcher([]*subsystem.Subsystem{
+		{Name: "net", PathRules: []subsystem.PathRule{{IncludeRegexp: `^net/`}}},
+		{Name: "fs", PathRules: []subsystem.PathRule{{IncludeRegexp: `^fs/`}}},
+	})
+	selector := NewReproSelector(source, "https://dashboard/", "upstream", matcher, &debugtracer.NullTracer{})
+	repros, err := selector.Select(&api.Series{
+		Patches: []api.SeriesPatch{{Body: []byte(testReproPatch)}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*api.ReproTest{
+		{
+			Title:       "KASAN: use-after-free Read in dev_open_locked",
+			BugURL:      "https://dashboard/bug?extid=title",
+			SyzReproURL: "https://dashboard/text?tag=ReproSyz&x=title",
+			Reason:      "the title mentions dev_open_locked()",
+		},
+		{
+			Title:       "WARNING in refcount_warn",
+			BugURL:      "https://dashboard/bug?extid=stack-func",
+			SyzReproURL: "https://dashboard/text?tag=ReproSyz&x=stack-func",
+			Reason:      "the crash stack includes dev_cleanup()",
+		},
+		{
+			Title:       "general protection fault in xyz",
+			BugURL:      "https://dashboard/bug?extid=stack-file",
+			SyzReproURL: "https://dashboard/text?tag=ReproSyz&x=stack-file",
+			Reason:      "the crash stack includes net/core/dev.c",
+		},
+	}, repros)
+	// The bugs from unrelated subsystems must not be even looked at.
+	assert.NotContains(t, source.queried, "/report?id=other-subsystem")
+}
+
+func testReproBug(id, title, status, reproLevel string) dashboard.ExportBug {
+	bug := dashboard.ExportBug{
+		ID:         id,
+		Title:      title,
+		Link:       "/bug?extid=" + id,
+		Status:     status,
+		ReproLevel: reproLevel,
+		Subsystems: []string{"net"},
+		LastCrash:  time.Now(),
+	}
+	if id == "other-subsystem" {
+		bug.Subsystems = []string{"fs"}
+	}
+	crash := dashboard.ExportCrash{}
+	crash.Title = title
+	crash.CrashReportLink = "/report?id=" + id
+	if reproLevel != "" {
+		crash.SyzReproducerLink = "/text?tag=ReproSyz&amp;x=" + id
+	}
+	bug.Crashes = append(bug.Crashes, crash)
+	return bug
+}
+
+type testReproSource struct {
+	bugs    []dashboard.ExportBug
+	reports map[string]string
+	queried []string
+}
+
+func (trs *testReproSource) ExportSubsystem(ns, subsystem string) (*dashboard.Export, error) {
+	ret := &dashboard.Export{Namespace: ns}
+	for _, bug := range trs.bugs {
+		for _, name := range bug.Subsystems {
+			if name == subsystem {
+				ret.Bugs = append(ret.Bugs, bug)
+				break
+			}
+		}
+	}
+	return ret, nil
+}
+
+func (trs *testReproSource) Text(query string) ([]byte, error) {
+	trs.queried = append(trs.queried, query)
+	report, ok := trs.reports[query]
+	if !ok {
+		return nil, fmt.Errorf("unknown query %q", query)
+	}
+	return []byte(report), nil
+}
@@ -0,0 +1,358 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/pkg/config"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/syz-cluster/pkg/api"
+)
+
+// LocalConfig describes where LocalService finds the step binaries and the data they need.
+// In the cluster, the same data is baked into the step containers or mounted as volumes.
+type LocalConfig struct {
+	// The folder with triage-step, build-step, boot-step and fuzz-step binaries.
+	BinDir string
+	// The kernel checkout. Every session works in its own git worktree of it.
+	Repository string
+	// The sessions' working folders are created there.
+	Workdir string
+	// The syzkaller configs, see workflow/configs.
+	Configs string
+	// The kernel configs, see the build step's Dockerfile.
+	KernelConfigs string
+	// The userspace image for the kernel builds.
+	Userspace string
+	// The syzkaller checkout with the built binaries.
+	Syzkaller string
+	// How long to fuzz the patched kernel.
+	FuzzTime time.Duration
+}
+
+// LocalService runs the same sequence of steps as template.yaml, but as subprocesses on the local machine.
+type LocalService struct {
+	cfg  LocalConfig
+	mu   sync.Mutex
+	runs map[string]*localRun
+}
+
+type localRun struct {
+	status Status
+	steps  []*localStep
+}
+
+type localStep struct {
+	name       string
+	phase      string
+	args       []string
+	logFile    string
+	startedAt  time.Time
+	finishedAt time.Time
+}
+
+func NewLocalService(cfg LocalConfig) *LocalService {
+	return &LocalService{
+		cfg:  cfg,
+		runs: map[string]*localRun{},
+	}
+}
+
+func (ls *LocalService) Start(sessionID string) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if ls.runs[sessionID] != nil {
+		return fmt.Errorf("the workflow for %q is already started", sessionID)
+	}
+	run := &localRun{status: StatusRunning}
+	ls.runs[sessionID] = run
+	go func() {
+		err := ls.run(sessionID, run)
+		ls.mu.Lock()
+		defer ls.mu.Unlock()
+		if err != nil {
+			run.status = StatusFailed
+			run.steps = append(run.steps, &localStep{
+				name:       "error",
+				phase:      err.Error(),
+				startedAt:  time.Now(),
+				finishedAt: time.Now(),
+			})
+		} else {
+			run.status = StatusFinished
+		}
+	}()
+	return nil
+}
+
+func (ls *LocalService) Status(sessionID string) (Status, []byte, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	run := ls.runs[sessionID]
+	if run == nil {
+		return StatusNotFound, nil, nil
+	}
+	var buf bytes.Buffer
+	for i, step := range run.steps {
+		if i > 0 {
+			buf.WriteString("---------\n")
+		}
+		fmt.Fprintf(&buf, "Name: %s\n", step.name)
+		fmt.Fprintf(&buf, "Phase: %s\n", step.phase)
+		fmt.Fprintf(&buf, "StartedAt: %s\n", step.startedAt)
+		fmt.Fprintf(&buf, "FinishedAt: %s\n", step.finishedAt)
+		fmt.Fprintf(&buf, "Args: %q\n", step.args)
+		fmt.Fprintf(&buf, "Log: %s\n", step.logFile)
+	}
+	return run.status, buf.Bytes(), nil
+}
+
+func (ls *LocalService) PollPeriod() time.Duration {
+	return 5 * time.Second
+}
+
+// run mirrors the "main" and "process-fuzz" templates of template.yaml.
+func (ls *LocalService) run(sessionID string, run *localRun) error {
+	dir, err := filepath.Abs(filepath.Join(ls.cfg.Workdir, sessionID))
+	if err != nil {
+		return err
+	}
+	if err := osutil.MkdirAll(dir); err != nil {
+		return err
+	}
+	// The steps reset and check out the repository, so don't let them touch the user's checkout.
+	repo := filepath.Join(dir, "repo")
+	if _, err := osutil.RunCmd(time.Hour, ls.cfg.Repository,
+		"git", "worktree", "add", "--force", "--detach", repo); err != nil {
+		return fmt.Errorf("failed to create a git worktree: %w", err)
+	}
+	defer osutil.RunCmd(time.Hour, ls.cfg.Repository, "git", "worktree", "remove", "--force", repo)
+
+	verdictFile := filepath.Join(dir, "verdict.json")
+	err = ls.step(run, dir, "triage-step",
+		"--session", sessionID,
+		"--repository", repo,
+		"--verdict", verdictFile)
+	if err != nil {
+		return err
+	}
+	var verdict api.TriageResult
+	if err := readJSON(verdictFile, &verdict); err != nil {
+		return err
+	}
+	if verdict.Skip != nil || verdict.Fuzz == nil {
+		// The triage step has already reported the reason.
+		return nil
+	}
+	fuzz := verdict.Fuzz
+	baseDir, patchedDir := filepath.Join(dir, "base"), filepath.Join(dir, "patched")
+	baseBuild, err := ls.build(run, dir, sessionID, repo, "base", "Build Base", &fuzz.Base, false)
+	if err != nil {
+		return err
+	}
+	patchedBuild, err := ls.build(run, dir, sessionID, repo, "patched", "Build Patched", &fuzz.Patched, true)
+	if err != nil {
+		return err
+	}
+	// The boot step expects the kernel at /base regardless of whether it's the base or the patched build.
+	baseBooted, err := ls.boot(run, dir, sessionID, fuzz.Config, "base", "Boot test: Base",
+		map[string]string{"/base": baseDir},
+		"--base_build", baseBuild)
+	if err != nil {
+		return err
+	}
+	patchedBooted, err := ls.boot(run, dir, sessionID, fuzz.Config, "patched", "Boot test: Patched",
+		map[string]string{"/base": patchedDir},
+		"--patched_build", patchedBuild, "-findings=true")
+	if err != nil {
+		return err
+	}
+	if !baseBooted || !patchedBooted {
+		return nil
+	}
+	configs := filepath.Join(dir, "configs-fuzz")
+	err = ls.prepareConfigs(configs, map[string]string{"/base": baseDir, "/patched": patchedDir})
+	if err != nil {
+		return err
+	}
+	repros := filepath.Join(dir, "repros.json")
+	if err := osutil.WriteJSON(repros, fuzz.Repros); err != nil {
+		return err
+	}
+	return ls.step(run, dir, "fuzz-step",
+		"--config", fuzz.Config,
+		"--configs", configs,
+		"--session", sessionID,
+		"--base_build", baseBuild,
+		"--patched_build", patchedBuild,
+		"--corpus_url", fuzz.CorpusURL,
+		"--repros", repros,
+		"--time", ls.cfg.FuzzTime.String(),
+		"--workdir", filepath.Join(dir, "fuzz"))
+}
+
+func (ls *LocalService) build(run *localRun, dir, sessionID, repo, name, testName string,
+	req *api.BuildRequest, findings bool) (string, error) {
+	reqFile := filepath.Join(dir, name+"-request.json")
+	if err := osutil.WriteJSON(reqFile, req); err != nil {
+		return "", err
+	}
+	output := filepath.Join(dir, name)
+	if err := osutil.MkdirAll(output); err != nil {
+		return "", err
+	}
+	err := ls.step(run, dir, "build-step",
+		"--request", reqFile,
+		"--repository", repo,
+		"--output", output,
+		"--session", sessionID,
+		"--test_name", testName,
+		fmt.Sprintf("-findings=%v", findings),
+		"--kernel_configs", ls.cfg.KernelConfigs,
+		"--userspace", ls.cfg.Userspace)
+	if err != nil {
+		return "", err
+	}
+	var result api.BuildResult
+	if err := readJSON(filepath.Join(output, "result.json"), &result); err != nil {
+		return "", err
+	}
+	if !result.Success {
+		return "", fmt.Errorf("%s did not succeed, aborting the workflow", testName)
+	}
+	return result.BuildID, nil
+}
+
+func (ls *LocalService) boot(run *localRun, dir, sessionID, config, name, testName string,
+	paths map[string]string, args ...string) (bool, error) {
+	configs := filepath.Join(dir, "configs-"+name)
+	if err := ls.prepareConfigs(configs, paths); err != nil {
+		return false, err
+	}
+	output := filepath.Join(dir, "boot-"+name+".json")
+	err := ls.step(run, dir, "boot-step", append([]string{
+		"--config", config,
+		"--configs", configs,
+		"--session", sessionID,
+		"--test_name", testName,
+		"--output", output,
+		"--workdir", filepath.Join(dir, "boot-"+name),
+	}, args...)...)
+	if err != nil {
+		return false, err
+	}
+	var result api.BootResult
+	if err := readJSON(output, &result); err != nil {
+		return false, err
+	}
+	return result.Success, nil
+}
+
+// step runs the step binary and saves its output to a log file in the session folder.
+func (ls *LocalService) step(run *localRun, dir, name string, args ...string) error {
+	ls.mu.Lock()
+	step := &localStep{
+		name:      name,
+		phase:     "Running",
+		args:      args,
+		logFile:   filepath.Join(dir, fmt.Sprintf("%02d-%s.log", len(run.steps), name)),
+		startedAt: time.Now(),
+	}
+	run.steps = append(run.steps, step)
+	ls.mu.Unlock()
+
+	err := runStep(filepath.Join(ls.cfg.BinDir, name), args, step.logFile)
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	step.finishedAt = time.Now()
+	step.phase = "Succeeded"
+	if err != nil {
+		step.phase = "Failed"
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+	return nil
+}
+
+func runStep(bin string, args []string, logFile string) error {
+	log, err := os.Create(logFile)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+	cmd := exec.Command(bin, args...)
+	cmd.Stdout = log
+	cmd.Stderr = log
+	return cmd.Run()
+}
+
+// prepareConfigs copies the syzkaller configs to the folder and rewrites the paths
+// that refer to the containers' volumes to the corresponding local folders.
+func (ls *LocalService) prepareConfigs(dst string, paths map[string]string) error {
+	rewrite := map[string]string{
+		"/syzkaller": ls.cfg.Syzkaller,
+		"/workdir":   filepath.Join(dst, "workdir"),
+	}
+	for from, to := range paths {
+		rewrite[from] = to
+	}
+	return filepath.WalkDir(ls.cfg.Configs, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".cfg" {
+			return err
+		}
+		var cfg interface{}
+		if err := config.LoadFile(path, &cfg); err != nil {
+			return fmt.Errorf("%v: %w", path, err)
+		}
+		rel, err := filepath.Rel(ls.cfg.Configs, path)
+		if err != nil {
+			return err
+		}
+		if err := osutil.MkdirAll(filepath.Join(dst, filepath.Dir(rel))); err != nil {
+			return err
+		}
+		return config.SaveFile(filepath.Join(dst, rel), rewritePaths(cfg, rewrite))
+	})
+}
+
+func rewritePaths(val interface{}, paths map[string]string) interface{} {
+	switch v := val.(type) {
+	case string:
+		for from, to := range paths {
+			if v == from || strings.HasPrefix(v, from+"/") {
+				return to + strings.TrimPrefix(v, from)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = rewritePaths(v[i], paths)
+		}
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = rewritePaths(v[key], paths)
+		}
+	}
+	return val
+}
+
+func readJSON(path string, obj interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return fmt.Errorf("failed to parse %v: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/pkg/config"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalService(t *testing.T) {
+	env := newLocalTestEnv(t, `{"build_id": "build", "success": true}`)
+	service := NewLocalService(env.cfg)
+	status := env.run(service, "session")
+	assert.Equal(t, StatusFinished, status)
+	assert.Equal(t, []string{
+		"triage-step", "build-step", "build-step", "boot-step", "boot-step", "fuzz-step",
+	}, env.steps())
+
+	// The paths in the configs must refer to the session folder.
+	var cfg map[string]interface{}
+	dir := filepath.Join(env.cfg.Workdir, "session")
+	require.NoError(t, config.LoadFile(filepath.Join(dir, "configs-fuzz", "all", "base.cfg"), &cfg))
+	assert.Equal(t, filepath.Join(dir, "base", "obj"), cfg["kernel_obj"])
+	assert.Equal(t, env.cfg.Syzkaller, cfg["syzkaller"])
+	require.NoError(t, config.LoadFile(filepath.Join(dir, "configs-patched", "all", "base.cfg"), &cfg))
+	assert.Equal(t, filepath.Join(dir, "patched", "obj"), cfg["kernel_obj"])
+
+	// The worktree must be removed once the workflow is finished.
+	assert.NoDirExists(t, filepath.Join(dir, "repo"))
+}
+
+func TestLocalServiceFailedBuild(t *testing.T) {
+	env := newLocalTestEnv(t, `{"build_id": "build", "success": false}`)
+	service := NewLocalService(env.cfg)
+	status := env.run(service, "session")
+	assert.Equal(t, StatusFailed, status)
+	assert.Equal(t, []string{"triage-step", "build-step"}, env.steps())
+}
+
+type localTestEnv struct {
+	t      *testing.T
+	cfg    LocalConfig
+	record string
+}
+
+func newLocalTestEnv(t *testing.T, buildResult string) *localTestEnv {
+	base := t.TempDir()
+	env := &localTestEnv{
+		t: t,
+		cfg: LocalConfig{
+			BinDir:     filepath.Join(base, "bin"),
+			Repository: filepath.Join(base, "linux"),
+			Workdir:    filepath.Join(base, "workdir"),
+			Configs:    filepath.Join(base, "configs"),
+			Syzkaller:  filepath.Join(base, "syzkaller"),
+			FuzzTime:   time.Minute,
+		},
+		record: filepath.Join(base, "record"),
+	}
+	require.NoError(t, osutil.MkdirAll(env.cfg.Repository))
+	for _, args := range [][]string{
+		{"init"},
+		{"-c", "user.name=test", "-c", "user.email=test@test.com", "commit", "--allow-empty", "-m", "init"},
+	} {
+		_, err := osutil.RunCmd(time.Minute, env.cfg.Repository, "git", args...)
+		require.NoError(t, err)
+	}
+	require.NoError(t, osutil.MkdirAll(filepath.Join(env.cfg.Configs, "all")))
+	require.NoError(t, osutil.WriteFile(filepath.Join(env.cfg.Configs, "all", "base.cfg"),
+		[]byte(`{"kernel_obj": "/base/obj", "syzkaller": "/syzkaller"}`)))
+
+	require.NoError(t, osutil.MkdirAll(env.cfg.BinDir))
+	env.writeStep("triage-step", `echo '{"fuzz": {"config": "all"}}' > "$verdict"`)
+	env.writeStep("build-step", fmt.Sprintf(`echo '%s' > "$output/result.json"`, buildResult))
+	env.writeStep("boot-step", `echo '{"success": true}' > "$output"`)
+	env.writeStep("fuzz-step", ``)
+	return env
+}
+
+func (env *localTestEnv) writeStep(name, action string) {
+	script := fmt.Sprintf(`#!/bin/sh
+echo %s >> %s
+while [ $# -gt 0 ]; do
+	case "$1" in
+	--verdict) verdict="$2";;
+	--output) output="$2";;
+	esac
+	shift
+done
+%s
+`, name, env.record, action)
+	err := os.WriteFile(filepath.Join(env.cfg.BinDir, name), []byte(script), 0755)
+	require.NoError(env.t, err)
+}
+
+func (env *localTestEnv) run(service *LocalService, sessionID string) Status {
+	require.NoError(env.t, service.Start(sessionID))
+	for start := time.Now(); time.Since(start) < time.Minute; time.Sleep(10 * time.Millisecond) {
+		status, _, err := service.Status(sessionID)
+		require.NoError(env.t, err)
+		if status != StatusRunning {
+			return status
+		}
+	}
+	env.t.Fatalf("the workflow did not finish")
+	return ""
+}
+
+func (env *localTestEnv) steps() []string {
+	data, err := os.ReadFile(env.record)
+	require.NoError(env.t, err)
+	return strings.Fields(string(data))
+}
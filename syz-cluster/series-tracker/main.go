@@ -22,15 +22,27 @@ import (
 	"github.com/google/syzkaller/pkg/vcs"
 	"github.com/google/syzkaller/syz-cluster/pkg/api"
 	"github.com/google/syzkaller/syz-cluster/pkg/app"
+	"github.com/google/syzkaller/syz-cluster/pkg/localseries"
 )
 
 var (
 	flagVerbose = flag.Bool("verbose", false, "enable verbose output")
+	// Instead of polling the lore archives, submit a single series from the local sources and exit.
+	flagMbox    = flag.String("mbox", "", "submit the patch series from the mbox file")
+	flagPatches = flag.String("patches", "", "submit the patch series from the git format-patch output folder")
+	flagRepo    = flag.String("repo", "", "the git repository for --range")
+	flagRange   = flag.String("range", "", "submit the commits of the range (e.g. origin/master..my-branch)")
 )
 
 func main() {
 	flag.Parse()
 	ctx := context.Background()
+	if *flagMbox != "" || *flagPatches != "" || *flagRange != "" {
+		if err := submitLocal(ctx); err != nil {
+			app.Fatalf("%v", err)
+		}
+		return
+	}
 	manifest := NewManifestSource(`https://lore.kernel.org`)
 	fetcher := &SeriesFetcher{
 		gitRepoFolder: `/git-repo`, // Set in deployment.yaml.
@@ -54,6 +66,36 @@ func main() {
 	}
 }
 
+func submitLocal(ctx context.Context) error {
+	var series *api.Series
+	var err error
+	switch {
+	case *flagMbox != "":
+		series, err = localseries.ReadMbox(*flagMbox)
+	case *flagPatches != "":
+		series, err = localseries.ReadPatchDir(*flagPatches)
+	default:
+		if *flagRepo == "" {
+			return fmt.Errorf("--repo must be set together with --range")
+		}
+		series, err = localseries.ReadGitRange(*flagRepo, *flagRange)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the series: %w", err)
+	}
+	if *flagVerbose {
+		for _, patch := range series.Patches {
+			log.Printf("  #%d %s", patch.Seq, patch.Title)
+		}
+	}
+	sessionID, err := localseries.Upload(ctx, app.DefaultClient(), series)
+	if err != nil {
+		return err
+	}
+	log.Printf("submitted %q (%d patches), session %s", series.Title, len(series.Patches), sessionID)
+	return nil
+}
+
 func archivesToPoll() []string {
 	cfg, err := app.Config()
 	if err != nil {
@@ -25,6 +25,8 @@ var (
 	flagPatchedBuild = flag.String("patched_build", "", "patched build ID")
 	flagOutput       = flag.String("output", "", "where to store the result")
 	flagFindings     = flag.Bool("findings", false, "report failur as findings")
+	flagConfigs      = flag.String("configs", "/configs", "path to the syzkaller configs folder")
+	flagWorkdir      = flag.String("workdir", "/tmp/test-workdir", "syzkaller workdir path")
 )
 
 func main() {
@@ -72,11 +74,11 @@ func main() {
 }
 
 func runTest(ctx context.Context, client *api.Client) (bool, error) {
-	cfg, err := mgrconfig.LoadFile(filepath.Join("/configs", *flagConfig, "base.cfg"))
+	cfg, err := mgrconfig.LoadFile(filepath.Join(*flagConfigs, *flagConfig, "base.cfg"))
 	if err != nil {
 		return false, err
 	}
-	cfg.Workdir = "/tmp/test-workdir"
+	cfg.Workdir = *flagWorkdir
 	rep, err := instance.RunSmokeTest(cfg)
 	if err != nil {
 		return false, err
@@ -25,13 +25,16 @@ import (
 )
 
 var (
-	flagRequest    = flag.String("request", "", "path to a build request description")
-	flagRepository = flag.String("repository", "", "path to a kernel checkout")
-	flagOutput     = flag.String("output", "", "path to save kernel build artifacts")
-	flagTestName   = flag.String("test_name", "", "test name")
-	flagSession    = flag.String("session", "", "session ID")
-	flagFindings   = flag.Bool("findings", false, "report build failures as findings")
-	flagSmokeBuild = flag.Bool("smoke_build", false, "build only if new, don't report findings")
+	flagRequest       = flag.String("request", "", "path to a build request description")
+	flagRepository    = flag.String("repository", "", "path to a kernel checkout")
+	flagOutput        = flag.String("output", "", "path to save kernel build artifacts")
+	flagTestName      = flag.String("test_name", "", "test name")
+	flagSession       = flag.String("session", "", "session ID")
+	flagFindings      = flag.Bool("findings", false, "report build failures as findings")
+	flagSmokeBuild    = flag.Bool("smoke_build", false, "build only if new, don't report findings")
+	flagKernelConfigs = flag.String("kernel_configs", "/kernel-configs", "path to the kernel configs folder")
+	// See the Dockerfile.
+	flagUserspace = flag.String("userspace", "/disk-images/buildroot_amd64_2024.09", "path to the userspace image")
 )
 
 func main() {
@@ -201,7 +204,7 @@ func checkoutKernel(tracer debugtracer.DebugTracer, req *api.BuildRequest, serie
 }
 
 func buildKernel(tracer debugtracer.DebugTracer, req *api.BuildRequest) error {
-	kernelConfig, err := os.ReadFile(filepath.Join("/kernel-configs", req.ConfigName))
+	kernelConfig, err := os.ReadFile(filepath.Join(*flagKernelConfigs, req.ConfigName))
 	if err != nil {
 		return fmt.Errorf("failed to read the kernel config: %w", err)
 	}
@@ -217,7 +220,7 @@ func buildKernel(tracer debugtracer.DebugTracer, req *api.BuildRequest) error {
 		OutputDir:    *flagOutput,
 		Compiler:     "clang",
 		Linker:       "ld.lld",
-		UserspaceDir: "/disk-images/buildroot_amd64_2024.09", // See the Dockerfile.
+		UserspaceDir: *flagUserspace,
 		Config:       kernelConfig,
 		Tracer:       tracer,
 	}
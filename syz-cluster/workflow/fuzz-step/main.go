@@ -35,6 +35,8 @@ var (
 	flagTime         = flag.String("time", "1h", "how long to fuzz")
 	flagWorkdir      = flag.String("workdir", "/workdir", "base workdir path")
 	flagCorpusURL    = flag.String("corpus_url", "", "an URL to download corpus from")
+	flagConfigs      = flag.String("configs", "/configs", "path to the syzkaller configs folder")
+	flagRepros       = flag.String("repros", "", "a JSON file with the reproducers to run before fuzzing")
 )
 
 const testName = "Fuzzing"
@@ -57,6 +59,17 @@ func main() {
 		app.Fatalf("failed to report the test: %v", err)
 	}
 
+	if *flagRepros != "" {
+		var repros []*api.ReproTest
+		if err := config.LoadFile(*flagRepros, &repros); err != nil {
+			app.Fatalf("failed to load the reproducers: %v", err)
+		}
+		// The reproducers must leave most of the time to fuzzing.
+		start := time.Now()
+		runRepros(ctx, client, repros, d/4)
+		d -= time.Since(start)
+	}
+
 	artifactsDir := filepath.Join(*flagWorkdir, "artifacts")
 	osutil.MkdirAll(artifactsDir)
 	store := &manager.DiffFuzzerStore{BasePath: artifactsDir}
@@ -90,7 +103,7 @@ func run(baseCtx context.Context, client *api.Client, timeout time.Duration,
 	const MB = 1000000
 	log.EnableLogCaching(100000, 10*MB)
 
-	base, patched, err := loadConfigs("/configs", *flagConfig, true)
+	base, patched, err := loadConfigs(*flagConfigs, *flagConfig, true)
 	if err != nil {
 		return fmt.Errorf("failed to load configs: %w", err)
 	}
@@ -0,0 +1,270 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/syzkaller/pkg/csource"
+	"github.com/google/syzkaller/pkg/instance"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/syz-cluster/pkg/api"
+	"github.com/google/syzkaller/syz-cluster/pkg/app"
+	"github.com/google/syzkaller/vm"
+)
+
+// runRepros runs the known reproducers selected by the triage step on the patched kernel
+// and reports the crashes that don't reproduce on the base kernel.
+// The reproducers that don't fit into the timeout are skipped.
+func runRepros(ctx context.Context, client *api.Client, repros []*api.ReproTest, timeout time.Duration) {
+	if len(repros) == 0 {
+		return
+	}
+	reportRepros := func(status string, output []byte) {
+		err := client.UploadTestResult(ctx, &api.TestResult{
+			SessionID:      *flagSession,
+			TestName:       api.ReproducersTestName,
+			BaseBuildID:    *flagBaseBuild,
+			PatchedBuildID: *flagPatchedBuild,
+			Result:         status,
+			Log:            output,
+		})
+		if err != nil {
+			app.Errorf("failed to upload the reproducers test status: %v", err)
+		}
+	}
+	reportRepros(api.TestRunning, nil)
+	var output bytes.Buffer
+	reproCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	status, err := reproRegressions(reproCtx, client, repros, &output)
+	if err != nil {
+		app.Errorf("failed to run the reproducers: %v", err)
+		fmt.Fprintf(&output, "failed to run the reproducers: %v\n", err)
+		status = api.TestError
+	}
+	reportRepros(status, output.Bytes())
+}
+
+func reproRegressions(ctx context.Context, client *api.Client, repros []*api.ReproTest,
+	output io.Writer) (string, error) {
+	base, patched, err := loadConfigs(*flagConfigs, *flagConfig, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to load configs: %w", err)
+	}
+	baseRunner, err := newReproRunner(base)
+	if err != nil {
+		return "", err
+	}
+	defer baseRunner.close()
+	patchedRunner, err := newReproRunner(patched)
+	if err != nil {
+		return "", err
+	}
+	defer patchedRunner.close()
+
+	status, errored := api.TestPassed, false
+	deadline, _ := ctx.Deadline()
+	for i, repro := range repros {
+		// Don't start a reproducer that may not finish before the deadline.
+		if time.Until(deadline) < baseRunner.maxRunTime()+patchedRunner.maxRunTime() {
+			fmt.Fprintf(output, "out of time, skipped %d reproducers\n", len(repros)-i)
+			errored = true
+			break
+		}
+		var reproOutput bytes.Buffer
+		res, err := runRepro(ctx, client, repro, baseRunner, patchedRunner,
+			io.MultiWriter(output, &reproOutput))
+		if err != nil {
+			return "", err
+		}
+		if res.regression {
+			status = api.TestFailed
+		}
+		errored = errored || res.errored
+		if repro.PrevFindingID != "" {
+			reportPrevFinding(ctx, client, repro.PrevFindingID, res, reproOutput.Bytes())
+		}
+	}
+	// The reproducers that could not be run prove nothing, so don't report a clean result.
+	if status == api.TestPassed && errored {
+		status = api.TestError
+	}
+	return status, nil
+}
+
+type reproResult struct {
+	errored    bool // The reproducer could not be downloaded or run.
+	crashed    bool // The reproducer crashed the patched kernel.
+	regression bool // The reproducer crashed the patched kernel, but not the base one.
+}
+
+func runRepro(ctx context.Context, client *api.Client, repro *api.ReproTest,
+	baseRunner, patchedRunner *reproRunner, output io.Writer) (reproResult, error) {
+	var res reproResult
+	fmt.Fprintf(output, "%s: %s\n", repro.Title, repro.Reason)
+	prog, opts := repro.SyzRepro, repro.SyzReproOpts
+	if len(prog) == 0 {
+		var err error
+		prog, err = download(ctx, repro.SyzReproURL)
+		if err != nil {
+			fmt.Fprintf(output, "\tfailed to download the reproducer: %v\n", err)
+			res.errored = true
+			return res, nil
+		}
+		opts = reproOpts(prog)
+	}
+	patchedRep, err := patchedRunner.run(prog, opts)
+	if err != nil {
+		fmt.Fprintf(output, "\tfailed to run on the patched kernel: %v\n", err)
+		res.errored = true
+		return res, nil
+	}
+	if patchedRep == nil {
+		fmt.Fprintf(output, "\tdid not crash the patched kernel\n")
+		return res, nil
+	}
+	res.crashed = true
+	baseRep, err := baseRunner.run(prog, opts)
+	if err != nil {
+		fmt.Fprintf(output, "\tcrashed the patched kernel: %q\n", patchedRep.Title)
+		fmt.Fprintf(output, "\tfailed to run on the base kernel: %v\n", err)
+		res.errored = true
+		return res, nil
+	}
+	if baseRep != nil {
+		fmt.Fprintf(output, "\tcrashed both kernels: %q and %q\n", baseRep.Title, patchedRep.Title)
+		return res, nil
+	}
+	fmt.Fprintf(output, "\tcrashed only the patched kernel: %q\n", patchedRep.Title)
+	res.regression = true
+	err = client.UploadFinding(ctx, &api.NewFinding{
+		SessionID:    *flagSession,
+		TestName:     api.ReproducersTestName,
+		Title:        patchedRep.Title,
+		Report:       patchedRep.Report,
+		Log:          patchedRep.Output,
+		SyzRepro:     prog,
+		SyzReproOpts: opts,
+	})
+	if err != nil {
+		return res, fmt.Errorf("failed to report a finding: %w", err)
+	}
+	return res, nil
+}
+
+// reportPrevFinding saves whether the reproducer of a finding of the previous series version
+// still crashes the patched kernel. Only a complete run without any crash proves that the finding is fixed.
+func reportPrevFinding(ctx context.Context, client *api.Client, findingID string, res reproResult, output []byte) {
+	status := api.TestPassed
+	if res.crashed {
+		status = api.TestFailed
+	} else if res.errored {
+		status = api.TestError
+	}
+	err := client.UploadTestResult(ctx, &api.TestResult{
+		SessionID:      *flagSession,
+		TestName:       api.PrevFindingTestName(findingID),
+		BaseBuildID:    *flagBaseBuild,
+		PatchedBuildID: *flagPatchedBuild,
+		Result:         status,
+		Log:            output,
+	})
+	if err != nil {
+		app.Errorf("failed to upload the result of the previous finding's reproducer: %v", err)
+	}
+}
+
+type reproRunner struct {
+	cfg      *mgrconfig.Config
+	pool     *vm.Pool
+	reporter *report.Reporter
+}
+
+func newReproRunner(cfg *mgrconfig.Config) (*reproRunner, error) {
+	reporter, err := report.NewReporter(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reporter for %q: %w", cfg.Name, err)
+	}
+	pool, err := vm.Create(cfg, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the VM pool for %q: %w", cfg.Name, err)
+	}
+	return &reproRunner{cfg: cfg, pool: pool, reporter: reporter}, nil
+}
+
+func (rr *reproRunner) close() {
+	rr.pool.Close()
+}
+
+const (
+	reproTime = 5 * time.Minute
+	// It includes VM boot.
+	reproVMTime = reproTime + 5*time.Minute
+)
+
+// maxRunTime returns an upper bound on the duration of a run() call.
+func (rr *reproRunner) maxRunTime() time.Duration {
+	return reproVMTime * rr.cfg.Timeouts.Scale
+}
+
+// run returns nil if the reproducer did not crash the kernel.
+func (rr *reproRunner) run(prog, optsData []byte) (*report.Report, error) {
+	opts := csource.DefaultOpts(rr.cfg)
+	if len(optsData) != 0 {
+		var err error
+		opts, err = csource.DeserializeOptions(optsData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the reproducer options: %w", err)
+		}
+	}
+	// Same as in instance.Env: it increases the chances to reproduce the crash.
+	opts.Repeat, opts.Threaded = true, true
+	inst, err := instance.CreateExecProgInstance(rr.pool, 0, rr.cfg, rr.reporter, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the VM: %w", err)
+	}
+	defer inst.VMInstance.Close()
+	res, err := inst.RunSyzProg(instance.ExecParams{
+		SyzProg:  prog,
+		Opts:     opts,
+		Duration: reproTime * rr.cfg.Timeouts.Scale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run the reproducer: %w", err)
+	}
+	return res.Report, nil
+}
+
+// The reproducers downloaded from the dashboard keep the options in a "#{...}" comment line.
+func reproOpts(prog []byte) []byte {
+	for _, line := range bytes.Split(prog, []byte("\n")) {
+		if bytes.HasPrefix(line, []byte("#{")) {
+			return line[1:]
+		}
+	}
+	return nil
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status is not 200: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
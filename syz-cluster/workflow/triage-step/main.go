@@ -11,8 +11,11 @@ import (
 	"io"
 	"os"
 
+	dashboard "github.com/google/syzkaller/dashboard/api"
 	"github.com/google/syzkaller/pkg/debugtracer"
 	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/subsystem"
+	_ "github.com/google/syzkaller/pkg/subsystem/lists"
 	"github.com/google/syzkaller/syz-cluster/pkg/api"
 	"github.com/google/syzkaller/syz-cluster/pkg/app"
 	"github.com/google/syzkaller/syz-cluster/pkg/triage"
@@ -22,6 +25,9 @@ var (
 	flagSession = flag.String("session", "", "session ID")
 	flagRepo    = flag.String("repository", "", "path to a kernel checkout")
 	flagVerdict = flag.String("verdict", "", "where to save the verdict")
+	// The reproducers of the known bugs are taken from the dashboard.
+	flagDashboard   = flag.String("dashboard", "https://syzkaller.appspot.com", "dashboard URL")
+	flagDashboardNs = flag.String("dashboard_ns", "upstream", "dashboard namespace")
 )
 
 func main() {
@@ -115,5 +121,56 @@ func getVerdict(ctx context.Context, client *api.Client, ops triage.TreeOps) (*a
 		},
 	}
 	ret.Fuzz.Patched.SeriesID = series.ID
+	ret.Fuzz.Repros, err = prevRepros(ctx, client)
+	if err != nil {
+		// TODO: the workflow step must be retried.
+		return nil, err
+	}
+	ret.Fuzz.Repros = append(ret.Fuzz.Repros, selectRepros(series)...)
+	return ret, nil
+}
+
+// Each reproducer takes several minutes to run on both kernels before fuzzing starts.
+const maxPrevRepros = 10
+
+// prevRepros returns the reproducers of the issues found in the previous version of the series.
+// They go first, so that we quickly learn whether the issues were addressed.
+func prevRepros(ctx context.Context, client *api.Client) ([]*api.ReproTest, error) {
+	resp, err := client.GetPrevFindings(ctx, *flagSession)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the previous findings: %w", err)
+	}
+	// The workflow passes the list to the fuzz step as is, so it must not be null.
+	ret := []*api.ReproTest{}
+	for _, finding := range resp.Findings {
+		if len(finding.SyzRepro) == 0 {
+			continue
+		}
+		if len(ret) == maxPrevRepros {
+			// The rest remain unverified, which is still better than no fuzzing.
+			break
+		}
+		ret = append(ret, &api.ReproTest{
+			Title:         finding.Title,
+			Reason:        "found in the previous version of the series",
+			SyzRepro:      finding.SyzRepro,
+			SyzReproOpts:  finding.SyzReproOpts,
+			PrevFindingID: finding.ID,
+		})
+	}
 	return ret, nil
 }
+
+// selectRepros never fails: the reproducers are a nice-to-have addition to fuzzing.
+func selectRepros(series *api.Series) []*api.ReproTest {
+	selector := triage.NewReproSelector(dashboard.NewClient(*flagDashboard, ""), *flagDashboard, *flagDashboardNs,
+		subsystem.MakePathMatcher(subsystem.GetList("linux")), &debugtracer.GenericTracer{
+			TraceWriter: os.Stderr,
+		})
+	repros, err := selector.Select(series)
+	if err != nil {
+		app.Errorf("failed to select the reproducers: %v", err)
+		return nil
+	}
+	return repros
+}
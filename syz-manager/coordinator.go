@@ -0,0 +1,261 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/pkg/coordinator"
+	"github.com/google/syzkaller/pkg/corpus"
+	"github.com/google/syzkaller/pkg/fuzzer"
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/manager"
+	"github.com/google/syzkaller/pkg/signal"
+	"github.com/google/syzkaller/pkg/stat"
+	"github.com/google/syzkaller/prog"
+)
+
+// coordinatorConnector shares max signal, corpus inputs and crash titles
+// with other managers connected to the same syz-coordinator.
+type coordinatorConnector struct {
+	mgr *Manager
+	// Serializes sync calls since the client is not thread-safe.
+	syncMu sync.Mutex
+	client *coordinator.Client
+
+	mu sync.Mutex
+	// The local updates that are not yet sent to the coordinator.
+	signal   []uint64
+	inputs   []*coordinator.Input
+	released []string
+	// Inputs received from other managers (with the time they were received), they must not be sent back.
+	imported map[string]time.Time
+	// Crash titles claimed by this manager (with the time the claim was last sent)
+	// and by other managers.
+	claimed map[string]time.Time
+	foreign map[string]bool
+
+	statSendInput      *stat.Val
+	statRecvInput      *stat.Val
+	statRecvInputDrop  *stat.Val
+	statRecvSignal     *stat.Val
+	statForeignCrashes *stat.Val
+}
+
+const (
+	coordinatorSyncPeriod = 10 * time.Second
+	// The claims expire on the coordinator after coordinator.ClaimLease, so renew them in advance.
+	claimRenewPeriod = coordinator.ClaimLease / 4
+	// The received inputs reach addInput soon after they are saved to the corpus (if they do at all),
+	// so there's no need to remember them for longer.
+	importedExpiration = time.Hour
+)
+
+func (mgr *Manager) newCoordinatorConnector() *coordinatorConnector {
+	return &coordinatorConnector{
+		mgr:      mgr,
+		client:   coordinator.NewClient(mgr.cfg.CoordinatorAddr, mgr.cfg.Name),
+		imported: make(map[string]time.Time),
+		claimed:  make(map[string]time.Time),
+		foreign:  make(map[string]bool),
+
+		statSendInput: stat.New("coord send input", "Corpus inputs sent to the coordinator",
+			stat.Graph("coordinator")),
+		statRecvInput: stat.New("coord recv input", "Corpus inputs received from other managers",
+			stat.Graph("coordinator")),
+		statRecvInputDrop: stat.New("coord recv input drop", "", stat.NoGraph),
+		statRecvSignal: stat.New("coord recv signal", "Max signal received from other managers",
+			stat.NoGraph),
+		statForeignCrashes: stat.New("coord foreign crashes",
+			"Crashes that are reproduced by other managers", stat.NoGraph),
+	}
+}
+
+func (cc *coordinatorConnector) loop(fuzzer *fuzzer.Fuzzer) {
+	for ; ; time.Sleep(coordinatorSyncPeriod) {
+		if err := cc.sync(fuzzer); err != nil {
+			log.Logf(0, "coordinator sync failed: %v", err)
+		}
+	}
+}
+
+func (cc *coordinatorConnector) sync(fuzzer *fuzzer.Fuzzer) error {
+	cc.syncMu.Lock()
+	defer cc.syncMu.Unlock()
+	req := cc.grab(fuzzer, !cc.client.Connected())
+	reply, err := cc.client.Sync(req)
+	if err != nil {
+		cc.putBack(req)
+		return err
+	}
+	cc.statSendInput.Add(len(req.Inputs))
+	cc.apply(fuzzer, reply)
+	return nil
+}
+
+// grab takes all pending local updates. If the coordinator connection needs to be
+// re-established, it sends the whole max signal and all claimed crashes.
+// The claims that were not sent for a while are re-sent to renew them.
+func (cc *coordinatorConnector) grab(fuzzer *fuzzer.Fuzzer, full bool) *coordinator.SyncRequest {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	req := &coordinator.SyncRequest{
+		Signal:   cc.signal,
+		Inputs:   cc.inputs,
+		Released: cc.released,
+	}
+	cc.signal, cc.inputs, cc.released = nil, nil, nil
+	if full {
+		req.Signal = fuzzer.Cover.CopyMaxSignal().ToRaw()
+	}
+	now := time.Now()
+	for sig, received := range cc.imported {
+		if now.Sub(received) > importedExpiration {
+			delete(cc.imported, sig)
+		}
+	}
+	for title, sent := range cc.claimed {
+		if full || now.Sub(sent) >= claimRenewPeriod {
+			req.Crashes = append(req.Crashes, title)
+			cc.claimed[title] = now
+		}
+	}
+	return req
+}
+
+func (cc *coordinatorConnector) putBack(req *coordinator.SyncRequest) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	// Signal and claimed crashes will be re-sent in full after reconnect.
+	cc.inputs = append(req.Inputs, cc.inputs...)
+	cc.released = append(req.Released, cc.released...)
+}
+
+func (cc *coordinatorConnector) apply(fuzzer *fuzzer.Fuzzer, reply *coordinator.SyncReply) {
+	cc.mu.Lock()
+	for _, title := range reply.Crashes {
+		if !cc.foreign[title] {
+			cc.foreign[title] = true
+			cc.statForeignCrashes.Add(1)
+		}
+	}
+	for _, title := range reply.Released {
+		if cc.foreign[title] {
+			delete(cc.foreign, title)
+			cc.statForeignCrashes.Add(-1)
+		}
+	}
+	cc.mu.Unlock()
+
+	newSignal := fuzzer.Cover.AddMaxSignal(reply.Signal)
+	for _, inp := range reply.Inputs {
+		p, err := cc.parseProgram(fuzzer, inp)
+		if err != nil {
+			log.Logf(1, "rejecting program from %v: %v", inp.Manager, err)
+			cc.statRecvInputDrop.Add(1)
+			continue
+		}
+		// Mark the input before saving, corpusInputHandler may get it right away.
+		cc.mu.Lock()
+		cc.imported[hash.String(p.Serialize())] = time.Now()
+		cc.mu.Unlock()
+		newSignal.Merge(fuzzer.Cover.AddMaxSignal(inp.Signal))
+		fuzzer.Config.Corpus.Save(corpus.NewInput{
+			Prog:   p,
+			Call:   int(inp.Call),
+			Signal: signal.FromRaw(inp.Signal, 0),
+			Cover:  inp.Cover,
+		})
+		cc.statRecvInput.Add(1)
+	}
+	cc.statRecvSignal.Add(newSignal.Len())
+	if len(newSignal) != 0 && cc.mgr.cfg.Cover && !cc.mgr.cfg.Snapshot {
+		cc.mgr.serv.DistributeSignalDelta(newSignal)
+	}
+}
+
+func (cc *coordinatorConnector) parseProgram(fuzzer *fuzzer.Fuzzer, inp *coordinator.Input) (*prog.Prog, error) {
+	p, err := manager.ParseSeed(cc.mgr.target, []byte(inp.Prog))
+	if err != nil {
+		return nil, err
+	}
+	if !p.OnlyContains(fuzzer.Config.EnabledCalls) {
+		return nil, fmt.Errorf("contains disabled calls")
+	}
+	if inp.Call < -1 || int(inp.Call) >= len(p.Calls) {
+		return nil, fmt.Errorf("bad call index %v", inp.Call)
+	}
+	return p, nil
+}
+
+// addSignal queues new max signal found by the local fuzzer.
+func (cc *coordinatorConnector) addSignal(sig signal.Signal) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.signal = append(cc.signal, sig.ToRaw()...)
+}
+
+// addInput queues a new corpus input found by the local fuzzer.
+func (cc *coordinatorConnector) addInput(item *corpus.Item) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if _, ok := cc.imported[item.Sig]; ok {
+		return
+	}
+	cc.inputs = append(cc.inputs, &coordinator.Input{
+		Prog:   string(item.Prog.Serialize()),
+		Call:   int32(item.Call),
+		Signal: item.Signal.ToRaw(),
+		Cover:  item.Cover,
+	})
+}
+
+// claimCrash returns false if the crash is already reproduced by another manager.
+// Otherwise it tells the coordinator that this manager is going to reproduce it.
+func (cc *coordinatorConnector) claimCrash(title string) bool {
+	cc.mu.Lock()
+	if cc.foreign[title] {
+		cc.mu.Unlock()
+		return false
+	}
+	_, claimed := cc.claimed[title]
+	if !claimed {
+		// The zero time makes grab send the claim with the next sync.
+		cc.claimed[title] = time.Time{}
+	}
+	cc.mu.Unlock()
+	fuzzer := cc.mgr.fuzzer.Load()
+	if claimed || fuzzer == nil {
+		return true
+	}
+	// Send the claim before we start reproducing, otherwise other managers may claim
+	// the same crash in the meantime. If the sync fails, the claim is sent later.
+	if err := cc.sync(fuzzer); err != nil {
+		log.Logf(0, "coordinator sync failed: %v", err)
+		return true
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if !cc.foreign[title] {
+		return true
+	}
+	// The coordinator has rejected the claim since another manager has claimed the crash first.
+	delete(cc.claimed, title)
+	return false
+}
+
+// releaseCrash tells other managers that they may reproduce the crash
+// since this manager has failed to do so.
+func (cc *coordinatorConnector) releaseCrash(title string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if _, ok := cc.claimed[title]; !ok {
+		return
+	}
+	delete(cc.claimed, title)
+	cc.released = append(cc.released, title)
+}
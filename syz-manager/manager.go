@@ -24,6 +24,7 @@ import (
 	"github.com/google/syzkaller/dashboard/dashapi"
 	"github.com/google/syzkaller/pkg/asset"
 	"github.com/google/syzkaller/pkg/corpus"
+	"github.com/google/syzkaller/pkg/cover"
 	"github.com/google/syzkaller/pkg/db"
 	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/fuzzer"
@@ -80,6 +81,7 @@ type Manager struct {
 	reportGenerator *manager.ReportGeneratorWrapper
 	fresh           bool
 	coverFilters    manager.CoverageFilters
+	coverTimeline   *cover.Timeline
 
 	dash *dashapi.Dashboard
 	// This is specifically separated from dash, so that we can keep dash = nil when
@@ -108,6 +110,7 @@ type Manager struct {
 	fsckChecker  image.FsckChecker
 
 	reproLoop *manager.ReproLoop
+	coord     *coordinatorConnector
 
 	Stats
 }
@@ -240,6 +243,7 @@ func main() {
 	if !mode.UseDashboard {
 		cfg.DashboardClient = ""
 		cfg.HubClient = ""
+		cfg.CoordinatorAddr = ""
 	}
 	RunManager(mode, cfg)
 }
@@ -253,6 +257,10 @@ func RunManager(mode *Mode, cfg *mgrconfig.Config) {
 			log.Fatalf("%v", err)
 		}
 		defer vmPool.Close()
+		if as := cfg.Autoscale; as != nil && max(as.MinVMs, as.MaxVMs) > vmPool.Count() {
+			log.Fatalf("bad autoscale bounds: min_vms=%v max_vms=%v exceed the VM count %v",
+				as.MinVMs, as.MaxVMs, vmPool.Count())
+		}
 	}
 
 	osutil.MkdirAll(cfg.Workdir)
@@ -290,8 +298,23 @@ func RunManager(mode *Mode, cfg *mgrconfig.Config) {
 		StartTime:  time.Now(),
 		CrashStore: mgr.crashStore,
 	}
+	if cfg.Cover && mgr.mode.LoadCorpus {
+		build, err := cover.TimelineBuild(cfg)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		mgr.coverTimeline, err = cover.OpenTimeline(filepath.Join(cfg.Workdir, "cover_timeline"), build)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		defer mgr.coverTimeline.Close()
+		mgr.http.CoverTimeline = mgr.coverTimeline
+	}
 
 	mgr.initStats()
+	if cfg.CoordinatorAddr != "" {
+		mgr.coord = mgr.newCoordinatorConnector()
+	}
 	if mgr.mode.LoadCorpus {
 		go mgr.preloadCorpus()
 	} else {
@@ -365,6 +388,15 @@ func RunManager(mode *Mode, cfg *mgrconfig.Config) {
 	mgr.reproLoop = manager.NewReproLoop(mgr, reproVMs, mgr.cfg.DashboardOnlyRepro)
 	mgr.http.ReproLoop = mgr.reproLoop
 	mgr.http.TogglePause = mgr.pool.TogglePause
+	if as := mgr.cfg.Autoscale; as != nil {
+		go mgr.pool.Autoscale(ctx, dispatcher.AutoscaleConfig{
+			Min:           as.MinVMs,
+			Max:           as.MaxVMs,
+			Interval:      time.Duration(as.Interval) * time.Second,
+			MaxHostLoad:   as.MaxHostLoad,
+			TriageBacklog: as.TriageBacklog,
+		}, mgr.scaleSignals)
+	}
 
 	if mgr.cfg.HTTP != "" {
 		go func() {
@@ -549,6 +581,9 @@ func (mgr *Manager) processRepro(res *manager.ReproResult) {
 		} else {
 			log.Logf(1, "report repro failure of '%v'", res.Crash.Title)
 			mgr.saveFailedRepro(res.Crash.Report, res.Stats)
+			if mgr.coord != nil {
+				mgr.coord.releaseCrash(res.Crash.Title)
+			}
 		}
 	} else {
 		mgr.saveRepro(res)
@@ -597,6 +632,7 @@ func (mgr *Manager) fuzzerInstance(ctx context.Context, inst *vm.Instance, updIn
 	injectExec := make(chan bool, 10)
 	serv.CreateInstance(inst.Index(), injectExec, updInfo)
 
+	start := time.Now()
 	rep, vmInfo, err := mgr.runInstanceInner(ctx, inst, injectExec, vm.EarlyFinishCb(func() {
 		// Depending on the crash type and kernel config, fuzzing may continue
 		// running for several seconds even after kernel has printed a crash report.
@@ -624,6 +660,28 @@ func (mgr *Manager) fuzzerInstance(ctx context.Context, inst *vm.Instance, updIn
 	if err != nil {
 		log.Logf(1, "VM %v: failed with error: %v", inst.Index(), err)
 	}
+	if ctx.Err() == nil {
+		mgr.reportHealth(inst.Index(), time.Since(start), rep, err)
+	}
+}
+
+func (mgr *Manager) reportHealth(index int, duration time.Duration, rep *report.Report, err error) {
+	ev := dispatcher.HealthOK
+	switch {
+	case err != nil:
+		ev = dispatcher.HealthInfraError
+	case rep == nil:
+	case rep.Type == crash_pkg.LostConnection:
+		ev = dispatcher.HealthLostConnection
+	case vm.IsNoOutputReport(rep):
+		ev = dispatcher.HealthNoOutput
+	case duration < time.Minute*mgr.cfg.Timeouts.Scale:
+		ev = dispatcher.HealthFastCrash
+	default:
+		// A normal kernel crash says nothing about the health of the VM.
+		return
+	}
+	mgr.pool.ReportHealth(index, ev)
 }
 
 func (mgr *Manager) runInstanceInner(ctx context.Context, inst *vm.Instance, injectExec <-chan bool,
@@ -775,7 +833,10 @@ func (mgr *Manager) needLocalRepro(crash *manager.Crash) bool {
 	if mgr.crashStore.HasRepro(crash.Title) {
 		return false
 	}
-	return mgr.crashStore.MoreReproAttempts(crash.Title)
+	if !mgr.crashStore.MoreReproAttempts(crash.Title) {
+		return false
+	}
+	return mgr.coord == nil || mgr.coord.claimCrash(crash.Title)
 }
 
 func (mgr *Manager) NeedRepro(crash *manager.Crash) bool {
@@ -890,20 +951,21 @@ func (mgr *Manager) saveRepro(res *manager.ReproResult) {
 		}
 
 		dc := &dashapi.Crash{
-			BuildID:       mgr.cfg.Tag,
-			Title:         report.Title,
-			AltTitles:     report.AltTitles,
-			Suppressed:    report.Suppressed,
-			Recipients:    report.Recipients.ToDash(),
-			Log:           output,
-			Flags:         crashFlags,
-			Report:        report.Report,
-			ReproOpts:     repro.Opts.Serialize(),
-			ReproSyz:      progText,
-			ReproC:        cprogText,
-			ReproLog:      truncateReproLog(res.Stats.FullLog()),
-			Assets:        mgr.uploadReproAssets(repro),
-			OriginalTitle: res.Crash.Title,
+			BuildID:          mgr.cfg.Tag,
+			Title:            report.Title,
+			AltTitles:        report.AltTitles,
+			Suppressed:       report.Suppressed,
+			Recipients:       report.Recipients.ToDash(),
+			Log:              output,
+			Flags:            crashFlags,
+			Report:           report.Report,
+			ReproOpts:        repro.Opts.Serialize(),
+			ReproSyz:         progText,
+			ReproC:           cprogText,
+			ReproLog:         truncateReproLog(res.Stats.FullLog()),
+			Assets:           mgr.uploadReproAssets(repro),
+			OriginalTitle:    res.Crash.Title,
+			ReproReliability: repro.Reliability,
 		}
 		setGuiltyFiles(dc, report)
 		if _, err := mgr.dash.ReportCrash(dc); err != nil {
@@ -924,6 +986,17 @@ func (mgr *Manager) ResizeReproPool(size int) {
 	mgr.pool.ReserveForRun(size)
 }
 
+func (mgr *Manager) scaleSignals() dispatcher.ScaleSignals {
+	ret := dispatcher.ScaleSignals{
+		HostLoad: osutil.SystemLoad(),
+		ReproVMs: mgr.reproLoop.NeededVMs(),
+	}
+	if fuzzer := mgr.fuzzer.Load(); fuzzer != nil {
+		ret.TriageBacklog = fuzzer.CandidatesToTriage()
+	}
+	return ret
+}
+
 func (mgr *Manager) uploadReproAssets(repro *repro.Result) []dashapi.NewAsset {
 	if mgr.assetStorage == nil {
 		return nil
@@ -971,10 +1044,20 @@ func (mgr *Manager) corpusInputHandler(updates <-chan corpus.NewItemEvent) {
 			}
 			mgr.statCoverFiltered.Add(filtered)
 		}
+		if len(update.NewCover) != 0 && mgr.coverTimeline != nil {
+			if err := mgr.coverTimeline.Add(manager.CoverToPCs(mgr.cfg, update.NewCover), time.Now()); err != nil {
+				log.Errorf("%v", err)
+			}
+		}
 		if update.Exists {
 			// We only save new progs into the corpus.db file.
 			continue
 		}
+		if mgr.coord != nil {
+			if item := mgr.corpus.Item(update.Sig); item != nil {
+				mgr.coord.addInput(item)
+			}
+		}
 		mgr.corpusDBMu.Lock()
 		mgr.corpusDB.Save(update.Sig, update.ProgData, 0)
 		if err := mgr.corpusDB.Flush(); err != nil {
@@ -1159,6 +1242,9 @@ func (mgr *Manager) MachineChecked(features flatrpc.Feature,
 		go mgr.corpusInputHandler(corpusUpdates)
 		go mgr.corpusMinimization()
 		go mgr.fuzzerLoop(fuzzerObj)
+		if mgr.coord != nil {
+			go mgr.coord.loop(fuzzerObj)
+		}
 		if mgr.dash != nil {
 			go mgr.dashboardReporter()
 			if mgr.cfg.Reproduce {
@@ -1274,6 +1360,9 @@ func (mgr *Manager) fuzzerLoop(fuzzer *fuzzer.Fuzzer) {
 			}
 			if len(newSignal) != 0 {
 				mgr.serv.DistributeSignalDelta(newSignal)
+				if mgr.coord != nil {
+					mgr.coord.addSignal(newSignal)
+				}
 			}
 		}
 
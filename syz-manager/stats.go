@@ -49,6 +49,31 @@ func (mgr *Manager) initStats() {
 		func(v int, _ time.Duration) string {
 			return fmt.Sprintf("%v sec", v)
 		})
+	stat.New("vm quarantined", "Number of VM slots quarantined due to repeated boot/infra errors",
+		stat.Graph("vm health"), stat.Prometheus("syz_vm_quarantined"),
+		func() int {
+			if mgr.pool == nil {
+				return 0
+			}
+			count := 0
+			for _, health := range mgr.pool.Health() {
+				if health.Quarantined() {
+					count++
+				}
+			}
+			return count
+		})
+	stat.New("vm quarantines", "Total number of VM slot quarantines", stat.Graph("vm health"),
+		func() int {
+			if mgr.pool == nil {
+				return 0
+			}
+			count := 0
+			for _, health := range mgr.pool.Health() {
+				count += health.Quarantines
+			}
+			return count
+		})
 
 	stat.New("heap", "Process heap size (bytes)", stat.Graph("memory"),
 		func() int {
@@ -40,6 +40,7 @@ var (
 	flagKernelCommit      = flag.String("kernel_commit", "", "original kernel commit")
 	flagKernelCommitTitle = flag.String("kernel_commit_title", "", "original kernel commit title")
 	flagSyzkallerCommit   = flag.String("syzkaller_commit", "", "original syzkaller commit")
+	flagParallel          = flag.Int("parallel", 1, "number of commits built and tested concurrently (cause bisection only)")
 )
 
 type Config struct {
@@ -109,6 +110,7 @@ func main() {
 		BinDir:          mycfg.BinDir,
 		Ccache:          mycfg.Ccache,
 		CrossTree:       mycfg.CrossTree,
+		Parallel:        *flagParallel,
 		Kernel: bisect.KernelConfig{
 			Repo:        mycfg.KernelRepo,
 			Branch:      mycfg.KernelBranch,
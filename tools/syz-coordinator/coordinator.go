@@ -0,0 +1,34 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-coordinator allows several syz-manager processes fuzzing the same kernel
+// to share max signal, corpus and crashes (see coordinator_addr manager config option).
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/google/syzkaller/pkg/coordinator"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/tool"
+)
+
+func main() {
+	var (
+		flagAddr    = flag.String("addr", ":8200", "address to listen on")
+		flagWorkdir = flag.String("workdir", "", "directory to persist the shared corpus and crashes")
+	)
+	defer tool.Init()()
+	if *flagWorkdir == "" {
+		tool.Failf("-workdir is required")
+	}
+	serv, err := coordinator.Listen(*flagWorkdir, *flagAddr)
+	if err != nil {
+		tool.Fail(err)
+	}
+	log.Logf(0, "serving on %v", serv.Addr)
+	if err := serv.Serve(context.Background()); err != nil {
+		tool.Fail(err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/syzkaller/pkg/cover"
+	"github.com/google/syzkaller/pkg/db"
+	"github.com/google/syzkaller/pkg/tool"
+)
+
+// toolQuery prints the smallest programs that cover the -query location.
+func toolQuery() {
+	if *flagLineProgs == "" {
+		tool.Failf("-query requires -lineprogs")
+	}
+	file, line, function, err := parseQuery(*flagQuery)
+	if err != nil {
+		tool.Fail(err)
+	}
+	f, err := os.Open(*flagLineProgs)
+	if err != nil {
+		tool.Fail(err)
+	}
+	defer f.Close()
+	sigs, total, err := queryPrograms(f, file, line, function)
+	if err != nil {
+		tool.Fail(err)
+	}
+	if len(sigs) == 0 {
+		tool.Failf("%v is not covered", *flagQuery)
+	}
+	var records map[string]db.Record
+	if *flagCorpus != "" {
+		corpusDB, err := db.Open(*flagCorpus, false)
+		if err != nil {
+			tool.Failf("failed to open corpus: %v", err)
+		}
+		records = corpusDB.Records
+	}
+	if line != 0 {
+		fmt.Printf("%v is covered by %v programs, the smallest ones:\n\n", *flagQuery, total)
+	} else {
+		fmt.Printf("%v is covered by these programs:\n\n", *flagQuery)
+	}
+	for _, sig := range sigs {
+		fmt.Printf("%v\n", sig)
+		if rec, ok := records[sig]; ok {
+			fmt.Printf("%s\n", rec.Val)
+		}
+	}
+}
+
+// parseQuery parses "file:line" or "file:function".
+func parseQuery(query string) (file string, line int, function string, err error) {
+	pos := strings.LastIndexByte(query, ':')
+	if pos <= 0 || pos == len(query)-1 {
+		return "", 0, "", fmt.Errorf("bad query %q, expected file:line or file:function", query)
+	}
+	file = query[:pos]
+	if line, err = strconv.Atoi(query[pos+1:]); err != nil {
+		line, function, err = 0, query[pos+1:], nil
+	}
+	return
+}
+
+// queryPrograms finds programs that cover the line (the smallest ones) or the function (a small set that
+// covers all of its lines) in the DoLinePrograms output. It also returns the total number of programs
+// that cover the line.
+func queryPrograms(r io.Reader, file string, line int, function string) ([]string, int, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var rec cover.FuncPrograms
+		if err := dec.Decode(&rec); err != nil {
+			if err == io.EOF {
+				return nil, 0, nil
+			}
+			return nil, 0, err
+		}
+		if rec.FilePath != file {
+			continue
+		}
+		if function != "" {
+			if rec.FuncName == function {
+				return rec.Programs, len(rec.Programs), nil
+			}
+			continue
+		}
+		for _, lp := range rec.Lines {
+			if lp.Line == line {
+				return lp.Programs, lp.Total, nil
+			}
+		}
+	}
+}
@@ -62,9 +62,27 @@ var (
 	flagSourceCommit = flag.String("source-commit", "", "[optional] filter input commit")
 	flagExports      = flag.String("exports", "cover",
 		"[optional] comma separated list of exports for which we want to generate coverage, "+
-			"possible values are: cover, subsystem, module, funccover, json, jsonl, rawcover, rawcoverfiles, all")
+			"possible values are: cover, subsystem, module, funccover, json, jsonl, rawcover, rawcoverfiles, "+
+			"lcov, cobertura, llvmjson, diff, all")
 	flagForce = flag.Bool("force", false, "[optional] create coverage report when "+
 		"there are missing coverage callbacks")
+	flagBranches = flag.Bool("branches", false, "[optional] report branch coverage in addition to line coverage "+
+		"(requires disassembling the kernel binary)")
+	flagCallers = flag.Bool("callers", false, "[optional] show the nearest covered callers of uncovered functions "+
+		"and syscalls that reach them (requires scanning the kernel binary)")
+	flagTimeline = flag.String("timeline", "", "[optional] cover_timeline file from the manager workdir "+
+		"(used by -since)")
+	flagSince = flag.Duration("since", 0, "[optional] report only coverage gained during the last period "+
+		"(e.g. 24h) according to -timeline")
+	flagQuery = flag.String("query", "", "[optional] show the smallest corpus programs that cover "+
+		"file:line or file:function according to -lineprogs")
+	flagLineProgs = flag.String("lineprogs", "", "[optional] programs attribution obtained from "+
+		"/cover?lineprogs=1 manager HTTP handler (used by -query)")
+	flagCorpus   = flag.String("corpus", "", "[optional] corpus.db to print the programs found by -query")
+	flagDiffBase = flag.String("diff-base", "", "[optional] comma separated list of raw coverage files "+
+		"to compare the coverage with (used by the diff export)")
+	flagDiffBaseConfig = flag.String("diff-base-config", "", "[optional] configuration file of the kernel build "+
+		"that -diff-base coverage was collected on (by default the same as -config)")
 )
 
 func toolFileCover() {
@@ -124,6 +142,10 @@ func main() {
 		toolFileCover()
 		return
 	}
+	if *flagQuery != "" {
+		toolQuery()
+		return
+	}
 	cfg, err := mgrconfig.LoadFile(*flagConfig)
 	if err != nil {
 		tool.Fail(err)
@@ -134,11 +156,16 @@ func main() {
 		tool.Fail(err)
 	}
 	pcs := initPCs(rg)
+	if *flagSince != 0 {
+		pcs = filterGainedPCs(cfg, pcs)
+	}
 	progs := []cover.Prog{{PCs: pcs}}
 	params := cover.HandlerParams{
-		Progs: progs,
-		Debug: *flagDebug,
-		Force: *flagForce,
+		Progs:    progs,
+		Debug:    *flagDebug,
+		Force:    *flagForce,
+		Branches: *flagBranches,
+		Callers:  *flagCallers,
 	}
 
 	if *flagExports == "all" {
@@ -164,6 +191,17 @@ func main() {
 			doReport(params, "json", rg.DoLineJSON)
 		case "jsonl":
 			doReport(params, "jsonl", rg.DoCoverJSONL)
+		case "lcov":
+			doReport(params, "syz-cover.lcov", rg.DoLCOV)
+		case "cobertura":
+			doReport(params, "syz-cover-cobertura.xml", rg.DoCobertura)
+		case "llvmjson":
+			doReport(params, "syz-cover-llvm.json", rg.DoLLVMCovJSON)
+		case "diff":
+			base, baseParams := initDiffBase(cfg, rg)
+			doReport(params, "syz-cover-diff.txt", func(w io.Writer, params cover.HandlerParams) error {
+				return rg.DoCoverDiff(w, base, baseParams, params)
+			})
 		default:
 			tool.Failf("unknown export type: %q", export)
 		}
@@ -183,6 +221,70 @@ func doReport(params cover.HandlerParams, fname string,
 	exec.Command("xdg-open", fname).Start()
 }
 
+// filterGainedPCs leaves only PCs that were covered for the first time during the last -since period.
+func filterGainedPCs(cfg *mgrconfig.Config, pcs []uint64) []uint64 {
+	if *flagTimeline == "" {
+		tool.Failf("-since requires -timeline")
+	}
+	build, err := cover.TimelineBuild(cfg)
+	if err != nil {
+		tool.Fail(err)
+	}
+	tl, err := cover.ReadTimeline(*flagTimeline, build)
+	if err != nil {
+		tool.Fail(err)
+	}
+	gained := make(map[uint64]bool)
+	for _, pc := range tl.Since(time.Now().Add(-*flagSince)) {
+		gained[pc] = true
+	}
+	var ret []uint64
+	for _, pc := range pcs {
+		if gained[pc] {
+			ret = append(ret, pc)
+		}
+	}
+	if len(ret) == 0 {
+		tool.Failf("no coverage was gained during the last %v", *flagSince)
+	}
+	return ret
+}
+
+func initDiffBase(cfg *mgrconfig.Config, rg *cover.ReportGenerator) (*cover.ReportGenerator, cover.HandlerParams) {
+	if *flagDiffBase == "" {
+		tool.Failf("the diff export requires -diff-base")
+	}
+	pcs, err := readPCs(strings.Split(*flagDiffBase, ","))
+	if err != nil {
+		tool.Fail(err)
+	}
+	params := cover.HandlerParams{
+		Progs: []cover.Prog{{PCs: pcs}},
+		Debug: *flagDebug,
+		Force: *flagForce,
+	}
+	if *flagDiffBaseConfig == "" || *flagDiffBaseConfig == *flagConfig {
+		return rg, params
+	}
+	baseCfg, err := mgrconfig.LoadFile(*flagDiffBaseConfig)
+	if err != nil {
+		tool.Fail(err)
+	}
+	if baseCfg.SysTarget != cfg.SysTarget {
+		tool.Failf("-diff-base-config has a different target")
+	}
+	// -modules describes the new build, so modules of the base build are discovered from its binaries.
+	modules, err := backend.DiscoverModules(baseCfg.SysTarget, baseCfg.KernelObj, baseCfg.ModuleObj)
+	if err != nil {
+		tool.Fail(err)
+	}
+	base, err := cover.MakeReportGenerator(baseCfg, modules)
+	if err != nil {
+		tool.Fail(err)
+	}
+	return base, params
+}
+
 func initPCs(rg *cover.ReportGenerator) []uint64 {
 	var pcs []uint64
 	if len(flag.Args()) == 0 {
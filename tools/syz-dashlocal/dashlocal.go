@@ -0,0 +1,40 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-dashlocal is a self-contained dashboard for on-prem deployments that can't use
+// the App Engine dashboard. Point dashboard_addr in syz-ci/syz-manager configs to it.
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"github.com/google/syzkaller/pkg/config"
+	"github.com/google/syzkaller/pkg/dashlocal"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/tool"
+)
+
+func main() {
+	var (
+		flagAddr    = flag.String("addr", ":8080", "address to listen on")
+		flagWorkdir = flag.String("workdir", "", "directory to store bugs, crashes and reproducers")
+		flagConfig  = flag.String("config", "", "config file with the API clients and UI users (see pkg/dashlocal.Config)")
+	)
+	defer tool.Init()()
+	if *flagWorkdir == "" || *flagConfig == "" {
+		tool.Failf("-workdir and -config are required")
+	}
+	cfg := new(dashlocal.Config)
+	if err := config.LoadFile(*flagConfig, cfg); err != nil {
+		tool.Fail(err)
+	}
+	dash, err := dashlocal.New(cfg, *flagWorkdir)
+	if err != nil {
+		tool.Fail(err)
+	}
+	log.Logf(0, "serving on %v", *flagAddr)
+	if err := http.ListenAndServe(*flagAddr, dash.Handler()); err != nil {
+		tool.Fail(err)
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package container runs the fuzzer inside of an OCI container using runc-compatible
+// runtimes (runc, crun, runsc, etc) without a hypervisor.
+// It's intended for fuzzing of the container runtimes and sandboxes themselves
+// (seccomp policies, user namespaces, gVisor in the container mode, etc).
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/pkg/config"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/vm/vmimpl"
+)
+
+func init() {
+	vmimpl.Register("container", vmimpl.Type{
+		Ctor:       ctor,
+		Overcommit: true,
+	})
+}
+
+type Config struct {
+	Count int `json:"count"` // number of containers to use
+	// Runtime is name or path of the OCI runtime binary (runc by default).
+	Runtime string `json:"runtime"`
+	// RuntimeArgs are additional global runtime flags (e.g. "-platform=kvm" for runsc).
+	RuntimeArgs string `json:"runtime_args"`
+	// Seccomp is an optional path to a JSON file with the OCI "linux.seccomp" section
+	// that is applied to all processes in the container.
+	Seccomp string `json:"seccomp"`
+	// UserNamespace runs the container in a separate user namespace
+	// with the container root mapped to the current host user.
+	UserNamespace    bool   `json:"user_namespace"`
+	MemoryTotalBytes uint64 `json:"memory_total_bytes"`
+	CPUs             uint64 `json:"cpus"`
+}
+
+type Pool struct {
+	env     *vmimpl.Env
+	cfg     *Config
+	seccomp json.RawMessage
+}
+
+type instance struct {
+	cfg      *Config
+	debug    bool
+	stateDir string
+	filesDir string
+	name     string
+	port     int
+	cmd      *exec.Cmd
+	merger   *vmimpl.OutputMerger
+}
+
+func ctor(env *vmimpl.Env) (vmimpl.Pool, error) {
+	cfg := &Config{
+		Count:   1,
+		Runtime: "runc",
+	}
+	if err := config.LoadData(env.Config, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse vm config: %w", err)
+	}
+	if cfg.Count < 1 || cfg.Count > 128 {
+		return nil, fmt.Errorf("invalid config param count: %v, want [1, 128]", cfg.Count)
+	}
+	if _, err := exec.LookPath(cfg.Runtime); err != nil {
+		return nil, fmt.Errorf("bad config param runtime: %w", err)
+	}
+	hostTotalMemory := osutil.SystemMemorySize()
+	minMemory := uint64(cfg.Count) * 10_000_000
+	if cfg.MemoryTotalBytes != 0 && (cfg.MemoryTotalBytes < minMemory || cfg.MemoryTotalBytes > hostTotalMemory) {
+		return nil, fmt.Errorf("invalid config param memory_total_bytes: %v, want [%d,%d]",
+			cfg.MemoryTotalBytes, minMemory, hostTotalMemory)
+	}
+	if env.Image != "" && !osutil.IsDir(env.Image) {
+		return nil, fmt.Errorf("image %q is not a root file system directory", env.Image)
+	}
+	pool := &Pool{
+		cfg: cfg,
+		env: env,
+	}
+	if cfg.Seccomp != "" {
+		data, err := os.ReadFile(cfg.Seccomp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read seccomp profile: %w", err)
+		}
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("seccomp profile %v is not a valid JSON", cfg.Seccomp)
+		}
+		pool.seccomp = data
+	}
+	return pool, nil
+}
+
+func (pool *Pool) Count() int {
+	return pool.cfg.Count
+}
+
+func (pool *Pool) Create(workdir string, index int) (vmimpl.Instance, error) {
+	stateDir := filepath.Clean(filepath.Join(workdir, "..", "container_state"))
+	bundleDir := filepath.Join(workdir, "bundle")
+	filesDir := filepath.Join(workdir, "files")
+	osutil.MkdirAll(stateDir)
+	osutil.MkdirAll(bundleDir)
+	osutil.MkdirAll(filesDir)
+
+	rootfs := pool.env.Image
+	if rootfs == "" {
+		// The fuzzer binaries are static, so an empty root file system is enough.
+		rootfs = filepath.Join(workdir, "rootfs")
+		osutil.MkdirAll(filepath.Join(rootfs, filepath.FromSlash(guestDir)))
+	}
+	name := fmt.Sprintf("%v-%v", pool.env.Name, index)
+	data, err := json.MarshalIndent(pool.spec(name, rootfs, filesDir), "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	if err := osutil.WriteFile(filepath.Join(bundleDir, "config.json"), data); err != nil {
+		return nil, err
+	}
+	bin, err := exec.LookPath(os.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup %v: %w", os.Args[0], err)
+	}
+	if err := osutil.CopyFile(bin, filepath.Join(filesDir, "init")); err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(filesDir, 0777); err != nil {
+		return nil, err
+	}
+
+	rpipe, wpipe, err := osutil.LongPipe()
+	if err != nil {
+		return nil, err
+	}
+	var tee io.Writer
+	if pool.env.Debug {
+		tee = os.Stdout
+	}
+	merger := vmimpl.NewOutputMerger(tee)
+	merger.Add("container", rpipe)
+
+	inst := &instance{
+		cfg:      pool.cfg,
+		debug:    pool.env.Debug,
+		stateDir: stateDir,
+		filesDir: filesDir,
+		name:     name,
+		merger:   merger,
+	}
+
+	// Kill the previous instance in case it's still running (e.g. after a crash of the manager).
+	osutil.Run(time.Minute, inst.runtimeCmd("delete", "--force", inst.name))
+
+	cmd := inst.runtimeCmd("run", "--bundle", bundleDir, inst.name)
+	cmd.Stdout = wpipe
+	cmd.Stderr = wpipe
+	if err := cmd.Start(); err != nil {
+		wpipe.Close()
+		merger.Wait()
+		return nil, err
+	}
+	inst.cmd = cmd
+	wpipe.Close()
+
+	if err := inst.waitBoot(); err != nil {
+		inst.Close()
+		return nil, err
+	}
+	return inst, nil
+}
+
+func (inst *instance) waitBoot() error {
+	timeout := time.NewTimer(time.Minute)
+	defer timeout.Stop()
+	var output []byte
+	for {
+		select {
+		case out := <-inst.merger.Output:
+			output = append(output, out...)
+			if bytes.Contains(output, []byte(initStartMsg)) {
+				return nil
+			}
+		case err := <-inst.merger.Err:
+			return vmimpl.BootError{
+				Title:  fmt.Sprintf("%v failed: %v", filepath.Base(inst.cfg.Runtime), err),
+				Output: output,
+			}
+		case <-timeout.C:
+			return vmimpl.BootError{
+				Title:  "init process did not start",
+				Output: output,
+			}
+		}
+	}
+}
+
+func (inst *instance) args() []string {
+	args := []string{"--root", inst.stateDir}
+	if inst.cfg.RuntimeArgs != "" {
+		args = append(args, strings.Fields(inst.cfg.RuntimeArgs)...)
+	}
+	return args
+}
+
+func (inst *instance) Info() ([]byte, error) {
+	info := fmt.Sprintf("%v %v\n", inst.cfg.Runtime, strings.Join(inst.args(), " "))
+	if version, err := osutil.RunCmd(time.Minute, "", inst.cfg.Runtime, "--version"); err == nil {
+		info += string(version)
+	}
+	return []byte(info), nil
+}
+
+func (inst *instance) runtimeCmd(add ...string) *exec.Cmd {
+	cmd := osutil.Command(inst.cfg.Runtime, append(inst.args(), add...)...)
+	cmd.Env = append(os.Environ(), "GOTRACEBACK=all")
+	return cmd
+}
+
+func (inst *instance) Close() error {
+	osutil.Run(time.Minute, inst.runtimeCmd("delete", "--force", inst.name))
+	inst.cmd.Process.Kill()
+	inst.merger.Wait()
+	inst.cmd.Wait()
+	return nil
+}
+
+func (inst *instance) Forward(port int) (string, error) {
+	if inst.port != 0 {
+		return "", fmt.Errorf("forward port is already setup")
+	}
+	inst.port = port
+	// The container has own network namespace, so we pass the connection via stdin.
+	return "stdin:0", nil
+}
+
+func (inst *instance) Copy(hostSrc string) (string, error) {
+	fname := filepath.Base(hostSrc)
+	if err := osutil.CopyFile(hostSrc, filepath.Join(inst.filesDir, fname)); err != nil {
+		return "", err
+	}
+	return guestDir + "/" + fname, nil
+}
+
+func (inst *instance) Run(ctx context.Context, command string) (
+	<-chan []byte, <-chan error, error) {
+	args := []string{"exec", "--user", "0:0", "--cwd", "/tmp", inst.name}
+	args = append(args, strings.Fields(command)...)
+	cmd := inst.runtimeCmd(args...)
+
+	rpipe, wpipe, err := osutil.LongPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer wpipe.Close()
+	inst.merger.Add("cmd", rpipe)
+	cmd.Stdout = wpipe
+	cmd.Stderr = wpipe
+
+	if inst.port != 0 {
+		guestSock, err := vmimpl.StdinProxy(inst.port)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer guestSock.Close()
+		cmd.Stdin = guestSock
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+	errc := make(chan error, 1)
+	signal := func(err error) {
+		select {
+		case errc <- err:
+		default:
+		}
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			signal(vmimpl.ErrTimeout)
+		case err := <-inst.merger.Err:
+			cmd.Process.Kill()
+			if cmdErr := cmd.Wait(); cmdErr == nil {
+				// If the command exited successfully, we got EOF error from merger.
+				// But in this case no error has happened and the EOF is expected.
+				err = nil
+			}
+			signal(err)
+			return
+		}
+		log.Logf(1, "stopping %s", inst.name)
+		w := make(chan bool)
+		go func() {
+			select {
+			case <-w:
+				return
+			case <-time.After(time.Minute):
+				cmd.Process.Kill()
+			}
+		}()
+		osutil.Run(time.Minute, inst.runtimeCmd("kill", inst.name, "KILL"))
+		err := cmd.Wait()
+		close(w)
+		log.Logf(1, "%s exited with %s", inst.name, err)
+	}()
+	return inst.merger.Output, errc, nil
+}
+
+func (inst *instance) Diagnose(rep *report.Report) ([]byte, bool) {
+	b, err := osutil.Run(time.Minute, inst.runtimeCmd("ps", inst.name))
+	if err != nil {
+		b = append(b, fmt.Sprintf("\n\nError listing processes: %v", err)...)
+	}
+	if filepath.Base(inst.cfg.Runtime) == "runsc" {
+		stacks, err := osutil.Run(time.Minute, inst.runtimeCmd("debug", "-stacks", inst.name))
+		b = append(b, stacks...)
+		if err != nil {
+			b = append(b, fmt.Sprintf("\n\nError collecting stacks: %v", err)...)
+		}
+	}
+	return b, false
+}
+
+const (
+	initStartMsg = "SYZKALLER CONTAINER INIT STARTED\n"
+	initEnv      = "SYZ_CONTAINER_INIT"
+	// guestDir is where the host files directory is mounted inside of the container.
+	guestDir = "/syzkaller"
+)
+
+func init() {
+	if os.Getenv(initEnv) == "" {
+		return
+	}
+	fmt.Fprint(os.Stderr, initStartMsg)
+	// If we do select{}, we can get a deadlock panic.
+	for range time.NewTicker(time.Hour).C {
+	}
+}
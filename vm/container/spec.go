@@ -0,0 +1,151 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package container
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/google/syzkaller/vm/vmimpl"
+)
+
+// The subset of the OCI runtime spec that we need.
+// See https://github.com/opencontainers/runtime-spec/blob/main/config.md
+type ociSpec struct {
+	Version  string     `json:"ociVersion"`
+	Root     ociRoot    `json:"root"`
+	Process  ociProcess `json:"process"`
+	Hostname string     `json:"hostname"`
+	Mounts   []ociMount `json:"mounts"`
+	Linux    ociLinux   `json:"linux"`
+}
+
+type ociRoot struct {
+	Path     string `json:"path"`
+	Readonly bool   `json:"readonly"`
+}
+
+type ociProcess struct {
+	User         ociUser         `json:"user"`
+	Args         []string        `json:"args"`
+	Env          []string        `json:"env"`
+	Cwd          string          `json:"cwd"`
+	Capabilities ociCapabilities `json:"capabilities"`
+}
+
+type ociUser struct {
+	UID uint32 `json:"uid"`
+	GID uint32 `json:"gid"`
+}
+
+type ociCapabilities struct {
+	Bounding    []string `json:"bounding"`
+	Effective   []string `json:"effective"`
+	Inheritable []string `json:"inheritable"`
+	Permitted   []string `json:"permitted"`
+	Ambient     []string `json:"ambient"`
+}
+
+type ociMount struct {
+	Destination string   `json:"destination"`
+	Type        string   `json:"type"`
+	Source      string   `json:"source"`
+	Options     []string `json:"options,omitempty"`
+}
+
+type ociLinux struct {
+	Namespaces  []ociNamespace  `json:"namespaces"`
+	UIDMappings []ociIDMapping  `json:"uidMappings,omitempty"`
+	GIDMappings []ociIDMapping  `json:"gidMappings,omitempty"`
+	CgroupsPath string          `json:"cgroupsPath,omitempty"`
+	Resources   *ociResources   `json:"resources,omitempty"`
+	Seccomp     json.RawMessage `json:"seccomp,omitempty"`
+}
+
+type ociNamespace struct {
+	Type string `json:"type"`
+}
+
+type ociIDMapping struct {
+	ContainerID uint32 `json:"containerID"`
+	HostID      uint32 `json:"hostID"`
+	Size        uint32 `json:"size"`
+}
+
+type ociResources struct {
+	CPU    *ociCPU    `json:"cpu,omitempty"`
+	Memory *ociMemory `json:"memory,omitempty"`
+}
+
+type ociCPU struct {
+	Quota  int64  `json:"quota"`
+	Period uint64 `json:"period"`
+}
+
+type ociMemory struct {
+	Limit int64 `json:"limit"`
+}
+
+func (pool *Pool) spec(name, rootfs, filesDir string) *ociSpec {
+	caps := vmimpl.LinuxCapabilities
+	spec := &ociSpec{
+		Version: "1.0.2",
+		Root: ociRoot{
+			Path:     rootfs,
+			Readonly: true,
+		},
+		Process: ociProcess{
+			Args: []string{guestDir + "/init"},
+			Env:  []string{initEnv + "=1", "PATH=/usr/sbin:/usr/bin:/sbin:/bin"},
+			Cwd:  "/tmp",
+			Capabilities: ociCapabilities{
+				Bounding:    caps,
+				Effective:   caps,
+				Inheritable: caps,
+				Permitted:   caps,
+				Ambient:     caps,
+			},
+		},
+		Hostname: "syzkaller",
+		Mounts: []ociMount{
+			{Destination: "/proc", Type: "proc", Source: "proc"},
+			{Destination: "/dev", Type: "tmpfs", Source: "tmpfs", Options: []string{"nosuid", "mode=755"}},
+			{Destination: "/dev/pts", Type: "devpts", Source: "devpts",
+				Options: []string{"nosuid", "noexec", "newinstance", "ptmxmode=0666", "mode=0620"}},
+			{Destination: "/dev/shm", Type: "tmpfs", Source: "shm", Options: []string{"nosuid", "nodev"}},
+			{Destination: "/sys", Type: "sysfs", Source: "sysfs", Options: []string{"nosuid", "noexec", "nodev", "ro"}},
+			{Destination: "/tmp", Type: "tmpfs", Source: "tmpfs", Options: []string{"nosuid", "nodev"}},
+			{Destination: guestDir, Type: "bind", Source: filesDir, Options: []string{"rbind", "rw"}},
+		},
+		Linux: ociLinux{
+			Namespaces: []ociNamespace{
+				{Type: "pid"}, {Type: "ipc"}, {Type: "uts"}, {Type: "mount"}, {Type: "network"},
+			},
+			Seccomp: pool.seccomp,
+		},
+	}
+	if pool.cfg.UserNamespace {
+		spec.Linux.Namespaces = append(spec.Linux.Namespaces, ociNamespace{Type: "user"})
+		spec.Linux.UIDMappings = []ociIDMapping{{HostID: uint32(os.Getuid()), Size: 1}}
+		spec.Linux.GIDMappings = []ociIDMapping{{HostID: uint32(os.Getgid()), Size: 1}}
+	} else {
+		spec.Linux.CgroupsPath = name
+	}
+	if pool.cfg.MemoryTotalBytes != 0 || pool.cfg.CPUs != 0 {
+		spec.Linux.Resources = new(ociResources)
+	}
+	if pool.cfg.MemoryTotalBytes != 0 {
+		spec.Linux.Resources.Memory = &ociMemory{
+			Limit: int64(pool.cfg.MemoryTotalBytes / uint64(pool.cfg.Count)),
+		}
+	}
+	if pool.cfg.CPUs != 0 {
+		const period = 100000
+		spec.Linux.Resources.CPU = &ociCPU{
+			Quota:  int64(period * pool.cfg.CPUs),
+			Period: period,
+		}
+	}
+	return spec
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package container
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        Config
+		namespaces []string
+		uidMapping []ociIDMapping
+		cgroups    string
+		resources  *ociResources
+	}{
+		{
+			name:       "default",
+			cfg:        Config{Count: 1},
+			namespaces: []string{"pid", "ipc", "uts", "mount", "network"},
+			cgroups:    "syz-0",
+		},
+		{
+			name:       "userns",
+			cfg:        Config{Count: 1, UserNamespace: true},
+			namespaces: []string{"pid", "ipc", "uts", "mount", "network", "user"},
+			uidMapping: []ociIDMapping{{HostID: uint32(os.Getuid()), Size: 1}},
+		},
+		{
+			name:       "memory",
+			cfg:        Config{Count: 4, MemoryTotalBytes: 4 << 30},
+			namespaces: []string{"pid", "ipc", "uts", "mount", "network"},
+			cgroups:    "syz-0",
+			resources:  &ociResources{Memory: &ociMemory{Limit: 1 << 30}},
+		},
+		{
+			name:       "cpu",
+			cfg:        Config{Count: 2, CPUs: 3},
+			namespaces: []string{"pid", "ipc", "uts", "mount", "network"},
+			cgroups:    "syz-0",
+			resources:  &ociResources{CPU: &ociCPU{Quota: 300000, Period: 100000}},
+		},
+		{
+			name:       "userns-resources",
+			cfg:        Config{Count: 2, UserNamespace: true, MemoryTotalBytes: 2 << 30, CPUs: 1},
+			namespaces: []string{"pid", "ipc", "uts", "mount", "network", "user"},
+			uidMapping: []ociIDMapping{{HostID: uint32(os.Getuid()), Size: 1}},
+			resources: &ociResources{
+				Memory: &ociMemory{Limit: 1 << 30},
+				CPU:    &ociCPU{Quota: 100000, Period: 100000},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &Pool{cfg: &test.cfg}
+			spec := pool.spec("syz-0", "/rootfs", "/files")
+			var namespaces []string
+			for _, ns := range spec.Linux.Namespaces {
+				namespaces = append(namespaces, ns.Type)
+			}
+			assert.Equal(t, test.namespaces, namespaces)
+			assert.Equal(t, test.uidMapping, spec.Linux.UIDMappings)
+			assert.Equal(t, len(test.uidMapping), len(spec.Linux.GIDMappings))
+			assert.Equal(t, test.cgroups, spec.Linux.CgroupsPath)
+			assert.Equal(t, test.resources, spec.Linux.Resources)
+			assert.Equal(t, "/rootfs", spec.Root.Path)
+			assert.True(t, spec.Root.Readonly)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/syzkaller/pkg/log"
+)
+
+type AutoscaleConfig struct {
+	// The bounds for the number of active instances.
+	// Max == 0 means the total number of instances in the pool.
+	Min int
+	Max int
+	// How often the decision is re-evaluated.
+	Interval time.Duration
+	// The host is considered overloaded if the load average per CPU exceeds the value.
+	MaxHostLoad float64
+	// The triage backlog above this value is considered a pressure to grow.
+	TriageBacklog int
+}
+
+// ScaleSignals describes the inputs for the autoscaling decisions.
+type ScaleSignals struct {
+	// Host load average per CPU.
+	HostLoad float64
+	// The number of instances that are needed for bug reproduction.
+	ReproVMs int
+	// The number of inputs waiting to be triaged.
+	TriageBacklog int
+}
+
+type ScaleDecision struct {
+	Time    time.Time
+	Active  int
+	Min     int
+	Max     int
+	Reason  string
+	Signals ScaleSignals
+}
+
+// Without a queue pressure, we only grow while the host load stays well below
+// the limit to leave some headroom for other tenants of the machine.
+const idleLoadFraction = 0.8
+
+// NextActive calculates the new number of active instances given the current one.
+func NextActive(cfg AutoscaleConfig, active int, signals ScaleSignals) (int, string) {
+	// The host load includes the load generated by our own instances,
+	// so we estimate by how much one more instance would increase it.
+	perInstance := 0.0
+	if active > 0 {
+		perInstance = signals.HostLoad / float64(active)
+	}
+	step := max(1, (cfg.Max-cfg.Min)/8)
+	pressure := signals.ReproVMs > 0 || signals.TriageBacklog > cfg.TriageBacklog
+	next, reason := active, "steady"
+	switch {
+	case active < cfg.Min:
+		next, reason = cfg.Min, "below the lower bound"
+	case active > cfg.Max:
+		next, reason = cfg.Max, "above the upper bound"
+	case signals.HostLoad > cfg.MaxHostLoad:
+		next = active - step
+		reason = fmt.Sprintf("host load %.2f exceeds %.2f", signals.HostLoad, cfg.MaxHostLoad)
+	case pressure && signals.HostLoad+perInstance <= cfg.MaxHostLoad:
+		next = active + step
+		reason = fmt.Sprintf("queue pressure: %v repro VMs needed, %v inputs to triage",
+			signals.ReproVMs, signals.TriageBacklog)
+	case !pressure && signals.HostLoad+perInstance <= cfg.MaxHostLoad*idleLoadFraction:
+		next = active + step
+		reason = fmt.Sprintf("idle host: load %.2f", signals.HostLoad)
+	}
+	next = max(cfg.Min, min(cfg.Max, next))
+	if next == active && reason != "steady" {
+		reason = "at the bound, " + reason
+	}
+	return next, reason
+}
+
+// Autoscale periodically adjusts the number of active instances (see SetActive)
+// based on the signals returned by the callback.
+// The function blocks until the context is cancelled.
+func (p *Pool[T]) Autoscale(ctx context.Context, cfg AutoscaleConfig, signals func() ScaleSignals) {
+	if cfg.Max == 0 {
+		cfg.Max = p.Total()
+	}
+	cfg.Min = max(1, min(cfg.Min, cfg.Max))
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Minute
+	}
+	p.SetActive(cfg.Min)
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		current := p.Active()
+		vals := signals()
+		next, reason := NextActive(cfg, current, vals)
+		if next != current {
+			log.Logf(0, "autoscaling VMs %v -> %v: %v", current, next, reason)
+			p.SetActive(next)
+		}
+		p.mu.Lock()
+		p.decision = &ScaleDecision{
+			Time:    time.Now(),
+			Active:  next,
+			Min:     cfg.Min,
+			Max:     cfg.Max,
+			Reason:  reason,
+			Signals: vals,
+		}
+		p.mu.Unlock()
+	}
+}
+
+// LastScaleDecision returns the latest decision made by Autoscale or nil.
+func (p *Pool[T]) LastScaleDecision() *ScaleDecision {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.decision
+}
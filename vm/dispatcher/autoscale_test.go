@@ -0,0 +1,86 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextActive(t *testing.T) {
+	cfg := AutoscaleConfig{
+		Min:           2,
+		Max:           10,
+		MaxHostLoad:   0.9,
+		TriageBacklog: 100,
+	}
+	tests := []struct {
+		name    string
+		active  int
+		signals ScaleSignals
+		next    int
+	}{
+		{
+			name:    "below min",
+			active:  0,
+			signals: ScaleSignals{HostLoad: 2.0},
+			next:    2,
+		},
+		{
+			name:    "overloaded",
+			active:  5,
+			signals: ScaleSignals{HostLoad: 1.5},
+			next:    4,
+		},
+		{
+			name:    "overloaded at min",
+			active:  2,
+			signals: ScaleSignals{HostLoad: 1.5},
+			next:    2,
+		},
+		{
+			name:    "idle",
+			active:  4,
+			signals: ScaleSignals{HostLoad: 0.2},
+			next:    5,
+		},
+		{
+			name:    "busy without pressure",
+			active:  4,
+			signals: ScaleSignals{HostLoad: 0.7},
+			next:    4,
+		},
+		{
+			name:    "busy with repro pressure",
+			active:  4,
+			signals: ScaleSignals{HostLoad: 0.7, ReproVMs: 2},
+			next:    5,
+		},
+		{
+			name:    "busy with triage pressure",
+			active:  4,
+			signals: ScaleSignals{HostLoad: 0.7, TriageBacklog: 1000},
+			next:    5,
+		},
+		{
+			name:    "pressure would overload",
+			active:  4,
+			signals: ScaleSignals{HostLoad: 0.8, TriageBacklog: 1000},
+			next:    4,
+		},
+		{
+			name:    "at max",
+			active:  10,
+			signals: ScaleSignals{HostLoad: 0.1, ReproVMs: 3},
+			next:    10,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next, reason := NextActive(cfg, test.active, test.signals)
+			assert.Equal(t, test.next, next, reason)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package dispatcher
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/syzkaller/pkg/log"
+)
+
+type HealthEvent int
+
+const (
+	// The instance has finished a run without problems.
+	HealthOK HealthEvent = iota
+	HealthBootError
+	HealthInfraError
+	// The instance crashed too soon after the start.
+	HealthFastCrash
+	HealthNoOutput
+	HealthLostConnection
+	healthEventCount
+)
+
+func (ev HealthEvent) String() string {
+	return [...]string{"ok", "boot error", "infra error", "fast crash",
+		"no output", "lost connection"}[ev]
+}
+
+// Health summarizes the recent history of problems with a pool slot.
+// Since the same instance index is reused by all instances booted in the slot,
+// the problems that persist across reboots (e.g. a broken physical device)
+// eventually accumulate and the slot is quarantined for some time.
+type Health struct {
+	// Score is in (0, 1], 1 means a perfectly healthy instance.
+	Score            float64
+	Events           [healthEventCount]int
+	Quarantines      int
+	QuarantinedUntil time.Time
+}
+
+func (h Health) Quarantined() bool {
+	return time.Now().Before(h.QuarantinedUntil)
+}
+
+func (h Health) String() string {
+	ret := fmt.Sprintf("%.2f", h.Score)
+	if h.Quarantined() {
+		ret += fmt.Sprintf(" [quarantined for %v]", time.Until(h.QuarantinedUntil).Round(time.Second))
+	}
+	return ret
+}
+
+var healthPenalty = [healthEventCount]float64{
+	HealthBootError:      1,
+	HealthInfraError:     2,
+	HealthFastCrash:      0.5,
+	HealthNoOutput:       1,
+	HealthLostConnection: 1,
+}
+
+const (
+	// The penalty points halve every healthHalfLife.
+	healthHalfLife = time.Hour
+	// The slot is quarantined once it has accumulated that many penalty points...
+	quarantineThreshold = 5.0
+	// ...and it's at least that many times worse than a typical slot in the pool.
+	// This way we don't quarantine all slots if the kernel itself is broken.
+	quarantineRatio = 3.0
+	// The quarantine duration doubles with each subsequent quarantine.
+	quarantineMin = 10 * time.Minute
+	quarantineMax = 4 * time.Hour
+)
+
+// slotHealth is protected by poolInstance.mu.
+type slotHealth struct {
+	penalty float64
+	updated time.Time
+	// The number of quarantines since the last successful run.
+	streak int
+	Health
+}
+
+func (sh *slotHealth) decay(now time.Time) {
+	if !sh.updated.IsZero() {
+		sh.penalty *= math.Pow(0.5, float64(now.Sub(sh.updated))/float64(healthHalfLife))
+	}
+	sh.updated = now
+}
+
+func (sh *slotHealth) snapshot(now time.Time) Health {
+	sh.decay(now)
+	ret := sh.Health
+	ret.Score = 1 / (1 + sh.penalty)
+	return ret
+}
+
+// ReportHealth records an event that affects the health of the idx-th pool slot.
+func (p *Pool[T]) ReportHealth(idx int, ev HealthEvent) {
+	if idx < 0 || idx >= len(p.instances) {
+		return
+	}
+	now := time.Now()
+	// Collect the penalties of the other slots before taking the instance lock.
+	var others []float64
+	for i, inst := range p.instances {
+		if i != idx {
+			inst.mu.Lock()
+			inst.health.decay(now)
+			others = append(others, inst.health.penalty)
+			inst.mu.Unlock()
+		}
+	}
+	inst := p.instances[idx]
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	sh := &inst.health
+	sh.decay(now)
+	sh.Events[ev]++
+	if ev == HealthOK {
+		sh.penalty /= 2
+		if !sh.Quarantined() {
+			sh.streak = 0
+		}
+		return
+	}
+	sh.penalty += healthPenalty[ev]
+	if sh.Quarantined() || sh.penalty < quarantineThreshold || len(others) == 0 {
+		return
+	}
+	slices.Sort(others)
+	median := others[len(others)/2]
+	if sh.penalty < median*quarantineRatio {
+		return
+	}
+	duration := min(quarantineMax, quarantineMin<<min(sh.streak, 16))
+	sh.streak++
+	sh.Quarantines++
+	sh.QuarantinedUntil = now.Add(duration)
+	// Give the slot a chance to recover after the quarantine.
+	sh.penalty = quarantineThreshold / 2
+	log.Logf(0, "VM %v: quarantined for %v after %v", idx, duration, ev)
+}
+
+// Health returns the health of all pool slots.
+func (p *Pool[T]) Health() []Health {
+	now := time.Now()
+	ret := make([]Health, len(p.instances))
+	for i, inst := range p.instances {
+		inst.mu.Lock()
+		ret[i] = inst.health.snapshot(now)
+		inst.mu.Unlock()
+	}
+	return ret
+}
+
+func (pi *poolInstance[T]) quarantineLeft() time.Duration {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	return time.Until(pi.health.QuarantinedUntil)
+}
+
+func bootErrorEvent(err error) HealthEvent {
+	var bootErr interface {
+		BootError() (string, []byte)
+	}
+	if errors.As(err, &bootErr) {
+		return HealthBootError
+	}
+	// By default, all instance creation errors are infrastructure problems.
+	return HealthInfraError
+}
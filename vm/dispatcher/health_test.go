@@ -0,0 +1,84 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package dispatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newHealthTestPool(count int) *Pool[*nilInstance] {
+	return NewPool[*nilInstance](
+		count,
+		func(idx int) (*nilInstance, error) {
+			return &nilInstance{}, nil
+		},
+		func(ctx context.Context, _ *nilInstance, _ UpdateInfo) {
+			<-ctx.Done()
+		},
+	)
+}
+
+func TestHealthQuarantine(t *testing.T) {
+	mgr := newHealthTestPool(4)
+	for i := 0; i < 4; i++ {
+		mgr.ReportHealth(0, HealthInfraError)
+		mgr.ReportHealth(1, HealthOK)
+		mgr.ReportHealth(2, HealthFastCrash)
+	}
+	health := mgr.Health()
+	assert.True(t, health[0].Quarantined())
+	assert.Equal(t, 1, health[0].Quarantines)
+	assert.Equal(t, 4, health[0].Events[HealthInfraError])
+	for i := 1; i < 4; i++ {
+		assert.False(t, health[i].Quarantined())
+	}
+	assert.Less(t, health[0].Score, health[2].Score)
+	assert.Less(t, health[2].Score, health[1].Score)
+	assert.Equal(t, 1.0, health[3].Score)
+
+	info := mgr.State()
+	assert.True(t, info[0].Health.Quarantined())
+}
+
+func TestHealthBrokenKernel(t *testing.T) {
+	// If all instances fail to boot, it's not the problem of a specific slot.
+	mgr := newHealthTestPool(4)
+	for i := 0; i < 10; i++ {
+		for idx := 0; idx < 4; idx++ {
+			mgr.ReportHealth(idx, HealthBootError)
+		}
+	}
+	for _, health := range mgr.Health() {
+		assert.False(t, health.Quarantined())
+		assert.Equal(t, 10, health.Events[HealthBootError])
+	}
+}
+
+func TestHealthSingleInstance(t *testing.T) {
+	mgr := newHealthTestPool(1)
+	for i := 0; i < 10; i++ {
+		mgr.ReportHealth(0, HealthNoOutput)
+	}
+	assert.False(t, mgr.Health()[0].Quarantined())
+}
+
+func TestHealthReserveSkipsQuarantined(t *testing.T) {
+	mgr := newHealthTestPool(4)
+	for i := 0; i < 4; i++ {
+		mgr.ReportHealth(0, HealthInfraError)
+		mgr.ReportHealth(1, HealthOK)
+	}
+	assert.True(t, mgr.Health()[0].Quarantined())
+	mgr.ReserveForRun(3)
+	var reserved []int
+	for i, info := range mgr.State() {
+		if info.Reserved {
+			reserved = append(reserved, i)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3}, reserved)
+}
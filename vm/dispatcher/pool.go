@@ -33,11 +33,14 @@ type Pool[T Instance] struct {
 	defaultJob Runner[T]
 	jobs       chan Runner[T]
 
-	// The mutex serializes ReserveForRun() and SetDefault() calls.
+	// The mutex serializes ReserveForRun(), SetDefault() and SetActive() calls.
 	mu        *sync.Mutex
 	cv        *sync.Cond
 	instances []*poolInstance[T]
 	paused    bool
+	// The number of instances that are allowed to run (see SetActive).
+	active   int
+	decision *ScaleDecision
 }
 
 func NewPool[T Instance](count int, creator CreateInstance[T], def Runner[T]) *Pool[T] {
@@ -56,6 +59,7 @@ func NewPool[T Instance](count int, creator CreateInstance[T], def Runner[T]) *P
 		creator:    creator,
 		defaultJob: def,
 		instances:  instances,
+		active:     count,
 		jobs:       make(chan Runner[T]),
 		mu:         mu,
 		cv:         sync.NewCond(mu),
@@ -89,12 +93,71 @@ func (p *Pool[T]) TogglePause(paused bool) {
 	}
 }
 
-func (p *Pool[T]) waitUnpaused() {
+// waitUnpaused returns false if the context was cancelled while waiting.
+// Otherwise it installs the stop callback of the instance under the same lock,
+// so that the instance cannot be parked unnoticed before it boots.
+func (p *Pool[T]) waitUnpaused(ctx context.Context, inst *poolInstance[T], stop func()) bool {
+	stopWake := context.AfterFunc(ctx, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.cv.Broadcast()
+	})
+	defer stopWake()
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	for p.paused {
+	for (p.paused || inst.parked) && ctx.Err() == nil {
 		p.cv.Wait()
 	}
+	if ctx.Err() != nil {
+		return false
+	}
+	inst.reset(stop)
+	return true
+}
+
+// SetActive limits the number of instances that are booted and run jobs.
+// The instances that are reserved by ReserveForRun are always active,
+// the rest of the active slots are given to the default runner.
+// The remaining instances are parked: they are shut down and not re-created until
+// the limit is increased.
+func (p *Pool[T]) SetActive(count int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active = max(0, min(count, len(p.instances)))
+	p.parkLocked()
+}
+
+// Active returns the current limit set by SetActive.
+func (p *Pool[T]) Active() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.active
+}
+
+func (p *Pool[T]) parkLocked() {
+	free := p.active
+	for _, inst := range p.instances {
+		if inst.reserved() {
+			free--
+		}
+	}
+	unparked := false
+	for _, inst := range p.instances {
+		park := false
+		if !inst.reserved() {
+			park = free <= 0
+			free--
+		}
+		if park == inst.parked {
+			continue
+		}
+		log.Logf(2, "pool: instance %d parked=%v", inst.idx, park)
+		inst.park(park)
+		unparked = unparked || !park
+	}
+	if unparked {
+		p.cv.Broadcast()
+	}
 }
 
 func (p *Pool[T]) Loop(ctx context.Context) {
@@ -112,19 +175,28 @@ func (p *Pool[T]) Loop(ctx context.Context) {
 }
 
 func (p *Pool[T]) runInstance(ctx context.Context, inst *poolInstance[T]) {
-	p.waitUnpaused()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	if !p.waitUnpaused(ctx, inst, cancel) {
+		return
+	}
+	if wait := inst.quarantineLeft(); wait > 0 {
+		log.Logf(2, "pool: instance %d is quarantined for %v", inst.idx, wait)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return
+		}
+	}
 	log.Logf(2, "pool: booting instance %d", inst.idx)
 
-	inst.reset(cancel)
-
 	start := time.Now()
 	inst.status(StateBooting)
 	defer inst.status(StateOffline)
 
 	obj, err := p.creator(inst.idx)
 	if err != nil {
+		p.ReportHealth(inst.idx, bootErrorEvent(err))
 		p.BootErrors <- err
 		return
 	}
@@ -164,14 +236,21 @@ func (p *Pool[T]) ReserveForRun(count int) {
 		panic("trying to reserve more VMs than present")
 	}
 
-	var free, reserved []*poolInstance[T]
+	var free, parked, quarantined, reserved []*poolInstance[T]
 	for _, inst := range p.instances {
 		if inst.reserved() {
 			reserved = append(reserved, inst)
+		} else if inst.quarantineLeft() > 0 {
+			quarantined = append(quarantined, inst)
+		} else if inst.parked {
+			parked = append(parked, inst)
 		} else {
 			free = append(free, inst)
 		}
 	}
+	// Prefer to take already running instances.
+	// The quarantined ones would not boot for a long time, so take them last.
+	free = append(append(free, parked...), quarantined...)
 
 	needReserve := count - len(reserved)
 	for i := 0; i < needReserve; i++ {
@@ -184,6 +263,7 @@ func (p *Pool[T]) ReserveForRun(count int) {
 		log.Logf(2, "pool: releasing instance %d", reserved[i].idx)
 		reserved[i].free(p.defaultJob)
 	}
+	p.parkLocked()
 }
 
 // Run blocks until it has found an instance to execute job and until job has finished.
@@ -216,6 +296,9 @@ type Info struct {
 	Status     string
 	LastUpdate time.Time
 	Reserved   bool
+	// Parked instances are not running because of the SetActive limit.
+	Parked bool
+	Health Health
 
 	// The optional callbacks.
 	MachineInfo    func() []byte
@@ -244,6 +327,9 @@ type poolInstance[T Instance] struct {
 	jobChan     chan Runner[T]
 	switchToJob chan Runner[T]
 	stop        func()
+	health      slotHealth
+	// Protected by Pool.mu.
+	parked bool
 }
 
 type InstanceState int
@@ -265,6 +351,7 @@ func (pi *poolInstance[T]) reset(stop func()) {
 		State:      StateOffline,
 		LastUpdate: time.Now(),
 		Reserved:   pi.info.Reserved,
+		Parked:     pi.info.Parked,
 	}
 	pi.stop = stop
 	pi.switchToJob = make(chan Runner[T])
@@ -290,7 +377,9 @@ func (pi *poolInstance[T]) reserved() bool {
 func (pi *poolInstance[T]) getInfo() Info {
 	pi.mu.Lock()
 	defer pi.mu.Unlock()
-	return pi.info
+	info := pi.info
+	info.Health = pi.health.snapshot(time.Now())
+	return info
 }
 
 func (pi *poolInstance[T]) reserve(ch chan Runner[T]) {
@@ -303,6 +392,16 @@ func (pi *poolInstance[T]) reserve(ch chan Runner[T]) {
 	pi.mu.Unlock()
 }
 
+func (pi *poolInstance[T]) park(park bool) {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	if park {
+		pi.stop()
+	}
+	pi.parked = park
+	pi.info.Parked = park
+}
+
 func (pi *poolInstance[T]) free(job Runner[T]) {
 	pi.mu.Lock()
 	if pi.job != nil {
@@ -286,6 +286,99 @@ func TestPoolCancelRun(t *testing.T) {
 	wg.Wait()
 }
 
+func TestPoolActive(t *testing.T) {
+	var running atomic.Int64
+	mgr := NewPool[*nilInstance](
+		10,
+		func(idx int) (*nilInstance, error) {
+			return &nilInstance{}, nil
+		},
+		func(ctx context.Context, _ *nilInstance, _ UpdateInfo) {
+			running.Add(1)
+			<-ctx.Done()
+			running.Add(-1)
+		},
+	)
+	done := make(chan bool)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		mgr.Loop(ctx)
+		close(done)
+	}()
+	waitRunning := func(count int64) {
+		for running.Load() != count {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitRunning(10)
+
+	mgr.SetActive(3)
+	waitRunning(3)
+	parked := 0
+	for _, info := range mgr.State() {
+		if info.Parked {
+			parked++
+		}
+	}
+	assert.Equal(t, 7, parked)
+
+	// Reserved instances are taken from the active ones.
+	mgr.ReserveForRun(2)
+	waitRunning(1)
+
+	// Reservations beyond the active limit unpark instances.
+	mgr.ReserveForRun(5)
+	waitRunning(0)
+	started := make(chan bool)
+	for i := 0; i < 5; i++ {
+		go mgr.Run(ctx, func(ctx context.Context, _ *nilInstance, _ UpdateInfo) {
+			started <- true
+		})
+	}
+	for i := 0; i < 5; i++ {
+		<-started
+	}
+
+	mgr.ReserveForRun(0)
+	mgr.SetActive(10)
+	waitRunning(10)
+
+	cancel()
+	<-done
+}
+
+func TestPoolCancelParked(t *testing.T) {
+	var running atomic.Int64
+	mgr := NewPool[*nilInstance](
+		10,
+		func(idx int) (*nilInstance, error) {
+			return &nilInstance{}, nil
+		},
+		func(ctx context.Context, _ *nilInstance, _ UpdateInfo) {
+			running.Add(1)
+			<-ctx.Done()
+			running.Add(-1)
+		},
+	)
+	mgr.SetActive(2)
+	done := make(chan bool)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		mgr.Loop(ctx)
+		close(done)
+	}()
+	for running.Load() != 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The parked instances must not prevent the loop from exiting.
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Loop did not return after the context was cancelled")
+	}
+}
+
 func makePool(count int) []testInstance {
 	var ret []testInstance
 	for i := 0; i < count; i++ {
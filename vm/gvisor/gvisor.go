@@ -10,7 +10,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -374,27 +373,7 @@ func (inst *instance) guestProxy() (*os.File, error) {
 	// We create a unix socket, pass it to guest in stdin.
 	// Guest will use it instead of dialing manager directly.
 	// On host we connect to manager tcp port and proxy between the tcp and unix connections.
-	socks, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
-	if err != nil {
-		return nil, err
-	}
-	hostSock := os.NewFile(uintptr(socks[0]), "host unix proxy")
-	guestSock := os.NewFile(uintptr(socks[1]), "guest unix proxy")
-	conn, err := net.Dial("tcp", fmt.Sprintf("localhost:%v", inst.port))
-	if err != nil {
-		hostSock.Close()
-		guestSock.Close()
-		return nil, err
-	}
-	go func() {
-		io.Copy(hostSock, conn)
-		hostSock.Close()
-	}()
-	go func() {
-		io.Copy(conn, hostSock)
-		conn.Close()
-	}()
-	return guestSock, nil
+	return vmimpl.StdinProxy(inst.port)
 }
 
 func (inst *instance) Diagnose(rep *report.Report) ([]byte, bool) {
@@ -461,13 +440,4 @@ const configTempl = `
 }
 `
 
-var sandboxCaps = []string{
-	"CAP_CHOWN", "CAP_DAC_OVERRIDE", "CAP_DAC_READ_SEARCH", "CAP_FOWNER", "CAP_FSETID",
-	"CAP_KILL", "CAP_SETGID", "CAP_SETUID", "CAP_SETPCAP", "CAP_LINUX_IMMUTABLE",
-	"CAP_NET_BIND_SERVICE", "CAP_NET_BROADCAST", "CAP_NET_ADMIN", "CAP_NET_RAW",
-	"CAP_IPC_LOCK", "CAP_IPC_OWNER", "CAP_SYS_MODULE", "CAP_SYS_RAWIO", "CAP_SYS_CHROOT",
-	"CAP_SYS_PTRACE", "CAP_SYS_PACCT", "CAP_SYS_ADMIN", "CAP_SYS_BOOT", "CAP_SYS_NICE",
-	"CAP_SYS_RESOURCE", "CAP_SYS_TIME", "CAP_SYS_TTY_CONFIG", "CAP_MKNOD", "CAP_LEASE",
-	"CAP_AUDIT_WRITE", "CAP_AUDIT_CONTROL", "CAP_SETFCAP", "CAP_MAC_OVERRIDE", "CAP_MAC_ADMIN",
-	"CAP_SYSLOG", "CAP_WAKE_ALARM", "CAP_BLOCK_SUSPEND", "CAP_AUDIT_READ",
-}
+var sandboxCaps = vmimpl.LinuxCapabilities
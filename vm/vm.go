@@ -33,6 +33,7 @@ import (
 	// Import all VM implementations, so that users only need to import vm.
 	_ "github.com/google/syzkaller/vm/adb"
 	_ "github.com/google/syzkaller/vm/bhyve"
+	_ "github.com/google/syzkaller/vm/container"
 	_ "github.com/google/syzkaller/vm/cuttlefish"
 	_ "github.com/google/syzkaller/vm/gce"
 	_ "github.com/google/syzkaller/vm/gvisor"
@@ -524,6 +525,12 @@ func (mon *monitor) waitForOutput() {
 	}
 }
 
+// IsNoOutputReport returns whether the report was generated because the instance
+// stopped producing any output.
+func IsNoOutputReport(rep *report.Report) bool {
+	return rep.Title == noOutputCrash
+}
+
 const (
 	maxErrorLength = 256
 
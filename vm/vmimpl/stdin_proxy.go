@@ -0,0 +1,41 @@
+// Copyright 2025 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+//go:build !windows
+
+package vmimpl
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+)
+
+// StdinProxy creates a unix socket connected to the host tcp port.
+// The returned file is meant to be passed as stdin of the guest process,
+// the guest then uses "stdin:0" address instead of dialing the host directly.
+// This is useful for sandboxes where the guest cannot reach host tcp ports.
+func StdinProxy(port int) (*os.File, error) {
+	socks, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		return nil, err
+	}
+	hostSock := os.NewFile(uintptr(socks[0]), "host unix proxy")
+	guestSock := os.NewFile(uintptr(socks[1]), "guest unix proxy")
+	conn, err := net.Dial("tcp", fmt.Sprintf("localhost:%v", port))
+	if err != nil {
+		hostSock.Close()
+		guestSock.Close()
+		return nil, err
+	}
+	go func() {
+		io.Copy(hostSock, conn)
+		hostSock.Close()
+	}()
+	go func() {
+		io.Copy(conn, hostSock)
+		conn.Close()
+	}()
+	return guestSock, nil
+}